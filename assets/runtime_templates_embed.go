@@ -5,5 +5,5 @@ package assets
 
 import "embed"
 
-//go:embed runtime-templates/python/templates/*.tmpl runtime-templates/java/templates/*.tmpl
+//go:embed runtime-templates/python/templates/*.tmpl runtime-templates/java/templates/*.tmpl runtime-templates/nodejs/templates/*.tmpl
 var RuntimeTemplatesFS embed.FS
@@ -4,8 +4,11 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/poruru-code/esb-cli/internal/app"
 	"github.com/poruru-code/esb-cli/internal/command"
@@ -13,6 +16,8 @@ import (
 
 // main is the entry point for the ESB CLI. It builds the dependencies,
 // parses command-line arguments, and dispatches to the appropriate command handler.
+// The first Ctrl-C (or SIGTERM) cancels the command context so the running
+// command can stop docker and clean up; a second one terminates immediately.
 func main() {
 	args := os.Args[1:]
 	deps, closer, err := app.BuildDependencies(args)
@@ -20,7 +25,14 @@ func main() {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+	deps.Context = ctx
 	exitCode := command.Run(args, deps)
+	stop()
 	if closer != nil {
 		if err := closer.Close(); err != nil {
 			fmt.Fprintln(os.Stderr, err)
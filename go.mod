@@ -3,6 +3,7 @@ module github.com/poruru-code/esb-cli
 go 1.25.1
 
 require (
+	github.com/BurntSushi/toml v1.5.0
 	github.com/Masterminds/sprig/v3 v3.3.0
 	github.com/alecthomas/kong v1.6.0
 	github.com/charmbracelet/huh v0.8.0
@@ -5,8 +5,11 @@ package app
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"io"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -56,13 +59,21 @@ func (composePortDiscoverer) Discover(ctx context.Context, rootDir, project, mod
 
 // BuildDependencies constructs CLI dependencies. It returns the dependencies
 // bundle, a closer for cleanup, and any initialization error.
-func BuildDependencies(_ []string) (command.Dependencies, io.Closer, error) {
+func BuildDependencies(args []string) (command.Dependencies, io.Closer, error) {
 	builder := build.NewGoBuilder(composePortDiscoverer{})
 	composeRunner := compose.ExecRunner{}
+	if command.JSONOutputRequested(args) {
+		// stdout carries the JSON event stream; keep build and compose output off it.
+		builder.Out = os.Stderr
+		builder.Runner = compose.ExecRunner{Out: os.Stderr}
+		builder.ComposeRunner = compose.ExecRunner{Out: os.Stderr}
+		composeRunner = compose.ExecRunner{Out: os.Stderr}
+	}
 
 	deps := command.Dependencies{
 		Out:          os.Stdout,
 		ErrOut:       os.Stderr,
+		In:           os.Stdin,
 		Prompter:     interaction.HuhPrompter{},
 		RepoResolver: config.ResolveRepoRoot,
 		Deploy: command.DeployDeps{
@@ -70,6 +81,21 @@ func BuildDependencies(_ []string) (command.Dependencies, io.Closer, error) {
 			Runtime:   newDeployRuntimeDeps(config.ResolveRepoRoot),
 			Provision: newDeployProvisionDeps(composeRunner),
 		},
+		Invoke: command.InvokeDeps{
+			DockerClient: compose.NewDockerClient,
+			HTTPClient:   newInvokeHTTPClient(),
+		},
+		Logs: command.LogsDeps{
+			DockerClient: compose.NewDockerClient,
+			HTTPClient:   http.DefaultClient,
+		},
+		Artifact: command.ArtifactDeps{
+			Runner: composeRunner,
+		},
+		Stack: command.StackDeps{
+			Runner:       composeRunner,
+			DockerClient: compose.NewDockerClient,
+		},
 	}
 
 	return deps, nil, nil
@@ -104,3 +130,21 @@ func newDeployProvisionDeps(composeRunner compose.CommandRunner) command.DeployP
 		NewDeployUI:               deployUIFactory,
 	}
 }
+
+// newInvokeHTTPClient trusts the ESB root CA when it can be found so that
+// invoke can verify the gateway certificate; otherwise system roots are used.
+func newInvokeHTTPClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if caPath, err := build.ResolveRootCAPath(); err == nil {
+		if pem, err := os.ReadFile(caPath); err == nil {
+			pool, err := x509.SystemCertPool()
+			if err != nil || pool == nil {
+				pool = x509.NewCertPool()
+			}
+			if pool.AppendCertsFromPEM(pem) {
+				transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+			}
+		}
+	}
+	return &http.Client{Transport: transport}
+}
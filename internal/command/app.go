@@ -4,6 +4,7 @@
 package command
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
@@ -22,11 +23,14 @@ import (
 	runtimeinfra "github.com/poruru-code/esb-cli/internal/infra/runtime"
 	"github.com/poruru-code/esb-cli/internal/infra/ui"
 	usecasedeploy "github.com/poruru-code/esb-cli/internal/usecase/deploy"
+	"github.com/poruru-code/esb-cli/internal/usecase/invoke"
+	"github.com/poruru-code/esb-cli/internal/usecase/logs"
 	"github.com/poruru-code/esb-cli/internal/version"
 )
 
 const (
 	repoRequiredExitCode     = 2
+	timeoutExitCode          = 124
 	repoRequiredErrorMessage = "EBS repository root not found from current directory. Run this command inside the EBS repository."
 	cliCommandName           = "esb"
 )
@@ -37,11 +41,22 @@ var commandValueFlags = collectValueFlags(reflect.TypeOf(CLI{}))
 // This structure enables dependency injection for testing and allows swapping
 // implementations of various subsystems.
 type Dependencies struct {
+	// Context is cancelled by cmd/esb on Ctrl-C or SIGTERM so long-running
+	// commands can stop their docker commands and clean up. Nil means
+	// context.Background.
+	Context      context.Context
 	Out          io.Writer
 	ErrOut       io.Writer
+	In           io.Reader
 	Prompter     interaction.Prompter
 	RepoResolver func(string) (string, error)
 	Deploy       DeployDeps
+	Invoke       InvokeDeps
+	Logs         LogsDeps
+	Artifact     ArtifactDeps
+	Stack        StackDeps
+	// Events is set by Run when --output json is selected.
+	Events *ui.JSONEventStream
 }
 
 // CLI defines the command-line interface structure parsed by Kong.
@@ -50,58 +65,202 @@ type CLI struct {
 	Template []string    `short:"t" help:"Path to SAM template (repeatable)"`
 	EnvFlag  string      `short:"e" name:"env" help:"Environment name"`
 	EnvFile  string      `name:"env-file" help:"Path to .env file"`
+	Output   string      `name:"output" enum:"text,json" default:"text" help:"Output mode (text/json); json streams events to stdout and text to stderr"`
 	Deploy   DeployCmd   `cmd:"" help:"Deploy functions"`
+	Diff     DiffCmd     `cmd:"" help:"Show planned runtime config changes without deploying"`
+	Invoke   InvokeCmd   `cmd:"" help:"Invoke a deployed function through the running gateway"`
+	Event    EventCmd    `cmd:"" help:"Generate a sample event payload"`
+	Logs     LogsCmd     `cmd:"" help:"Show function logs from VictoriaLogs"`
+	Up       UpCmd       `cmd:"" help:"Start the project-env stack and wait for health"`
+	Down     DownCmd     `cmd:"" help:"Stop and remove the project-env stack"`
+	Status   StatusCmd   `cmd:"" help:"Show stack services, health and published ports"`
+	Rollback RollbackCmd `cmd:"" help:"Restore the runtime config of a deploy history entry"`
+	History  HistoryCmd  `cmd:"" help:"Show the deploy history"`
 	Artifact ArtifactCmd `cmd:"" help:"Artifact operations"`
+	Validate ValidateCmd `cmd:"" help:"Validate SAM templates offline"`
 	Version  VersionCmd  `cmd:"" help:"Show version information"`
 }
 
 type (
 	// DeployCmd defines the deploy command flags.
 	DeployCmd struct {
-		Mode         string   `short:"m" help:"Runtime mode (docker/containerd)"`
-		ArtifactRoot string   `name:"artifact-root" help:"Artifact root directory (artifact.yml + artifacts/)"`
-		Project      string   `short:"p" help:"Compose project name to target"`
-		ComposeFiles []string `name:"compose-file" sep:"," help:"Compose file(s) to use (repeatable or comma-separated)"`
-		ImageURI     []string `name:"image-uri" sep:"," help:"Image URI override for image functions (<function>=<image-uri>)"`
-		ImageRuntime []string `name:"image-runtime" sep:"," help:"Runtime override for image functions (<function>=<python|java21>)"`
-		BuildOnly    bool     `name:"build-only" help:"Build only (skip provisioner and runtime sync)"`
-		Bundle       bool     `name:"bundle-manifest" help:"Write bundle manifest (for bundling)"`
-		NoCache      bool     `name:"no-cache" help:"Do not use cache when building images"`
-		WithDeps     bool     `name:"with-deps" help:"Start dependent services when running provisioner"`
-		SecretEnv    string   `name:"secret-env" help:"Path to secret env file for apply phase"`
-		Verbose      bool     `short:"v" help:"Verbose output"`
-		Emoji        bool     `name:"emoji" help:"Enable emoji output (default: auto)"`
-		NoEmoji      bool     `name:"no-emoji" help:"Disable emoji output"`
-		Force        bool     `help:"Allow environment mismatch with running gateway (skip auto-alignment)"`
-		NoSave       bool     `name:"no-save-defaults" help:"Do not persist deploy defaults"`
+		Mode               string   `short:"m" help:"Runtime mode (docker/containerd)"`
+		ArtifactRoot       string   `name:"artifact-root" help:"Artifact root directory (artifact.yml + artifacts/)"`
+		Project            string   `short:"p" help:"Compose project name to target"`
+		ComposeFiles       []string `name:"compose-file" sep:"," help:"Compose file(s) to use (repeatable or comma-separated)"`
+		ImageURI           []string `name:"image-uri" sep:"," help:"Image URI override for image functions (<function>=<image-uri>)"`
+		ImageRuntime       []string `name:"image-runtime" sep:"," help:"Runtime override for image functions (<function>=<python|java21|nodejs20.x|nodejs22.x>)"`
+		ParameterOverrides []string `name:"parameter-overrides" sep:"none" help:"Template parameter overrides (Key=Value ..., repeatable)"`
+		ParametersFile     string   `name:"parameters-file" help:"Template parameters file (JSON/YAML, flat map, CloudFormation list or samconfig)"`
+		Functions          []string `name:"function" sep:"," help:"Only stage and build these functions (name or glob, repeatable or comma-separated)"`
+		BuildOnly          bool     `name:"build-only" help:"Build only (skip provisioner and runtime sync)"`
+		DryRun             bool     `name:"dry-run" help:"Show planned runtime config changes (no build or sync)"`
+		Watch              bool     `name:"watch" help:"After deploying, watch sources and rebuild only changed functions"`
+		Bundle             bool     `name:"bundle-manifest" help:"Write bundle manifest (for bundling)"`
+		SignKey            string   `name:"sign-key" help:"Sign bundle image registry digests with a cosign-compatible key file (or keyless)"`
+		NoCache            bool     `name:"no-cache" help:"Do not use cache when building images"`
+		BuildCache         string   `name:"build-cache" help:"Import/export BuildKit cache (registry=<ref>, local=<dir> or inline)"`
+		Explain            bool     `name:"explain" help:"Explain why each function image is rebuilt or skipped"`
+		WithDeps           bool     `name:"with-deps" help:"Start dependent services when running provisioner"`
+		SecretEnv          string   `name:"secret-env" help:"Path to secret env file for apply phase"`
+		Verbose            bool     `short:"v" help:"Verbose output"`
+		Emoji              bool     `name:"emoji" help:"Enable emoji output (default: auto)"`
+		NoEmoji            bool     `name:"no-emoji" help:"Disable emoji output"`
+		Force              bool     `help:"Allow environment mismatch with running gateway (skip auto-alignment)"`
+		NoSave             bool     `name:"no-save-defaults" help:"Do not persist deploy defaults"`
+		Timeout            string   `name:"timeout" help:"Abort the deploy after this duration (e.g. 30m; 0 disables)"`
+		PhaseTimeouts      []string `name:"phase-timeout" sep:"," help:"Per-phase timeout budget (<phase>=<duration>; registry_wait, generate, base_build, function_build, provision, sync)"`
+	}
+
+	// DiffCmd defines the diff command flags (deploy --dry-run).
+	DiffCmd struct {
+		Mode               string   `short:"m" help:"Runtime mode (docker/containerd)"`
+		ArtifactRoot       string   `name:"artifact-root" help:"Artifact root directory (artifact.yml + artifacts/)"`
+		Project            string   `short:"p" help:"Compose project name to target"`
+		ComposeFiles       []string `name:"compose-file" sep:"," help:"Compose file(s) to use (repeatable or comma-separated)"`
+		ImageURI           []string `name:"image-uri" sep:"," help:"Image URI override for image functions (<function>=<image-uri>)"`
+		ImageRuntime       []string `name:"image-runtime" sep:"," help:"Runtime override for image functions (<function>=<python|java21|nodejs20.x|nodejs22.x>)"`
+		ParameterOverrides []string `name:"parameter-overrides" sep:"none" help:"Template parameter overrides (Key=Value ..., repeatable)"`
+		ParametersFile     string   `name:"parameters-file" help:"Template parameters file (JSON/YAML, flat map, CloudFormation list or samconfig)"`
+		SecretEnv          string   `name:"secret-env" help:"Path to secret env file for apply phase"`
+		Verbose            bool     `short:"v" help:"Verbose output"`
+		Emoji              bool     `name:"emoji" help:"Enable emoji output (default: auto)"`
+		NoEmoji            bool     `name:"no-emoji" help:"Disable emoji output"`
+		Force              bool     `help:"Allow environment mismatch with running gateway (skip auto-alignment)"`
+		NoSave             bool     `name:"no-save-defaults" help:"Do not persist deploy defaults"`
 	}
 
 	ArtifactCmd struct {
 		Generate ArtifactGenerateCmd `cmd:"" help:"Generate artifacts and manifest (without apply)"`
 		Apply    ArtifactApplyCmd    `cmd:"" help:"Apply artifact manifest"`
+		Export   ArtifactExportCmd   `cmd:"" help:"Save bundle images into an OCI image-layout tarball"`
+		Import   ArtifactImportCmd   `cmd:"" help:"Load bundle images from an exported tarball"`
 	}
 
 	ArtifactGenerateCmd struct {
-		Mode         string   `short:"m" help:"Runtime mode (docker/containerd)"`
-		ArtifactRoot string   `name:"artifact-root" help:"Artifact root directory (artifact.yml + artifacts/)"`
-		Project      string   `short:"p" help:"Compose project name to target"`
-		ComposeFiles []string `name:"compose-file" sep:"," help:"Compose file(s) to use (repeatable or comma-separated)"`
-		ImageURI     []string `name:"image-uri" sep:"," help:"Image URI override for image functions (<function>=<image-uri>)"`
-		ImageRuntime []string `name:"image-runtime" sep:"," help:"Runtime override for image functions (<function>=<python|java21>)"`
-		Bundle       bool     `name:"bundle-manifest" help:"Write bundle manifest (for bundling)"`
-		BuildImages  bool     `name:"build-images" help:"Build base/function images during generate"`
-		NoCache      bool     `name:"no-cache" help:"Do not use cache when building images"`
-		Verbose      bool     `short:"v" help:"Verbose output"`
-		Emoji        bool     `name:"emoji" help:"Enable emoji output (default: auto)"`
-		NoEmoji      bool     `name:"no-emoji" help:"Disable emoji output"`
-		Force        bool     `help:"Allow environment mismatch with running gateway (skip auto-alignment)"`
-		NoSave       bool     `name:"no-save-defaults" help:"Do not persist deploy defaults"`
+		Mode               string   `short:"m" help:"Runtime mode (docker/containerd)"`
+		ArtifactRoot       string   `name:"artifact-root" help:"Artifact root directory (artifact.yml + artifacts/)"`
+		Project            string   `short:"p" help:"Compose project name to target"`
+		ComposeFiles       []string `name:"compose-file" sep:"," help:"Compose file(s) to use (repeatable or comma-separated)"`
+		ImageURI           []string `name:"image-uri" sep:"," help:"Image URI override for image functions (<function>=<image-uri>)"`
+		ImageRuntime       []string `name:"image-runtime" sep:"," help:"Runtime override for image functions (<function>=<python|java21|nodejs20.x|nodejs22.x>)"`
+		ParameterOverrides []string `name:"parameter-overrides" sep:"none" help:"Template parameter overrides (Key=Value ..., repeatable)"`
+		ParametersFile     string   `name:"parameters-file" help:"Template parameters file (JSON/YAML, flat map, CloudFormation list or samconfig)"`
+		Functions          []string `name:"function" sep:"," help:"Only stage and build these functions (name or glob, repeatable or comma-separated)"`
+		Bundle             bool     `name:"bundle-manifest" help:"Write bundle manifest (for bundling)"`
+		SignKey            string   `name:"sign-key" help:"Sign bundle image registry digests with a cosign-compatible key file (or keyless)"`
+		BuildImages        bool     `name:"build-images" help:"Build base/function images during generate"`
+		NoCache            bool     `name:"no-cache" help:"Do not use cache when building images"`
+		BuildCache         string   `name:"build-cache" help:"Import/export BuildKit cache (registry=<ref>, local=<dir> or inline)"`
+		Explain            bool     `name:"explain" help:"Explain why each function image is rebuilt or skipped"`
+		Verbose            bool     `short:"v" help:"Verbose output"`
+		Emoji              bool     `name:"emoji" help:"Enable emoji output (default: auto)"`
+		NoEmoji            bool     `name:"no-emoji" help:"Disable emoji output"`
+		Force              bool     `help:"Allow environment mismatch with running gateway (skip auto-alignment)"`
+		NoSave             bool     `name:"no-save-defaults" help:"Do not persist deploy defaults"`
 	}
 
 	ArtifactApplyCmd struct {
 		Artifact  string `name:"artifact" help:"Path to artifact manifest (artifact.yml)"`
 		OutputDir string `name:"out" help:"Output config directory"`
 		SecretEnv string `name:"secret-env" help:"Path to secret env file"`
+		VerifyKey string `name:"verify-key" help:"Public key to verify bundle image signatures (fails closed when set)"`
+	}
+
+	ArtifactExportCmd struct {
+		Artifact string `name:"artifact" help:"Path to artifact manifest (artifact.yml)"`
+		Output   string `name:"out" help:"Output tarball (default: images.oci.tar next to artifact.yml)"`
+	}
+
+	ArtifactImportCmd struct {
+		Artifact  string `name:"artifact" help:"Path to artifact manifest (artifact.yml)"`
+		Archive   string `name:"archive" help:"Image tarball (default: images.oci.tar next to artifact.yml)"`
+		Registry  string `name:"registry" help:"Push function images to this registry and rewrite their refs"`
+		Namespace string `name:"namespace" help:"Import into this containerd namespace (ctr) instead of Docker"`
+	}
+
+	// ValidateCmd defines the validate command flags.
+	ValidateCmd struct {
+		Format             string   `name:"format" enum:"text,json,sarif" default:"text" help:"Output format (text/json/sarif)"`
+		Strict             bool     `name:"strict" help:"Treat warnings as failures"`
+		ParameterOverrides []string `name:"parameter-overrides" sep:"none" help:"Template parameter overrides (Key=Value ..., repeatable)"`
+		ParametersFile     string   `name:"parameters-file" help:"Template parameters file (JSON/YAML, flat map, CloudFormation list or samconfig)"`
+	}
+
+	// InvokeCmd defines the invoke command flags.
+	InvokeCmd struct {
+		Function string        `arg:"" help:"Function name"`
+		Event    string        `name:"event" help:"Path to event JSON file (- for stdin)"`
+		Project  string        `short:"p" help:"Compose project name to target"`
+		URL      string        `name:"url" help:"Gateway URL (skip discovery)"`
+		Timeout  time.Duration `name:"timeout" default:"60s" help:"Invocation timeout"`
+		NoLogs   bool          `name:"no-logs" help:"Do not request the log tail"`
+	}
+
+	// EventCmd defines the event generator flags.
+	EventCmd struct {
+		Kind   string `arg:"" enum:"api,httpapi,schedule,sqs" help:"Event kind (api/httpapi/schedule/sqs)"`
+		Method string `name:"method" default:"GET" help:"HTTP method (api/httpapi)"`
+		Path   string `name:"path" default:"/" help:"Request path with optional query (api/httpapi)"`
+		Body   string `name:"body" help:"Request or message body"`
+		Name   string `name:"name" help:"Queue name (sqs) or rule name (schedule)"`
+		Region string `name:"region" help:"AWS region used in ARNs"`
+	}
+
+	// LogsCmd defines the logs command flags.
+	LogsCmd struct {
+		Function  string        `arg:"" optional:"" help:"Function name (all functions when omitted)"`
+		Since     time.Duration `name:"since" default:"10m" help:"Show logs newer than this duration"`
+		Follow    bool          `short:"f" name:"follow" help:"Stream new log lines"`
+		Filter    string        `name:"filter" help:"Additional LogsQL filter (e.g. error)"`
+		RequestID string        `name:"request-id" help:"Only show lines for this request id"`
+		Limit     int           `name:"limit" default:"1000" help:"Maximum lines to show without --follow"`
+		Project   string        `short:"p" help:"Compose project name to target"`
+		URL       string        `name:"url" help:"VictoriaLogs URL (skip discovery)"`
+	}
+
+	// UpCmd defines the up command flags.
+	UpCmd struct {
+		Mode         string        `short:"m" help:"Runtime mode (docker/containerd)"`
+		Project      string        `short:"p" help:"Compose project name to target"`
+		ComposeFiles []string      `name:"compose-file" sep:"," help:"Compose file(s) to use (repeatable or comma-separated)"`
+		Build        bool          `name:"build" help:"Build images before starting containers"`
+		NoWait       bool          `name:"no-wait" help:"Do not wait for services to become healthy"`
+		Timeout      time.Duration `name:"timeout" default:"3m" help:"Maximum time to wait for healthy services"`
+	}
+
+	// DownCmd defines the down command flags.
+	DownCmd struct {
+		Mode         string   `short:"m" help:"Runtime mode (docker/containerd)"`
+		Project      string   `short:"p" help:"Compose project name to target"`
+		ComposeFiles []string `name:"compose-file" sep:"," help:"Compose file(s) to use (repeatable or comma-separated)"`
+		Volumes      bool     `name:"volumes" help:"Also remove named volumes (database, storage, logs)"`
+	}
+
+	// StatusCmd defines the status command flags.
+	StatusCmd struct {
+		Project string `short:"p" help:"Compose project name to target"`
+	}
+
+	// RollbackCmd defines the rollback command flags.
+	RollbackCmd struct {
+		Project string `short:"p" help:"Compose project name to target"`
+		To      string `name:"to" help:"History entry id to restore (default: the latest entry)"`
+	}
+
+	// HistoryCmd groups the deploy history subcommands.
+	HistoryCmd struct {
+		List HistoryListCmd `cmd:"" default:"withargs" help:"List deploy history entries (newest first)"`
+		Show HistoryShowCmd `cmd:"" help:"Show a history entry and the config it applied"`
+	}
+
+	HistoryListCmd struct {
+		Project string `short:"p" help:"Only show entries of this compose project"`
+	}
+
+	HistoryShowCmd struct {
+		ID      string `arg:"" help:"History entry id (from esb history)"`
+		Project string `short:"p" help:"Compose project of the entry (when the id is ambiguous)"`
 	}
 
 	VersionCmd struct{}
@@ -123,6 +282,25 @@ type (
 		DockerClient       DockerClientFactory
 	}
 
+	InvokeDeps struct {
+		DockerClient DockerClientFactory
+		HTTPClient   invoke.HTTPDoer
+	}
+
+	LogsDeps struct {
+		DockerClient DockerClientFactory
+		HTTPClient   logs.HTTPDoer
+	}
+
+	ArtifactDeps struct {
+		Runner compose.CommandRunner
+	}
+
+	StackDeps struct {
+		Runner       compose.CommandRunner
+		DockerClient DockerClientFactory
+	}
+
 	DeployProvisionDeps struct {
 		ComposeRunner             compose.CommandRunner
 		ComposeProvisioner        usecasedeploy.ComposeProvisioner
@@ -132,7 +310,7 @@ type (
 )
 
 type (
-	RegistryWaiter func(registry string, timeout time.Duration) error
+	RegistryWaiter func(ctx context.Context, registry string, timeout time.Duration) error
 
 	DockerClientFactory func() (compose.DockerClient, error)
 
@@ -196,7 +374,11 @@ func Run(args []string, deps Dependencies) int {
 	}
 
 	command := ctx.Command()
+	if cli.Output == outputModeJSON {
+		deps, out = enableJSONOutput(deps, out)
+	}
 	if exitCode, handled := dispatchCommand(command, cli, deps, out); handled {
+		deps.Events.Finish(command, exitCode)
 		return exitCode
 	}
 
@@ -206,12 +388,35 @@ func Run(args []string, deps Dependencies) int {
 
 type commandHandler func(CLI, Dependencies, io.Writer) int
 
+// commandContext returns the cancellation context commands should run under.
+func commandContext(deps Dependencies) context.Context {
+	if deps.Context == nil {
+		return context.Background()
+	}
+	return deps.Context
+}
+
 func dispatchCommand(command string, cli CLI, deps Dependencies, out io.Writer) (int, bool) {
 	exactHandlers := map[string]commandHandler{
 		"deploy":            runDeploy,
+		"diff":              runDiff,
+		"invoke <function>": runInvoke,
+		"event <kind>":      runEvent,
+		"logs":              runLogs,
+		"logs <function>":   runLogs,
+		"up":                runUp,
+		"down":              runDown,
+		"status":            runStatus,
+		"rollback":          runRollback,
+		"history":           runHistoryList,
+		"history list":      runHistoryList,
+		"history show <id>": runHistoryShow,
 		"artifact generate": runArtifactGenerate,
 		"artifact apply":    runArtifactApply,
-		"version":           func(_ CLI, _ Dependencies, out io.Writer) int { return runVersion(cli, out) },
+		"artifact export":   runArtifactExport,
+		"artifact import":   runArtifactImport,
+		"validate":          runValidate,
+		"version":           runVersion,
 	}
 
 	if handler, ok := exactHandlers[command]; ok {
@@ -222,7 +427,8 @@ func dispatchCommand(command string, cli CLI, deps Dependencies, out io.Writer)
 }
 
 // runVersion prints the version information of the CLI.
-func runVersion(_ CLI, out io.Writer) int {
+func runVersion(_ CLI, deps Dependencies, out io.Writer) int {
+	deps.Events.SetDetail("version", version.GetVersion())
 	legacyUI(out).Info(version.GetVersion())
 	return 0
 }
@@ -248,6 +454,23 @@ func commandName(args []string) string {
 	return ""
 }
 
+// JSONOutputRequested reports whether args select --output json, so that
+// dependency wiring can keep stdout free of subprocess output.
+func JSONOutputRequested(args []string) bool {
+	for i, arg := range args {
+		if arg == "--" {
+			return false
+		}
+		if arg == "--output="+outputModeJSON {
+			return true
+		}
+		if arg == "--output" && i+1 < len(args) && args[i+1] == outputModeJSON {
+			return true
+		}
+	}
+	return false
+}
+
 func commandFlagExpectsValue(arg string) bool {
 	trimmed := strings.TrimSpace(arg)
 	if trimmed == "" || trimmed == "-" || trimmed == "--" {
@@ -344,7 +567,7 @@ func requiresRepoScope(args []string) bool {
 		return false
 	}
 	switch commandName(args) {
-	case "deploy", "artifact":
+	case "deploy", "diff", "artifact", "up":
 		return true
 	default:
 		return false
@@ -383,6 +606,10 @@ func runNoArgs(out io.Writer) int {
 	ui := legacyUI(out)
 	ui.Info("Usage:")
 	ui.Info(fmt.Sprintf("  %s deploy --template <path> --env <name> --mode <docker|containerd> [flags]", cliCommandName))
+	ui.Info(fmt.Sprintf("  %s diff --template <path> --env <name> --mode <docker|containerd> [flags]", cliCommandName))
+	ui.Info(fmt.Sprintf("  %s invoke <function> [--event <file|->]", cliCommandName))
+	ui.Info(fmt.Sprintf("  %s logs [function] [--since 10m] [--follow]", cliCommandName))
+	ui.Info(fmt.Sprintf("  %s validate --template <path> [--format <text|json|sarif>]", cliCommandName))
 	ui.Info("")
 	ui.Info(fmt.Sprintf("Try: %s deploy --help", cliCommandName))
 	return 0
@@ -420,7 +647,7 @@ func handleParseError(args []string, err error, deps Dependencies, out io.Writer
 			ui.Info(fmt.Sprintf("Example: %s deploy --image-uri lambda-image=public.ecr.aws/example/repo:latest", cliCommandName))
 			return 1
 		case strings.Contains(msg, "--image-runtime"):
-			ui.Warn("`--image-runtime` expects a value. Use <function>=<python|java21>.")
+			ui.Warn("`--image-runtime` expects a value. Use <function>=<python|java21|nodejs20.x|nodejs22.x>.")
 			ui.Info(fmt.Sprintf("Example: %s deploy --image-runtime lambda-image=java21", cliCommandName))
 			return 1
 		case strings.Contains(msg, "--artifact-root"):
@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/poruru-code/esb-cli/internal/infra/interaction"
+	"github.com/poruru-code/esb-cli/internal/infra/ui"
 	"github.com/poruru-code/esb/pkg/deployops"
 )
 
@@ -25,20 +26,26 @@ func runArtifactGenerate(cli CLI, deps Dependencies, out io.Writer) int {
 
 func artifactGenerateToDeployFlags(cmd ArtifactGenerateCmd) DeployCmd {
 	return DeployCmd{
-		Mode:         cmd.Mode,
-		ArtifactRoot: cmd.ArtifactRoot,
-		Project:      cmd.Project,
-		ComposeFiles: append([]string(nil), cmd.ComposeFiles...),
-		ImageURI:     append([]string(nil), cmd.ImageURI...),
-		ImageRuntime: append([]string(nil), cmd.ImageRuntime...),
-		BuildOnly:    true,
-		Bundle:       cmd.Bundle,
-		NoCache:      cmd.NoCache,
-		Verbose:      cmd.Verbose,
-		Emoji:        cmd.Emoji,
-		NoEmoji:      cmd.NoEmoji,
-		Force:        cmd.Force,
-		NoSave:       cmd.NoSave,
+		Mode:               cmd.Mode,
+		ArtifactRoot:       cmd.ArtifactRoot,
+		Project:            cmd.Project,
+		ComposeFiles:       append([]string(nil), cmd.ComposeFiles...),
+		ImageURI:           append([]string(nil), cmd.ImageURI...),
+		ImageRuntime:       append([]string(nil), cmd.ImageRuntime...),
+		ParameterOverrides: append([]string(nil), cmd.ParameterOverrides...),
+		ParametersFile:     cmd.ParametersFile,
+		Functions:          append([]string(nil), cmd.Functions...),
+		BuildOnly:          true,
+		Bundle:             cmd.Bundle,
+		SignKey:            cmd.SignKey,
+		NoCache:            cmd.NoCache,
+		BuildCache:         cmd.BuildCache,
+		Explain:            cmd.Explain,
+		Verbose:            cmd.Verbose,
+		Emoji:              cmd.Emoji,
+		NoEmoji:            cmd.NoEmoji,
+		Force:              cmd.Force,
+		NoSave:             cmd.NoSave,
 	}
 }
 
@@ -57,6 +64,9 @@ func runArtifactApply(cli CLI, deps Dependencies, out io.Writer) int {
 	if err != nil {
 		return exitWithError(out, err)
 	}
+	if err := verifyArtifactBundles(args.Artifact, resolveBundleVerifyKey(args.VerifyKey)); err != nil {
+		return exitWithError(out, err)
+	}
 	result, err := deployops.Execute(deployops.Input{
 		ArtifactPath:  args.Artifact,
 		OutputDir:     args.OutputDir,
@@ -65,7 +75,9 @@ func runArtifactApply(cli CLI, deps Dependencies, out io.Writer) int {
 	if err != nil {
 		return exitWithError(out, err)
 	}
-	deployUI := legacyUI(out)
+	deps.Events.SetDetail("artifact", args.Artifact)
+	deps.Events.SetDetail("output_dir", args.OutputDir)
+	deployUI := ui.NewEventUI(legacyUI(out), eventSink(deps))
 	for _, warning := range result.Warnings {
 		deployUI.Warn(warning)
 	}
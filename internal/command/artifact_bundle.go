@@ -0,0 +1,80 @@
+// Where: cli/internal/command/artifact_bundle.go
+// What: CLI adapter for artifact export/import of bundle images.
+// Why: Move generated artifacts and their images into air-gapped environments.
+package command
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/poruru-code/esb-cli/internal/infra/ui"
+	"github.com/poruru-code/esb-cli/internal/usecase/bundle"
+)
+
+var (
+	errArtifactRunnerNotConfigured = errors.New("artifact: command runner not configured")
+	errArtifactPathMissing         = errors.New("artifact: --artifact is required")
+)
+
+func runArtifactExport(cli CLI, deps Dependencies, out io.Writer) int {
+	args := cli.Artifact.Export
+	artifactPath := strings.TrimSpace(args.Artifact)
+	if artifactPath == "" {
+		return exitWithError(out, errArtifactPathMissing)
+	}
+	if deps.Artifact.Runner == nil {
+		return exitWithError(out, errArtifactRunnerNotConfigured)
+	}
+	workflow := bundle.Workflow{Runner: deps.Artifact.Runner}
+	result, err := workflow.Export(commandContext(deps), bundle.ExportRequest{
+		ArtifactPath: artifactPath,
+		Output:       strings.TrimSpace(args.Output),
+	})
+	if err != nil {
+		return exitWithError(out, fmt.Errorf("artifact export: %w", err))
+	}
+	if deps.Events != nil {
+		deps.Events.SetDetail("archive", result.Path)
+		deps.Events.SetDetail("images", len(result.Images))
+	}
+	exportUI := ui.NewEventUI(legacyUI(out), eventSink(deps))
+	exportUI.Success(fmt.Sprintf("Exported %d images to %s", len(result.Images), result.Path))
+	return 0
+}
+
+func runArtifactImport(cli CLI, deps Dependencies, out io.Writer) int {
+	args := cli.Artifact.Import
+	artifactPath := strings.TrimSpace(args.Artifact)
+	if artifactPath == "" {
+		return exitWithError(out, errArtifactPathMissing)
+	}
+	if deps.Artifact.Runner == nil {
+		return exitWithError(out, errArtifactRunnerNotConfigured)
+	}
+	workflow := bundle.Workflow{Runner: deps.Artifact.Runner}
+	result, err := workflow.Import(commandContext(deps), bundle.ImportRequest{
+		ArtifactPath: artifactPath,
+		Archive:      strings.TrimSpace(args.Archive),
+		Registry:     strings.TrimSpace(args.Registry),
+		Namespace:    strings.TrimSpace(args.Namespace),
+	})
+	if err != nil {
+		return exitWithError(out, fmt.Errorf("artifact import: %w", err))
+	}
+	importUI := ui.NewEventUI(legacyUI(out), eventSink(deps))
+	for _, image := range result.Images {
+		if image.Target != image.Source {
+			importUI.Info(fmt.Sprintf("  %s -> %s", image.Source, image.Target))
+		}
+	}
+	for _, path := range result.RewrittenConfig {
+		importUI.Info(fmt.Sprintf("  Rewrote image refs in %s", path))
+	}
+	if deps.Events != nil {
+		deps.Events.SetDetail("images", len(result.Images))
+	}
+	importUI.Success(fmt.Sprintf("Imported %d images", len(result.Images)))
+	return 0
+}
@@ -24,6 +24,7 @@ func TestArtifactGenerateToDeployFlags(t *testing.T) {
 		ImageURI:     []string{"fn=image:latest"},
 		ImageRuntime: []string{"fn=python"},
 		Bundle:       true,
+		SignKey:      "cosign.key",
 		BuildImages:  true,
 		NoCache:      true,
 		Verbose:      true,
@@ -47,6 +48,9 @@ func TestArtifactGenerateToDeployFlags(t *testing.T) {
 	if !reflect.DeepEqual(got.ImageRuntime, cmd.ImageRuntime) {
 		t.Fatalf("image runtime mismatch: got=%v want=%v", got.ImageRuntime, cmd.ImageRuntime)
 	}
+	if got.SignKey != cmd.SignKey {
+		t.Fatalf("sign key mismatch: got=%q want=%q", got.SignKey, cmd.SignKey)
+	}
 	if !got.Bundle || !got.NoCache || !got.Verbose || !got.Emoji || !got.Force || !got.NoSave {
 		t.Fatalf("boolean/metadata mapping mismatch: %#v", got)
 	}
@@ -339,3 +343,15 @@ func writeYAML(t *testing.T, path, content string) {
 		t.Fatal(err)
 	}
 }
+
+func TestRunArtifactExportImportRequireArtifactAndRunner(t *testing.T) {
+	var out bytes.Buffer
+	if code := runArtifactExport(CLI{}, Dependencies{}, &out); code == 0 || !strings.Contains(out.String(), "--artifact is required") {
+		t.Fatalf("expected missing artifact error, got %d output=%q", code, out.String())
+	}
+	out.Reset()
+	cli := CLI{Artifact: ArtifactCmd{Import: ArtifactImportCmd{Artifact: "artifact.yml"}}}
+	if code := runArtifactImport(cli, Dependencies{}, &out); code == 0 || !strings.Contains(out.String(), "runner not configured") {
+		t.Fatalf("expected missing runner error, got %d output=%q", code, out.String())
+	}
+}
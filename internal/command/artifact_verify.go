@@ -0,0 +1,73 @@
+// Where: cli/internal/command/artifact_verify.go
+// What: Bundle signature verification before artifact apply.
+// Why: Fail closed on bundles whose images were not signed by the trusted key.
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/poruru-code/esb-cli/internal/constants"
+	"github.com/poruru-code/esb-cli/internal/infra/bundlesign"
+	"github.com/poruru-code/esb-cli/internal/infra/envutil"
+	"github.com/poruru-code/esb-cli/internal/usecase/bundle"
+)
+
+// resolveBundleVerifyKey prefers --verify-key and falls back to
+// <PREFIX>_BUNDLE_VERIFY_KEY.
+func resolveBundleVerifyKey(flag string) string {
+	if trimmed := strings.TrimSpace(flag); trimmed != "" {
+		return trimmed
+	}
+	value, err := envutil.GetHostEnv(constants.HostSuffixBundleVerifyKey)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(value)
+}
+
+// verifyArtifactBundles verifies the bundle signatures of every artifact
+// entry and checks that every function image its functions.yml deploys is
+// one of the signed images. With verification enabled, entries without a
+// bundle manifest are rejected as well.
+func verifyArtifactBundles(artifactPath, publicKeyPath string) error {
+	if publicKeyPath == "" {
+		return nil
+	}
+	bundles, err := bundle.ReadBundles(artifactPath)
+	if err != nil {
+		return fmt.Errorf("verify bundle signatures: %w", err)
+	}
+	for _, b := range bundles {
+		signed, err := bundlesign.VerifyBundle(b.ManifestPath, publicKeyPath)
+		if err != nil {
+			return fmt.Errorf("verify bundle signatures (%s): %w", b.ManifestPath, err)
+		}
+		refs, err := b.FunctionImages()
+		if err != nil {
+			return fmt.Errorf("verify bundle signatures: %w", err)
+		}
+		if err := checkSignedFunctionImages(refs, signed); err != nil {
+			return fmt.Errorf("verify bundle signatures (%s): %w", b.FunctionsPath(), err)
+		}
+	}
+	return nil
+}
+
+// checkSignedFunctionImages rejects function image refs that are not in the
+// signed set. Refs are compared without their registry host because
+// artifact import retargets function images to another registry; digest
+// pinned refs must match the signed manifest digest.
+func checkSignedFunctionImages(refs []string, signed []bundlesign.Image) error {
+	allowed := make(map[string]struct{}, len(signed)*2)
+	for _, image := range signed {
+		allowed[bundle.ImagePath(image.Name)] = struct{}{}
+		allowed[bundle.ImagePath(bundlesign.Repository(image.Name))+"@"+image.Digest] = struct{}{}
+	}
+	for _, ref := range refs {
+		if _, ok := allowed[bundle.ImagePath(ref)]; !ok {
+			return fmt.Errorf("function image %s is not signed", ref)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,179 @@
+package command
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/poruru-code/esb-cli/internal/infra/bundlesign"
+	engine "github.com/poruru-code/esb/pkg/artifactcore"
+)
+
+func writeVerifyArtifact(t *testing.T, root, bundleManifest, functions string) string {
+	t.Helper()
+	artifactRoot := filepath.Join(root, "a")
+	writeYAML(t, filepath.Join(artifactRoot, "config", "functions.yml"), functions)
+	writeYAML(t, filepath.Join(artifactRoot, "config", "routing.yml"), "routes: []\n")
+	manifest := engine.ArtifactManifest{
+		SchemaVersion: engine.ArtifactSchemaVersionV1,
+		Project:       "esb-dev",
+		Env:           "dev",
+		Mode:          "docker",
+		Artifacts: []engine.ArtifactEntry{
+			{
+				ArtifactRoot:     "../a",
+				RuntimeConfigDir: "config",
+				BundleManifest:   bundleManifest,
+				SourceTemplate: engine.ArtifactSourceTemplate{
+					Path:   "/tmp/template.yaml",
+					SHA256: "sha",
+				},
+			},
+		},
+	}
+	manifestPath := filepath.Join(root, "manifest", "artifact.yml")
+	if err := engine.WriteArtifactManifest(manifestPath, manifest); err != nil {
+		t.Fatalf("write manifest: %v", err)
+	}
+	return manifestPath
+}
+
+func writeSigningKeyPair(t *testing.T, dir string) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshal key: %v", err)
+	}
+	pubDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("marshal public key: %v", err)
+	}
+	keyPath := filepath.Join(dir, "cosign.key")
+	pubPath := filepath.Join(dir, "cosign.pub")
+	if err := os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("write key: %v", err)
+	}
+	if err := os.WriteFile(pubPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}), 0o600); err != nil {
+		t.Fatalf("write public key: %v", err)
+	}
+	return keyPath, pubPath
+}
+
+func TestRunArtifactApplyVerifiesBundleSignatures(t *testing.T) {
+	root := t.TempDir()
+	bundleDir := filepath.Join(root, "a", "bundle")
+	writeYAML(t, filepath.Join(bundleDir, "manifest.json"),
+		`{"images": [{"name": "127.0.0.1:5010/esb-lambda-hello:latest", "digest": "sha256:111", "manifest_digest": "sha256:aaa"}]}`)
+	keyPath, pubKey := writeSigningKeyPair(t, root)
+	signer, err := bundlesign.NewSigner(keyPath)
+	if err != nil {
+		t.Fatalf("NewSigner: %v", err)
+	}
+	if _, err := bundlesign.WriteSignatures(t.Context(), bundleDir, signer, []bundlesign.Image{
+		{Name: "127.0.0.1:5010/esb-lambda-hello:latest", Digest: "sha256:aaa"},
+	}, ""); err != nil {
+		t.Fatalf("WriteSignatures: %v", err)
+	}
+	manifestPath := writeVerifyArtifact(t, root, "bundle/manifest.json",
+		"functions:\n  Hello:\n    image: registry:5010/esb-lambda-hello:latest\n")
+
+	outDir := filepath.Join(root, "out")
+	var out bytes.Buffer
+	exitCode := runArtifactApply(
+		CLI{Artifact: ArtifactCmd{Apply: ArtifactApplyCmd{Artifact: manifestPath, OutputDir: outDir, VerifyKey: pubKey}}},
+		Dependencies{},
+		&out,
+	)
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d output=%q", exitCode, out.String())
+	}
+
+	// Tampering with the digest must stop apply before any config is written.
+	writeYAML(t, filepath.Join(bundleDir, "manifest.json"),
+		`{"images": [{"name": "127.0.0.1:5010/esb-lambda-hello:latest", "digest": "sha256:111", "manifest_digest": "sha256:bbb"}]}`)
+	outDir = filepath.Join(root, "out-tampered")
+	out.Reset()
+	exitCode = runArtifactApply(
+		CLI{Artifact: ArtifactCmd{Apply: ArtifactApplyCmd{Artifact: manifestPath, OutputDir: outDir, VerifyKey: pubKey}}},
+		Dependencies{},
+		&out,
+	)
+	if exitCode == 0 || !strings.Contains(out.String(), "no signature for 127.0.0.1:5010/esb-lambda-hello:latest") {
+		t.Fatalf("expected verification failure, got %d output=%q", exitCode, out.String())
+	}
+	if _, err := os.Stat(outDir); !os.IsNotExist(err) {
+		t.Fatalf("expected no output after failed verification, stat err=%v", err)
+	}
+}
+
+func TestVerifyArtifactBundlesRequiresBundleWhenEnabled(t *testing.T) {
+	root := t.TempDir()
+	manifestPath := writeVerifyArtifact(t, root, "", "functions: {}\n")
+	if err := verifyArtifactBundles(manifestPath, ""); err != nil {
+		t.Fatalf("verification disabled should pass: %v", err)
+	}
+	err := verifyArtifactBundles(manifestPath, filepath.Join(root, "cosign.pub"))
+	if err == nil || !strings.Contains(err.Error(), "has no bundle manifest") {
+		t.Fatalf("expected missing bundle error, got %v", err)
+	}
+}
+
+func TestVerifyArtifactBundlesRejectsUnsignedFunctionImages(t *testing.T) {
+	root := t.TempDir()
+	bundleDir := filepath.Join(root, "a", "bundle")
+	writeYAML(t, filepath.Join(bundleDir, "manifest.json"),
+		`{"images": [{"name": "127.0.0.1:5010/esb-lambda-hello:v1", "digest": "sha256:111", "manifest_digest": "sha256:aaa"}]}`)
+	keyPath, pubKey := writeSigningKeyPair(t, root)
+	signer, err := bundlesign.NewSigner(keyPath)
+	if err != nil {
+		t.Fatalf("NewSigner: %v", err)
+	}
+	if _, err := bundlesign.WriteSignatures(t.Context(), bundleDir, signer, []bundlesign.Image{
+		{Name: "127.0.0.1:5010/esb-lambda-hello:v1", Digest: "sha256:aaa"},
+	}, ""); err != nil {
+		t.Fatalf("WriteSignatures: %v", err)
+	}
+
+	// Refs retargeted to another registry or pinned to the signed digest pass.
+	manifestPath := writeVerifyArtifact(t, root, "bundle/manifest.json",
+		"functions:\n  Hello:\n    image: mirror.example.com/esb-lambda-hello:v1\n"+
+			"  Pinned:\n    image: registry:5010/esb-lambda-hello@sha256:aaa\n")
+	if err := verifyArtifactBundles(manifestPath, pubKey); err != nil {
+		t.Fatalf("expected signed refs to pass: %v", err)
+	}
+
+	for _, ref := range []string{
+		"registry:5010/esb-lambda-hello:v2",
+		"registry:5010/esb-lambda-hello@sha256:bbb",
+		"registry:5010/esb-lambda-other:v1",
+	} {
+		manifestPath = writeVerifyArtifact(t, root, "bundle/manifest.json",
+			"functions:\n  Hello:\n    image: "+ref+"\n")
+		err := verifyArtifactBundles(manifestPath, pubKey)
+		if err == nil || !strings.Contains(err.Error(), "function image "+ref+" is not signed") {
+			t.Fatalf("expected %s to be rejected, got %v", ref, err)
+		}
+	}
+}
+
+func TestResolveBundleVerifyKeyFallsBackToEnv(t *testing.T) {
+	t.Setenv("ENV_PREFIX", "ESB")
+	t.Setenv("ESB_BUNDLE_VERIFY_KEY", " /keys/cosign.pub ")
+	if got := resolveBundleVerifyKey(""); got != "/keys/cosign.pub" {
+		t.Fatalf("expected env key, got %q", got)
+	}
+	if got := resolveBundleVerifyKey("flag.pub"); got != "flag.pub" {
+		t.Fatalf("expected flag to win, got %q", got)
+	}
+}
@@ -6,24 +6,29 @@ package command
 import "errors"
 
 type deployInputs struct {
-	ProjectDir    string
-	ArtifactRoot  string
-	TargetStack   string
-	Env           string
-	EnvSource     string
-	Mode          string
-	Templates     []deployTemplateInput
-	Project       string
-	ProjectSource string
-	ComposeFiles  []string
+	ProjectDir     string
+	ArtifactRoot   string
+	TargetStack    string
+	Env            string
+	EnvSource      string
+	Mode           string
+	Templates      []deployTemplateInput
+	TemplateSource string
+	Project        string
+	ProjectSource  string
+	ComposeFiles   []string
 }
 
 type deployTemplateInput struct {
-	TemplatePath  string
-	OutputDir     string
-	Parameters    map[string]string
-	ImageSources  map[string]string
-	ImageRuntimes map[string]string
+	TemplatePath     string
+	OutputDir        string
+	Parameters       map[string]string
+	ParameterSources map[string]string
+	// NoEchoParameters lists parameters declared NoEcho: true; their values
+	// are masked in JSON output and history.
+	NoEchoParameters []string
+	ImageSources     map[string]string
+	ImageRuntimes    map[string]string
 }
 
 type deployTargetStack struct {
@@ -54,11 +59,22 @@ type samParameter struct {
 	Description string
 	Default     any
 	Allowed     []string
+	// Constraints declared in the template (AllowedPattern, Min/MaxLength,
+	// Min/MaxValue) and the message shown when they fail.
+	Pattern               string
+	MinLength             *int
+	MaxLength             *int
+	MinValue              *float64
+	MaxValue              *float64
+	ConstraintDescription string
+	NoEcho                bool
 }
 
 const (
 	templateHistoryLimit = 10
 	templateManualOption = "Enter path..."
+	// maskedParameterValue replaces NoEcho parameter values, as sam deploy does.
+	maskedParameterValue = "****"
 )
 
 var (
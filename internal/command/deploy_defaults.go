@@ -12,6 +12,7 @@ import (
 	domaintpl "github.com/poruru-code/esb-cli/internal/domain/template"
 	"github.com/poruru-code/esb-cli/internal/infra/config"
 	"github.com/poruru-code/esb-cli/internal/infra/envutil"
+	"github.com/poruru-code/esb-cli/internal/infra/sam"
 )
 
 func loadTemplateHistory() []string {
@@ -91,6 +92,29 @@ func loadDeployDefaults(projectRoot, templatePath string) storedDeployDefaults {
 	}
 }
 
+// loadDeploySamconfig reads the samconfig.toml/.yaml environment selected by
+// --env (default section otherwise) from the working directory or repo root.
+// A missing samconfig is not an error.
+func loadDeploySamconfig(repoRoot, env string) (sam.SamconfigDeploy, error) {
+	cwd, _ := os.Getwd()
+	path, ok := sam.FindSamconfig(cwd, repoRoot)
+	if !ok {
+		return sam.SamconfigDeploy{}, nil
+	}
+	return sam.LoadSamconfigDeploy(path, env)
+}
+
+// reloadDeploySamconfig re-reads a loaded samconfig for the resolved env.
+// The first read only knows --env; the env may later come from a running
+// stack or a prompt, and parameter_overrides must follow the same env as
+// --parameters-file.
+func reloadDeploySamconfig(samconfig sam.SamconfigDeploy, env string) (sam.SamconfigDeploy, error) {
+	if samconfig.Path == "" {
+		return samconfig, nil
+	}
+	return sam.LoadSamconfigDeploy(samconfig.Path, env)
+}
+
 func saveDeployDefaults(projectRoot string, template deployTemplateInput, inputs deployInputs) error {
 	if strings.TrimSpace(template.TemplatePath) == "" {
 		return nil
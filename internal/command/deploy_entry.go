@@ -4,6 +4,7 @@
 package command
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -11,6 +12,8 @@ import (
 	"strings"
 
 	"github.com/poruru-code/esb-cli/internal/domain/state"
+	"github.com/poruru-code/esb-cli/internal/domain/template"
+	"github.com/poruru-code/esb-cli/internal/domain/timeout"
 	"github.com/poruru-code/esb-cli/internal/infra/build"
 	"github.com/poruru-code/esb-cli/internal/infra/compose"
 	"github.com/poruru-code/esb-cli/internal/infra/interaction"
@@ -26,6 +29,7 @@ func runDeploy(cli CLI, deps Dependencies, out io.Writer) int {
 type deployRunOverrides struct {
 	buildImages    *bool
 	forceBuildOnly bool
+	forceDryRun    bool
 }
 
 type deployRunConfig struct {
@@ -33,6 +37,11 @@ type deployRunConfig struct {
 	noDeps      bool
 	buildImages bool
 	buildOnly   bool
+	dryRun      bool
+	watch       bool
+	functions   []string
+	// timeouts bounds the deploy (--timeout) and each of its phases.
+	timeouts timeout.Budget
 }
 
 func runDeployWithOverrides(
@@ -46,11 +55,14 @@ func runDeployWithOverrides(
 		return exitWithError(out, err)
 	}
 
-	commandConfig, err := resolveDeployCommandConfig(deps.Deploy, out, emojiEnabled)
+	events := eventSink(deps)
+	commandConfig, err := resolveDeployCommandConfig(withDeployEvents(deps.Deploy, events), out, emojiEnabled)
 	if err != nil {
 		return exitWithError(out, err)
 	}
 	cmd := newDeployCommand(commandConfig)
+	cmd.events = events
+	cmd.ctx = commandContext(deps)
 
 	inputs, err := resolveDeployInputs(cli, deps)
 	if err != nil {
@@ -70,6 +82,9 @@ type deployCommand struct {
 	composeRunner compose.CommandRunner
 	workflow      deployWorkflowDeps
 	emojiEnabled  bool
+	events        ui.EventSink
+	// ctx is cancelled on Ctrl-C; every deploy request carries it.
+	ctx context.Context
 }
 
 type deployWorkflowDeps struct {
@@ -130,6 +145,18 @@ func resolveDeployCommandConfig(
 	}, nil
 }
 
+// withDeployEvents mirrors deploy UI messages into the event stream.
+func withDeployEvents(deployDeps DeployDeps, events ui.EventSink) DeployDeps {
+	newDeployUI := deployDeps.Provision.NewDeployUI
+	if events == nil || newDeployUI == nil {
+		return deployDeps
+	}
+	deployDeps.Provision.NewDeployUI = func(out io.Writer, emojiEnabled bool) ui.UserInterface {
+		return ui.NewEventUI(newDeployUI(out, emojiEnabled), events)
+	}
+	return deployDeps
+}
+
 func resolveDeployBuildComponent(buildDeps DeployBuildDeps) (func(build.BuildRequest) error, error) {
 	if buildDeps.Build == nil {
 		return nil, errDeployBuilderNotConfigured
@@ -185,9 +212,17 @@ func newDeployCommand(config deployCommandConfig) *deployCommand {
 		composeRunner: config.composeRunner,
 		workflow:      config.workflow,
 		emojiEnabled:  config.emojiEnabled,
+		ctx:           context.Background(),
 	}
 }
 
+func (c *deployCommand) runContext() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
 func (c *deployCommand) runWithOverrides(
 	inputs deployInputs,
 	flags DeployCmd,
@@ -200,7 +235,22 @@ func (c *deployCommand) runWithOverrides(
 	if err != nil {
 		return err
 	}
+	if err := validateFunctionSelection(inputs, runConfig.functions); err != nil {
+		return err
+	}
+	runConfig.timeouts, err = resolveDeployTimeouts(inputs.ProjectDir, flags)
+	if err != nil {
+		return fmt.Errorf("deploy: %w", err)
+	}
+	parent := c.runContext()
+	deployCtx, cancel := timeout.WithTotal(parent, runConfig.timeouts.Total)
+	defer cancel()
+	c.ctx = deployCtx
+	c.emitInputs(inputs, runConfig)
 	workflow := c.newWorkflow()
+	if runConfig.dryRun {
+		return c.runPlanPhase(workflow, inputs, flags, runConfig)
+	}
 
 	if err := c.runGeneratePhase(workflow, inputs, flags, runConfig); err != nil {
 		return err
@@ -212,11 +262,51 @@ func (c *deployCommand) runWithOverrides(
 	if runConfig.buildOnly {
 		return nil
 	}
-	return c.runApplyPhase(workflow, inputs, flags, runConfig, manifestPath)
+	if err := c.runApplyPhase(workflow, inputs, flags, runConfig, manifestPath); err != nil {
+		return err
+	}
+	if runConfig.watch {
+		// --timeout covers the initial deploy, not the watch session.
+		c.ctx = parent
+		return c.runWatch(workflow, inputs, flags, runConfig)
+	}
+	return nil
 }
 
 func resolveDeployRunConfig(flags DeployCmd, overrides deployRunOverrides) (deployRunConfig, error) {
 	buildOnly := flags.BuildOnly || overrides.forceBuildOnly
+	dryRun := flags.DryRun || overrides.forceDryRun
+	if dryRun && buildOnly {
+		return deployRunConfig{}, errors.New("deploy: --dry-run cannot be used with --build-only")
+	}
+	if dryRun && flags.Bundle {
+		return deployRunConfig{}, errors.New("deploy: --dry-run cannot be used with --bundle-manifest")
+	}
+	if flags.Watch && (buildOnly || dryRun) {
+		return deployRunConfig{}, errors.New("deploy: --watch cannot be used with --build-only or --dry-run")
+	}
+	if flags.Watch && flags.Bundle {
+		return deployRunConfig{}, errors.New("deploy: --watch cannot be used with --bundle-manifest")
+	}
+	selector, err := template.NewFunctionSelector(flags.Functions)
+	if err != nil {
+		return deployRunConfig{}, fmt.Errorf("deploy: %w", err)
+	}
+	if !selector.Empty() && flags.Bundle {
+		return deployRunConfig{}, errors.New("deploy: --function cannot be used with --bundle-manifest")
+	}
+	if _, err := build.ParseBuildCache(flags.BuildCache); err != nil {
+		return deployRunConfig{}, fmt.Errorf("deploy: %w", err)
+	}
+	if dryRun && strings.TrimSpace(flags.BuildCache) != "" {
+		return deployRunConfig{}, errors.New("deploy: --build-cache cannot be used with --dry-run")
+	}
+	if strings.TrimSpace(flags.SignKey) != "" && !flags.Bundle {
+		return deployRunConfig{}, errors.New("deploy: --sign-key requires --bundle-manifest")
+	}
+	if dryRun && flags.Explain {
+		return deployRunConfig{}, errors.New("deploy: --explain cannot be used with --dry-run")
+	}
 	if buildOnly && flags.WithDeps {
 		return deployRunConfig{}, errors.New("deploy: --with-deps cannot be used with --build-only")
 	}
@@ -227,11 +317,17 @@ func resolveDeployRunConfig(flags DeployCmd, overrides deployRunOverrides) (depl
 	if overrides.buildImages != nil {
 		buildImages = *overrides.buildImages
 	}
+	if dryRun {
+		buildImages = false
+	}
 	return deployRunConfig{
 		tag:         resolveBrandTag(),
 		noDeps:      !flags.WithDeps,
 		buildImages: buildImages,
 		buildOnly:   buildOnly,
+		dryRun:      dryRun,
+		watch:       flags.Watch,
+		functions:   selector,
 	}, nil
 }
 
@@ -336,16 +432,19 @@ func (c *deployCommand) newGenerateRequest(
 	flags DeployCmd,
 	runConfig deployRunConfig,
 ) deploy.Request {
-	request := buildDeployRequestCommon(inputs, tpl, flags, runConfig)
+	request := buildDeployRequestCommon(c.runContext(), inputs, tpl, flags, runConfig, c.events)
 	request.OutputDir = tpl.OutputDir
-	request.Parameters = tpl.Parameters
 	request.ImageSources = tpl.ImageSources
 	request.ImageRuntimes = tpl.ImageRuntimes
 	request.NoCache = flags.NoCache
+	request.BuildCache = strings.TrimSpace(flags.BuildCache)
+	request.Explain = flags.Explain
 	request.BuildOnly = true
 	request.BuildImages = boolPtr(runConfig.buildImages)
 	request.BundleManifest = flags.Bundle
+	request.BundleSignKey = strings.TrimSpace(flags.SignKey)
 	request.Emoji = c.emojiEnabled
+	request.Functions = runConfig.functions
 	return request
 }
 
@@ -356,7 +455,7 @@ func (c *deployCommand) newApplyRequest(
 	runConfig deployRunConfig,
 	manifestPath string,
 ) deploy.Request {
-	request := buildDeployRequestCommon(inputs, tpl, flags, runConfig)
+	request := buildDeployRequestCommon(c.runContext(), inputs, tpl, flags, runConfig, c.events)
 	request.ArtifactPath = manifestPath
 	request.SecretEnvPath = flags.SecretEnv
 	request.OutputDir = tpl.OutputDir
@@ -365,17 +464,25 @@ func (c *deployCommand) newApplyRequest(
 }
 
 func buildDeployRequestCommon(
+	ctx context.Context,
 	inputs deployInputs,
 	tpl deployTemplateInput,
 	flags DeployCmd,
 	runConfig deployRunConfig,
+	events ui.EventSink,
 ) deploy.Request {
 	return deploy.Request{
+		Ctx:          ctx,
 		Context:      deployTemplateStateContext(inputs, tpl),
+		Events:       events,
 		Tag:          runConfig.tag,
+		Timeouts:     runConfig.timeouts,
 		NoDeps:       runConfig.noDeps,
 		Verbose:      flags.Verbose,
 		ComposeFiles: inputs.ComposeFiles,
+		// Apply records them in deploy history (NoEcho values masked).
+		Parameters:       tpl.Parameters,
+		NoEchoParameters: tpl.NoEchoParameters,
 	}
 }
 
@@ -392,6 +499,26 @@ func deployTemplateStateContext(
 	}
 }
 
+// validateFunctionSelection requires every --function pattern to match a
+// function in at least one template so typos fail before any build.
+func validateFunctionSelection(inputs deployInputs, patterns []string) error {
+	if len(patterns) == 0 {
+		return nil
+	}
+	names := []string{}
+	for _, tpl := range inputs.Templates {
+		found, err := deploy.TemplateFunctionNames(tpl.TemplatePath, tpl.Parameters)
+		if err != nil {
+			return fmt.Errorf("deploy: resolve functions (%s): %w", tpl.TemplatePath, err)
+		}
+		names = append(names, found...)
+	}
+	if unmatched := template.FunctionSelector(patterns).Unmatched(names); len(unmatched) > 0 {
+		return fmt.Errorf("deploy: --function matched no functions: %s", strings.Join(unmatched, ", "))
+	}
+	return nil
+}
+
 func resolveDeployEmojiEnabled(out io.Writer, flags DeployCmd) (bool, error) {
 	if flags.Emoji && flags.NoEmoji {
 		return false, errors.New("deploy: --emoji and --no-emoji cannot be used together")
@@ -47,9 +47,10 @@ type deployEntryProvisioner struct {
 	noDepsArgs []bool
 }
 
-func (p *deployEntryProvisioner) CheckServicesStatus(string, string) {}
+func (p *deployEntryProvisioner) CheckServicesStatus(context.Context, string, string) {}
 
 func (p *deployEntryProvisioner) RunProvisioner(
+	_ context.Context,
 	_ string,
 	_ string,
 	noDeps bool,
@@ -188,7 +189,7 @@ func TestDeployCommandRunBuildsAllTemplatesAndRunsProvisionerOnlyOnLast(t *testi
 		composeRunner: deployEntryRunner{},
 		workflow: deployWorkflowDeps{
 			composeProvisioner: provisioner,
-			registryWaiter:     func(string, time.Duration) error { return nil },
+			registryWaiter:     func(context.Context, string, time.Duration) error { return nil },
 		},
 	}
 
@@ -256,6 +257,50 @@ func TestDeployCommandRunBuildsAllTemplatesAndRunsProvisionerOnlyOnLast(t *testi
 	}
 }
 
+func TestDeployCommandRunStopsWhenInterrupted(t *testing.T) {
+	tmp := t.TempDir()
+	setWorkingDir(t, tmp)
+	templatePath := filepath.Join(tmp, "template.yaml")
+	if err := os.WriteFile(templatePath, []byte("Resources: {}"), 0o600); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	builder := &deployEntryBuilder{}
+	provisioner := &deployEntryProvisioner{}
+	cmd := &deployCommand{
+		build:         builder.Build,
+		applyRuntime:  func(state.Context) error { return nil },
+		ui:            deployEntryUI{},
+		composeRunner: deployEntryRunner{},
+		workflow: deployWorkflowDeps{
+			composeProvisioner: provisioner,
+			registryWaiter:     func(context.Context, string, time.Duration) error { return nil },
+		},
+		ctx: ctx,
+	}
+
+	err := cmd.runWithOverrides(
+		deployInputs{
+			ProjectDir: tmp,
+			Env:        "dev",
+			Mode:       "docker",
+			Project:    "esb-dev",
+			Templates:  []deployTemplateInput{{TemplatePath: templatePath, OutputDir: ".out"}},
+		},
+		DeployCmd{},
+		deployRunOverrides{},
+	)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected cancellation error, got %v", err)
+	}
+	if len(builder.requests) != 0 || provisioner.runCalls != 0 {
+		t.Fatalf("cancelled deploy must not build or provision: builds=%d provision=%d",
+			len(builder.requests), provisioner.runCalls)
+	}
+}
+
 func TestDeployCommandRunWithDepsDisablesNoDeps(t *testing.T) {
 	tmp := t.TempDir()
 	setWorkingDir(t, tmp)
@@ -277,7 +322,7 @@ func TestDeployCommandRunWithDepsDisablesNoDeps(t *testing.T) {
 		composeRunner: deployEntryRunner{},
 		workflow: deployWorkflowDeps{
 			composeProvisioner: provisioner,
-			registryWaiter:     func(string, time.Duration) error { return nil },
+			registryWaiter:     func(context.Context, string, time.Duration) error { return nil },
 		},
 	}
 
@@ -325,7 +370,7 @@ func TestDeployCommandRunAllowsRenderOnlyGenerate(t *testing.T) {
 		composeRunner: deployEntryRunner{},
 		workflow: deployWorkflowDeps{
 			composeProvisioner: provisioner,
-			registryWaiter:     func(string, time.Duration) error { return nil },
+			registryWaiter:     func(context.Context, string, time.Duration) error { return nil },
 		},
 	}
 
@@ -447,6 +492,95 @@ func TestDeployCommandRunBuildOnlyRejectsSecretEnv(t *testing.T) {
 	}
 }
 
+func TestDeployCommandRunDryRunSkipsBuildAndSync(t *testing.T) {
+	tmp := t.TempDir()
+	setWorkingDir(t, tmp)
+	if err := os.WriteFile(filepath.Join(tmp, "docker-compose.docker.yml"), []byte("services: {}\n"), 0o600); err != nil {
+		t.Fatalf("write compose marker: %v", err)
+	}
+	templatePath := filepath.Join(tmp, "template.yaml")
+	if err := os.WriteFile(templatePath, []byte("Resources: {}"), 0o600); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+	writeTestRuntimeAssets(t, tmp)
+
+	builder := &deployEntryBuilder{}
+	provisioner := &deployEntryProvisioner{}
+	cmd := &deployCommand{
+		build:         builder.Build,
+		applyRuntime:  func(state.Context) error { return nil },
+		ui:            deployEntryUI{},
+		composeRunner: deployEntryRunner{},
+		workflow: deployWorkflowDeps{
+			composeProvisioner: provisioner,
+			registryWaiter:     func(context.Context, string, time.Duration) error { return nil },
+		},
+	}
+
+	artifactRoot := filepath.Join(tmp, "artifact-root")
+	err := cmd.runWithOverrides(
+		deployInputs{
+			ProjectDir:   tmp,
+			ArtifactRoot: artifactRoot,
+			Env:          "dev",
+			Mode:         "docker",
+			Project:      "esb-dev",
+			Templates: []deployTemplateInput{{
+				TemplatePath: templatePath,
+				OutputDir:    filepath.Join(artifactRoot, "artifacts", "artifact-a"),
+			}},
+		},
+		DeployCmd{DryRun: true},
+		deployRunOverrides{},
+	)
+	if err != nil {
+		t.Fatalf("run deploy dry-run: %v", err)
+	}
+	if len(builder.requests) != 1 || builder.requests[0].BuildImages {
+		t.Fatalf("expected one render-only request, got %#v", builder.requests)
+	}
+	if strings.HasPrefix(builder.requests[0].OutputDir, artifactRoot) {
+		t.Fatalf("dry-run must render outside the artifact root, got %s", builder.requests[0].OutputDir)
+	}
+	if provisioner.runCalls != 0 {
+		t.Fatalf("provisioner must not run on dry-run, got %d", provisioner.runCalls)
+	}
+	if _, err := os.Stat(artifactRoot); !os.IsNotExist(err) {
+		t.Fatalf("dry-run must not write the artifact root, stat err=%v", err)
+	}
+}
+
+func TestResolveDeployRunConfigRejectsDryRunWithBuildOnly(t *testing.T) {
+	_, err := resolveDeployRunConfig(DeployCmd{DryRun: true}, deployRunOverrides{forceBuildOnly: true})
+	if err == nil || !strings.Contains(err.Error(), "--dry-run cannot be used with --build-only") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestResolveDeployRunConfigValidatesBuildCache(t *testing.T) {
+	if _, err := resolveDeployRunConfig(DeployCmd{BuildCache: "s3=bucket"}, deployRunOverrides{}); err == nil ||
+		!strings.Contains(err.Error(), "invalid build cache") {
+		t.Fatalf("expected invalid build cache error, got %v", err)
+	}
+	if _, err := resolveDeployRunConfig(DeployCmd{BuildCache: "inline", DryRun: true}, deployRunOverrides{}); err == nil ||
+		!strings.Contains(err.Error(), "--build-cache cannot be used with --dry-run") {
+		t.Fatalf("expected dry-run conflict, got %v", err)
+	}
+	if _, err := resolveDeployRunConfig(DeployCmd{BuildCache: "registry=ghcr.io/acme/cache"}, deployRunOverrides{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestResolveDeployRunConfigSignKeyRequiresBundle(t *testing.T) {
+	if _, err := resolveDeployRunConfig(DeployCmd{SignKey: "cosign.key"}, deployRunOverrides{}); err == nil ||
+		!strings.Contains(err.Error(), "--sign-key requires --bundle-manifest") {
+		t.Fatalf("expected bundle requirement error, got %v", err)
+	}
+	if _, err := resolveDeployRunConfig(DeployCmd{SignKey: "cosign.key", Bundle: true}, deployRunOverrides{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 func writeTestRuntimeAssets(t *testing.T, root string) {
 	t.Helper()
 	files := map[string]string{
@@ -537,7 +671,7 @@ func TestNewDeployCommandCopiesConfig(t *testing.T) {
 		ui:            deployEntryUI{},
 		composeRunner: runner,
 		workflow: deployWorkflowDeps{
-			registryWaiter: func(string, time.Duration) error { return nil },
+			registryWaiter: func(context.Context, string, time.Duration) error { return nil },
 		},
 		emojiEnabled: true,
 	}
@@ -553,3 +687,62 @@ func TestNewDeployCommandCopiesConfig(t *testing.T) {
 		t.Fatal("expected emojiEnabled=true")
 	}
 }
+
+func TestDeployCommandRunForwardsFunctionSelection(t *testing.T) {
+	tmp := t.TempDir()
+	setWorkingDir(t, tmp)
+	if err := os.WriteFile(filepath.Join(tmp, "docker-compose.docker.yml"), []byte("services: {}\n"), 0o600); err != nil {
+		t.Fatalf("write compose marker: %v", err)
+	}
+	templatePath := filepath.Join(tmp, "template.yaml")
+	template := `
+Resources:
+  HelloFunction:
+    Type: AWS::Serverless::Function
+    Properties:
+      FunctionName: api-hello
+      CodeUri: functions/hello/
+      Handler: app.handler
+      Runtime: python3.12
+`
+	if err := os.WriteFile(templatePath, []byte(template), 0o600); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+	writeTestRuntimeAssets(t, tmp)
+
+	builder := &deployEntryBuilder{}
+	cmd := &deployCommand{
+		build:         builder.Build,
+		applyRuntime:  func(state.Context) error { return nil },
+		ui:            deployEntryUI{},
+		composeRunner: deployEntryRunner{},
+		workflow: deployWorkflowDeps{
+			composeProvisioner: &deployEntryProvisioner{},
+			registryWaiter:     func(context.Context, string, time.Duration) error { return nil },
+		},
+	}
+	inputs := deployInputs{
+		ProjectDir:   tmp,
+		ArtifactRoot: filepath.Join(tmp, "artifact-root"),
+		Env:          "dev",
+		Mode:         "docker",
+		Project:      "esb-dev",
+		Templates:    []deployTemplateInput{{TemplatePath: templatePath, OutputDir: ".out/a"}},
+	}
+
+	err := cmd.runWithOverrides(inputs, DeployCmd{Functions: []string{"api-*"}}, deployRunOverrides{})
+	if err != nil {
+		t.Fatalf("run deploy command: %v", err)
+	}
+	if len(builder.requests) != 1 || len(builder.requests[0].Functions) != 1 || builder.requests[0].Functions[0] != "api-*" {
+		t.Fatalf("expected function selection to be forwarded, got %#v", builder.requests)
+	}
+
+	err = cmd.runWithOverrides(inputs, DeployCmd{Functions: []string{"missing"}}, deployRunOverrides{})
+	if err == nil || !strings.Contains(err.Error(), "matched no functions: missing") {
+		t.Fatalf("expected unmatched pattern error, got %v", err)
+	}
+	if len(builder.requests) != 1 {
+		t.Fatalf("unmatched selection must fail before building, got %d requests", len(builder.requests))
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path"
 	"sort"
 	"strings"
 
@@ -19,11 +20,16 @@ const (
 	defaultImageRuntimeChoice = "python"
 	defaultImageRuntimeValue  = "python3.12"
 	imageRuntimeJava21        = "java21"
+	imageRuntimeNodejsChoice  = "nodejs"
+	imageRuntimeNodejs20      = "nodejs20.x"
+	imageRuntimeNodejs22      = "nodejs22.x"
 )
 
 type imageRuntimePromptTarget struct {
 	Name        string
 	ImageSource string
+	// Dockerfile is set for image functions built from SAM Metadata.
+	Dockerfile string
 }
 
 func promptTemplateImageRuntimes(
@@ -119,12 +125,17 @@ func discoverImageRuntimePromptTargets(
 	targets := make([]imageRuntimePromptTarget, 0, len(parsed.Functions))
 	for _, fn := range parsed.Functions {
 		source := strings.TrimSpace(fn.ImageSource)
-		if source == "" {
+		dockerfile := ""
+		if fn.ImageBuild != nil {
+			dockerfile = path.Join(fn.ImageBuild.Context, fn.ImageBuild.Dockerfile)
+		}
+		if source == "" && dockerfile == "" {
 			continue
 		}
 		targets = append(targets, imageRuntimePromptTarget{
 			Name:        fn.Name,
 			ImageSource: source,
+			Dockerfile:  dockerfile,
 		})
 	}
 	sort.SliceStable(targets, func(i, j int) bool {
@@ -142,6 +153,9 @@ func resolvePromptImageSource(target imageRuntimePromptTarget, imageSources map[
 	if source := strings.TrimSpace(target.ImageSource); source != "" {
 		return source
 	}
+	if target.Dockerfile != "" {
+		return "build " + target.Dockerfile
+	}
 	return "<unknown>"
 }
 
@@ -158,10 +172,12 @@ func normalizeImageRuntimeSelection(value string) (string, error) {
 	switch normalized {
 	case "", defaultImageRuntimeChoice:
 		normalized = defaultImageRuntimeValue
-	case defaultImageRuntimeValue, imageRuntimeJava21:
+	case imageRuntimeNodejsChoice:
+		normalized = imageRuntimeNodejs22
+	case defaultImageRuntimeValue, imageRuntimeJava21, imageRuntimeNodejs20, imageRuntimeNodejs22:
 		// keep as is
 	default:
-		return "", fmt.Errorf("unsupported runtime %q (use python or java21)", value)
+		return "", fmt.Errorf("unsupported runtime %q (use python, java21, nodejs20.x or nodejs22.x)", value)
 	}
 
 	profile, err := runtimecfg.Resolve(normalized)
@@ -169,7 +185,7 @@ func normalizeImageRuntimeSelection(value string) (string, error) {
 		return "", err
 	}
 	switch profile.Kind {
-	case runtimecfg.KindPython, runtimecfg.KindJava:
+	case runtimecfg.KindPython, runtimecfg.KindJava, runtimecfg.KindNode:
 		return profile.Name, nil
 	default:
 		return "", fmt.Errorf("unsupported runtime kind %q", profile.Kind)
@@ -192,7 +208,12 @@ func orderedImageRuntimeChoices(defaultChoice string) []string {
 	if strings.TrimSpace(defaultChoice) != "" {
 		out[0] = defaultChoice
 	}
-	for _, choice := range []string{defaultImageRuntimeChoice, imageRuntimeJava21} {
+	for _, choice := range []string{
+		defaultImageRuntimeChoice,
+		imageRuntimeJava21,
+		imageRuntimeNodejs20,
+		imageRuntimeNodejs22,
+	} {
 		if choice == out[0] {
 			continue
 		}
@@ -107,7 +107,7 @@ func TestPromptTemplateImageRuntimesTTYPromptsEachImageFunction(t *testing.T) {
 	if !strings.Contains(prompter.selectCalls[0].title, "public.ecr.aws/example/a:latest") {
 		t.Fatalf("expected first prompt to include image uri, got %q", prompter.selectCalls[0].title)
 	}
-	if !reflect.DeepEqual(prompter.selectCalls[1].options, []string{"java21", "python"}) {
+	if !reflect.DeepEqual(prompter.selectCalls[1].options, []string{"java21", "python", "nodejs20.x", "nodejs22.x"}) {
 		t.Fatalf("unexpected options for b-image: %v", prompter.selectCalls[1].options)
 	}
 	if !strings.Contains(prompter.selectCalls[1].title, "public.ecr.aws/example/b:latest") {
@@ -159,7 +159,7 @@ func TestPromptTemplateImageRuntimesExplicitInvalidReturnsError(t *testing.T) {
 		prompter,
 		nil,
 		map[string]string{
-			"a-image": "nodejs20.x",
+			"a-image": "ruby3.3",
 		},
 		&bytes.Buffer{},
 	)
@@ -234,7 +234,10 @@ func TestNormalizeImageRuntimeSelection(t *testing.T) {
 		{input: "python", want: "python3.12", ok: true},
 		{input: "python3.12", want: "python3.12", ok: true},
 		{input: "java21", want: "java21", ok: true},
-		{input: "nodejs20.x", ok: false},
+		{input: "nodejs", want: "nodejs22.x", ok: true},
+		{input: "nodejs20.x", want: "nodejs20.x", ok: true},
+		{input: "nodejs18.x", ok: false},
+		{input: "ruby3.3", ok: false},
 	}
 
 	for _, tc := range cases {
@@ -15,6 +15,7 @@ import (
 	"github.com/poruru-code/esb-cli/internal/infra/envutil"
 	"github.com/poruru-code/esb-cli/internal/infra/interaction"
 	runtimeinfra "github.com/poruru-code/esb-cli/internal/infra/runtime"
+	"github.com/poruru-code/esb-cli/internal/infra/sam"
 )
 
 type deployInputsResolver struct {
@@ -28,6 +29,7 @@ type deployInputsResolver struct {
 
 type deployRuntimeContext struct {
 	repoRoot         string
+	samconfig        sam.SamconfigDeploy
 	selectedStack    deployTargetStack
 	composeProject   string
 	projectSource    string
@@ -49,6 +51,10 @@ type runtimeProjectSelection struct {
 type deployTemplateOverrideInputs struct {
 	imageSources  map[string]string
 	imageRuntimes map[string]string
+	// parameters come from samconfig, --parameters-file and
+	// --parameter-overrides; parameterSources records which one won.
+	parameters       map[string]string
+	parameterSources map[string]string
 }
 
 type templateInputResolveContext struct {
@@ -60,6 +66,22 @@ type templateInputResolveContext struct {
 	overrides     deployTemplateOverrideInputs
 }
 
+// resolveDeployInputs resolves deploy inputs, re-running the resolution when
+// the user picks Edit on the confirm summary. Each value takes the first
+// source that provides it:
+//
+//   - project: --project, ENV_PROJECT_NAME/<PREFIX>_PROJECT, samconfig
+//     stack_name, running stack, prompt
+//   - env: --env, running stack, prompt
+//   - template: --template, samconfig template_file, prompt/history
+//   - parameters: --parameter-overrides, --parameters-file, samconfig
+//     parameter_overrides, prompt (previous input and .esb/config.yaml
+//     defaults as suggestions)
+//
+// samconfig.toml/.yaml is read from the working directory or repo root. The
+// env is resolved first (the --env or default section only seeds project
+// discovery), then stack_name, template_file and parameter_overrides are all
+// read from the section of the resolved env.
 func resolveDeployInputs(cli CLI, deps Dependencies) (deployInputs, error) {
 	resolver := newDeployInputsResolver(deps)
 
@@ -89,8 +111,9 @@ func (r deployInputsResolver) resolveInputIteration(
 		return deployInputs{}, err
 	}
 
+	templateValues, templateSource := resolveTemplateValues(cli.Template, runtimeCtx.samconfig)
 	templatePaths, err := resolveDeployTemplates(
-		cli.Template,
+		templateValues,
 		r.isTTY,
 		r.prompter,
 		previousTemplatePath(last),
@@ -100,12 +123,41 @@ func (r deployInputsResolver) resolveInputIteration(
 		return deployInputs{}, err
 	}
 
-	storedDefaults := loadDeployDefaults(runtimeCtx.repoRoot, templatePaths[0])
-
 	selectedEnv, err := r.resolveSelectedEnv(cli, runtimeCtx, templatePaths[0])
 	if err != nil {
 		return deployInputs{}, err
 	}
+	samconfig, err := reloadDeploySamconfig(runtimeCtx.samconfig, selectedEnv.Value)
+	if err != nil {
+		return deployInputs{}, err
+	}
+	if samconfig.Env != runtimeCtx.samconfig.Env {
+		// Runtime reconciliation moved the env to another section; its
+		// stack_name must still name the resolved project.
+		if runtimeCtx.projectSource == "samconfig" && samconfig.StackName != runtimeCtx.composeProject {
+			return deployInputs{}, fmt.Errorf(
+				"samconfig [%s] stack_name %q does not match project %q; pass --env or --project",
+				samconfig.Env,
+				samconfig.StackName,
+				runtimeCtx.composeProject,
+			)
+		}
+		if templateSource == "samconfig" && samconfig.TemplateFile != "" &&
+			samconfig.TemplateFile != runtimeCtx.samconfig.TemplateFile {
+			templatePaths, err = resolveDeployTemplates(
+				[]string{samconfig.TemplateFile},
+				r.isTTY,
+				r.prompter,
+				previousTemplatePath(last),
+				r.errOut,
+			)
+			if err != nil {
+				return deployInputs{}, err
+			}
+		}
+	}
+
+	storedDefaults := loadDeployDefaults(runtimeCtx.repoRoot, templatePaths[0])
 
 	mode, err := r.resolveMode(cli, runtimeCtx, storedDefaults, last)
 	if err != nil {
@@ -121,7 +173,9 @@ func (r deployInputsResolver) resolveInputIteration(
 		last,
 		runtimeCtx.repoRoot,
 		artifactRoot,
+		selectedEnv.Value,
 		templatePaths,
+		samconfig.ParameterOverrides,
 	)
 	if err != nil {
 		return deployInputs{}, err
@@ -132,19 +186,34 @@ func (r deployInputsResolver) resolveInputIteration(
 	}
 
 	return deployInputs{
-		ProjectDir:    runtimeCtx.repoRoot,
-		ArtifactRoot:  artifactRoot,
-		TargetStack:   runtimeCtx.selectedStack.Name,
-		Env:           selectedEnv.Value,
-		EnvSource:     selectedEnv.Source,
-		Mode:          mode,
-		Templates:     templateInputs,
-		Project:       runtimeCtx.composeProject,
-		ProjectSource: runtimeCtx.projectSource,
-		ComposeFiles:  composeFiles,
+		ProjectDir:     runtimeCtx.repoRoot,
+		ArtifactRoot:   artifactRoot,
+		TargetStack:    runtimeCtx.selectedStack.Name,
+		Env:            selectedEnv.Value,
+		EnvSource:      selectedEnv.Source,
+		Mode:           mode,
+		Templates:      templateInputs,
+		TemplateSource: templateSource,
+		Project:        runtimeCtx.composeProject,
+		ProjectSource:  runtimeCtx.projectSource,
+		ComposeFiles:   composeFiles,
 	}, nil
 }
 
+// resolveTemplateValues prefers --template over samconfig template_file;
+// without either the template is prompted (or taken from history).
+func resolveTemplateValues(flagTemplates []string, samconfig sam.SamconfigDeploy) ([]string, string) {
+	for _, value := range flagTemplates {
+		if strings.TrimSpace(value) != "" {
+			return flagTemplates, "flag"
+		}
+	}
+	if samconfig.TemplateFile != "" {
+		return []string{samconfig.TemplateFile}, "samconfig"
+	}
+	return nil, "prompt"
+}
+
 func (r deployInputsResolver) confirmAndPersistResolvedInputs(
 	noSave bool,
 	inputs deployInputs,
@@ -191,7 +260,11 @@ func (r deployInputsResolver) resolveRuntimeContext(cli CLI, last deployInputs)
 	if err != nil {
 		return deployRuntimeContext{}, err
 	}
-	selection, err := r.resolveRuntimeProjectSelection(cli, last)
+	samconfig, err := loadDeploySamconfig(repoRoot, cli.EnvFlag)
+	if err != nil {
+		return deployRuntimeContext{}, err
+	}
+	selection, err := r.resolveRuntimeProjectSelection(cli, last, samconfig)
 	if err != nil {
 		return deployRuntimeContext{}, err
 	}
@@ -206,9 +279,30 @@ func (r deployInputsResolver) resolveRuntimeContext(cli CLI, last deployInputs)
 	if err != nil {
 		return deployRuntimeContext{}, err
 	}
+	envSamconfig, err := reloadDeploySamconfig(samconfig, selectedEnv.Value)
+	if err != nil {
+		return deployRuntimeContext{}, err
+	}
+	if envSamconfig.StackName != samconfig.StackName {
+		// The env came from a running stack or a prompt and its section
+		// names another stack: redo project selection with that section.
+		envCLI := cli
+		envCLI.EnvFlag = selectedEnv.Value
+		if selection, err = r.resolveRuntimeProjectSelection(envCLI, last, envSamconfig); err != nil {
+			return deployRuntimeContext{}, err
+		}
+		if composeProject, projectSource, err = r.resolveRuntimeComposeProject(envCLI, selection); err != nil {
+			return deployRuntimeContext{}, err
+		}
+		if strings.TrimSpace(composeProject) == "" {
+			return deployRuntimeContext{}, errComposeProjectRequired
+		}
+	}
+	samconfig = envSamconfig
 	inferredMode, inferredModeSource, modeInferErr := r.resolveRuntimeModeInference(composeProject)
 	return deployRuntimeContext{
 		repoRoot:         repoRoot,
+		samconfig:        samconfig,
 		selectedStack:    selection.selectedStack,
 		composeProject:   composeProject,
 		projectSource:    projectSource,
@@ -234,9 +328,13 @@ func (r deployInputsResolver) resolveRuntimeRepoRoot() (string, error) {
 func (r deployInputsResolver) resolveRuntimeProjectSelection(
 	cli CLI,
 	last deployInputs,
+	samconfig sam.SamconfigDeploy,
 ) (runtimeProjectSelection, error) {
 	prevEnv := strings.TrimSpace(last.Env)
 	projectValue, projectValueSource, projectExplicit := resolveProjectValue(cli.Deploy.Project)
+	if !projectExplicit && samconfig.StackName != "" {
+		projectValue, projectValueSource, projectExplicit = samconfig.StackName, "samconfig", true
+	}
 	selectedStack, err := r.resolveRuntimeSelectedStack(projectExplicit)
 	if err != nil {
 		return runtimeProjectSelection{}, err
@@ -454,9 +552,11 @@ func (r deployInputsResolver) resolveTemplateInputs(
 	last deployInputs,
 	repoRoot string,
 	artifactRoot string,
+	env string,
 	templatePaths []string,
+	samconfigParameters []string,
 ) ([]deployTemplateInput, error) {
-	overrides, err := parseDeployTemplateOverrideInputs(cli.Deploy)
+	overrides, err := parseDeployTemplateOverrideInputs(cli.Deploy, env, samconfigParameters)
 	if err != nil {
 		return nil, err
 	}
@@ -476,10 +576,17 @@ func (r deployInputsResolver) resolveTemplateInputs(
 		}
 		templateInputs = append(templateInputs, templateInput)
 	}
+	if unknown := unknownParameterOverrides(overrides.parameters, templateInputs); len(unknown) > 0 {
+		return nil, fmt.Errorf("parameters not declared in any template: %s", strings.Join(unknown, ", "))
+	}
 	return templateInputs, nil
 }
 
-func parseDeployTemplateOverrideInputs(deployFlags DeployCmd) (deployTemplateOverrideInputs, error) {
+func parseDeployTemplateOverrideInputs(
+	deployFlags DeployCmd,
+	env string,
+	samconfigParameters []string,
+) (deployTemplateOverrideInputs, error) {
 	imageSources, err := parseFunctionOverrideFlag(deployFlags.ImageURI, "--image-uri")
 	if err != nil {
 		return deployTemplateOverrideInputs{}, err
@@ -488,9 +595,15 @@ func parseDeployTemplateOverrideInputs(deployFlags DeployCmd) (deployTemplateOve
 	if err != nil {
 		return deployTemplateOverrideInputs{}, err
 	}
+	parameters, parameterSources, err := resolveParameterInputs(deployFlags, env, samconfigParameters)
+	if err != nil {
+		return deployTemplateOverrideInputs{}, err
+	}
 	return deployTemplateOverrideInputs{
-		imageSources:  imageSources,
-		imageRuntimes: imageRuntimes,
+		imageSources:     imageSources,
+		imageRuntimes:    imageRuntimes,
+		parameters:       parameters,
+		parameterSources: parameterSources,
 	}, nil
 }
 
@@ -510,12 +623,24 @@ func (r deployInputsResolver) resolveSingleTemplateInput(
 ) (deployTemplateInput, error) {
 	storedTemplate := loadDeployDefaults(ctx.repoRoot, templatePath)
 
-	prevParams := resolvePreviousTemplateParameters(
+	prevParams, prevSource := resolvePreviousTemplateParameters(
 		templatePath,
 		ctx.prevTemplates,
 		storedTemplate.Params,
 	)
-	params, err := promptTemplateParameters(templatePath, r.isTTY, r.prompter, prevParams, r.errOut)
+	params, promptedSources, err := promptTemplateParameters(
+		templatePath,
+		r.isTTY,
+		r.prompter,
+		prevParams,
+		prevSource,
+		ctx.overrides.parameters,
+		r.errOut,
+	)
+	if err != nil {
+		return deployTemplateInput{}, err
+	}
+	noEcho, err := templateNoEchoParameters(templatePath)
 	if err != nil {
 		return deployTemplateInput{}, err
 	}
@@ -563,11 +688,13 @@ func (r deployInputsResolver) resolveSingleTemplateInput(
 	outputDir := deriveTemplateArtifactOutputDir(ctx.artifactRoot, artifactID)
 
 	return deployTemplateInput{
-		TemplatePath:  templatePath,
-		OutputDir:     outputDir,
-		Parameters:    params,
-		ImageSources:  templateImageSources,
-		ImageRuntimes: imageRuntimes,
+		TemplatePath:     templatePath,
+		OutputDir:        outputDir,
+		Parameters:       params,
+		ParameterSources: templateParameterSources(ctx.overrides.parameterSources, promptedSources, params),
+		NoEchoParameters: noEcho,
+		ImageSources:     templateImageSources,
+		ImageRuntimes:    imageRuntimes,
 	}, nil
 }
 
@@ -609,15 +736,18 @@ func (r deployInputsResolver) resolveComposeFiles(
 	)
 }
 
+// resolvePreviousTemplateParameters returns the values suggested at the
+// prompt and their summary source: the last Edit iteration (entered at the
+// prompt) or the .esb/config.yaml defaults (saved-defaults).
 func resolvePreviousTemplateParameters(
 	templatePath string,
 	prevTemplates map[string]deployTemplateInput,
 	storedParams map[string]string,
-) map[string]string {
+) (map[string]string, string) {
 	if prev, ok := prevTemplates[templatePath]; ok && len(prev.Parameters) > 0 {
-		return prev.Parameters
+		return prev.Parameters, parameterSourcePrompt
 	}
-	return storedParams
+	return storedParams, parameterSourceSavedDefaults
 }
 
 func resolvePreviousTemplateImageRuntimes(
@@ -14,6 +14,7 @@ import (
 	"github.com/poruru-code/esb-cli/internal/infra/compose"
 	"github.com/poruru-code/esb-cli/internal/infra/config"
 	"github.com/poruru-code/esb-cli/internal/infra/interaction"
+	runtimeinfra "github.com/poruru-code/esb-cli/internal/infra/runtime"
 )
 
 func TestResolveDeployInputsWarnsWhenRuntimeDiscoveryFails(t *testing.T) {
@@ -65,6 +66,168 @@ func TestResolveDeployInputsWarnsWhenRuntimeDiscoveryFails(t *testing.T) {
 	}
 }
 
+func TestResolveDeployInputsUsesSamconfigLayer(t *testing.T) {
+	repoRoot := t.TempDir()
+	setWorkingDir(t, t.TempDir())
+	if err := os.WriteFile(filepath.Join(repoRoot, "docker-compose.docker.yml"), []byte("version: '3'\n"), 0o600); err != nil {
+		t.Fatalf("write compose marker: %v", err)
+	}
+	template := "Parameters:\n  Stage:\n    Type: String\n  Size:\n    Type: Number\nResources: {}\n"
+	if err := os.WriteFile(filepath.Join(repoRoot, "template.yaml"), []byte(template), 0o600); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+	samconfig := `version = 0.1
+[default.deploy.parameters]
+stack_name = "esb-default"
+[prod.deploy.parameters]
+stack_name = "esb-prod"
+template_file = "template.yaml"
+parameter_overrides = "Stage=prod Size=1"
+`
+	if err := os.WriteFile(filepath.Join(repoRoot, "samconfig.toml"), []byte(samconfig), 0o600); err != nil {
+		t.Fatalf("write samconfig: %v", err)
+	}
+
+	cli := CLI{
+		EnvFlag: "prod",
+		Deploy: DeployCmd{
+			Mode:               "docker",
+			NoSave:             true,
+			ParameterOverrides: []string{"Size=2"},
+		},
+	}
+	deps := Dependencies{
+		ErrOut: &bytes.Buffer{},
+		RepoResolver: func(string) (string, error) {
+			return repoRoot, nil
+		},
+	}
+
+	inputs, err := resolveDeployInputs(cli, deps)
+	if err != nil {
+		t.Fatalf("resolve deploy inputs: %v", err)
+	}
+	if inputs.Project != "esb-prod" || inputs.ProjectSource != "samconfig" {
+		t.Fatalf("expected samconfig project, got %q (%s)", inputs.Project, inputs.ProjectSource)
+	}
+	if inputs.TemplateSource != "samconfig" || len(inputs.Templates) != 1 ||
+		inputs.Templates[0].TemplatePath != filepath.Join(repoRoot, "template.yaml") {
+		t.Fatalf("expected samconfig template, got %#v (%s)", inputs.Templates, inputs.TemplateSource)
+	}
+	tpl := inputs.Templates[0]
+	if tpl.Parameters["Stage"] != "prod" || tpl.Parameters["Size"] != "2" {
+		t.Fatalf("unexpected parameters: %#v", tpl.Parameters)
+	}
+	if tpl.ParameterSources["Stage"] != "samconfig" || tpl.ParameterSources["Size"] != "flag" {
+		t.Fatalf("unexpected parameter sources: %#v", tpl.ParameterSources)
+	}
+}
+
+func TestResolveDeployInputsReadsSamconfigParametersForResolvedEnv(t *testing.T) {
+	repoRoot := t.TempDir()
+	setWorkingDir(t, t.TempDir())
+	if err := os.WriteFile(filepath.Join(repoRoot, "docker-compose.docker.yml"), []byte("version: '3'\n"), 0o600); err != nil {
+		t.Fatalf("write compose marker: %v", err)
+	}
+	templatePath := filepath.Join(repoRoot, "template.yaml")
+	if err := os.WriteFile(templatePath, []byte("Parameters:\n  Stage:\n    Type: String\nResources: {}\n"), 0o600); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+	samconfig := `version = 0.1
+[default.deploy.parameters]
+stack_name = "esb-dev"
+parameter_overrides = "Stage=default"
+[prod.deploy.parameters]
+parameter_overrides = "Stage=prod"
+`
+	if err := os.WriteFile(filepath.Join(repoRoot, "samconfig.toml"), []byte(samconfig), 0o600); err != nil {
+		t.Fatalf("write samconfig: %v", err)
+	}
+
+	cli := CLI{
+		Template: []string{templatePath},
+		Deploy:   DeployCmd{Mode: "docker", NoSave: true},
+	}
+	deps := Dependencies{
+		ErrOut: &bytes.Buffer{},
+		RepoResolver: func(string) (string, error) {
+			return repoRoot, nil
+		},
+		Deploy: DeployDeps{
+			Runtime: DeployRuntimeDeps{
+				RuntimeEnvResolver: fixedEnvResolver{inferred: runtimeinfra.EnvInference{Env: "prod", Source: "container label"}},
+			},
+		},
+	}
+
+	inputs, err := resolveDeployInputs(cli, deps)
+	if err != nil {
+		t.Fatalf("resolve deploy inputs: %v", err)
+	}
+	if inputs.Env != "prod" {
+		t.Fatalf("expected inferred env, got %q", inputs.Env)
+	}
+	if got := inputs.Templates[0].Parameters["Stage"]; got != "prod" {
+		t.Fatalf("samconfig parameters must follow the resolved env, got Stage=%q", got)
+	}
+}
+
+func TestResolveDeployInputsReadsEverySamconfigKeyFromResolvedEnv(t *testing.T) {
+	repoRoot := t.TempDir()
+	setWorkingDir(t, t.TempDir())
+	if err := os.WriteFile(filepath.Join(repoRoot, "docker-compose.docker.yml"), []byte("version: '3'\n"), 0o600); err != nil {
+		t.Fatalf("write compose marker: %v", err)
+	}
+	for _, name := range []string{"template.dev.yaml", "template.prod.yaml"} {
+		if err := os.WriteFile(filepath.Join(repoRoot, name), []byte("Parameters:\n  Stage:\n    Type: String\nResources: {}\n"), 0o600); err != nil {
+			t.Fatalf("write template: %v", err)
+		}
+	}
+	samconfig := `version = 0.1
+[default.deploy.parameters]
+stack_name = "esb-dev"
+template_file = "template.dev.yaml"
+parameter_overrides = "Stage=default"
+[prod.deploy.parameters]
+stack_name = "esb-prod"
+template_file = "template.prod.yaml"
+parameter_overrides = "Stage=prod"
+`
+	if err := os.WriteFile(filepath.Join(repoRoot, "samconfig.toml"), []byte(samconfig), 0o600); err != nil {
+		t.Fatalf("write samconfig: %v", err)
+	}
+
+	cli := CLI{Deploy: DeployCmd{Mode: "docker", NoSave: true}}
+	deps := Dependencies{
+		ErrOut: &bytes.Buffer{},
+		RepoResolver: func(string) (string, error) {
+			return repoRoot, nil
+		},
+		Deploy: DeployDeps{
+			Runtime: DeployRuntimeDeps{
+				RuntimeEnvResolver: fixedEnvResolver{inferred: runtimeinfra.EnvInference{Env: "prod", Source: "container label"}},
+			},
+		},
+	}
+
+	inputs, err := resolveDeployInputs(cli, deps)
+	if err != nil {
+		t.Fatalf("resolve deploy inputs: %v", err)
+	}
+	if inputs.Env != "prod" {
+		t.Fatalf("expected inferred env, got %q", inputs.Env)
+	}
+	if inputs.Project != "esb-prod" || inputs.ProjectSource != "samconfig" {
+		t.Fatalf("stack_name must come from the prod section, got %q (%s)", inputs.Project, inputs.ProjectSource)
+	}
+	if got := inputs.Templates[0].TemplatePath; got != filepath.Join(repoRoot, "template.prod.yaml") {
+		t.Fatalf("template_file must come from the prod section, got %q", got)
+	}
+	if got := inputs.Templates[0].Parameters["Stage"]; got != "prod" {
+		t.Fatalf("parameter_overrides must come from the prod section, got Stage=%q", got)
+	}
+}
+
 func TestResolveDeployInputsAllowsTemplateOutsideRepo(t *testing.T) {
 	repoRoot := filepath.Join(t.TempDir(), "repo")
 	if err := os.MkdirAll(repoRoot, 0o755); err != nil {
@@ -0,0 +1,61 @@
+// Where: cli/internal/command/deploy_output_events.go
+// What: Structured deploy input events for JSON output mode.
+// Why: Give CI the resolved deploy inputs without parsing the plan block.
+package command
+
+import "github.com/poruru-code/esb-cli/internal/infra/ui"
+
+type deployInputsEvent struct {
+	ProjectDir   string                `json:"project_dir"`
+	ArtifactRoot string                `json:"artifact_root,omitempty"`
+	Env          string                `json:"env"`
+	EnvSource    string                `json:"env_source,omitempty"`
+	Mode         string                `json:"mode"`
+	Project      string                `json:"project"`
+	ComposeFiles []string              `json:"compose_files,omitempty"`
+	Templates    []deployTemplateEvent `json:"templates"`
+	BuildOnly    bool                  `json:"build_only"`
+	DryRun       bool                  `json:"dry_run,omitempty"`
+	Functions    []string              `json:"functions,omitempty"`
+	BuildImages  bool                  `json:"build_images"`
+	Tag          string                `json:"tag"`
+}
+
+type deployTemplateEvent struct {
+	TemplatePath  string            `json:"template"`
+	OutputDir     string            `json:"output_dir,omitempty"`
+	Parameters    map[string]string `json:"parameters,omitempty"`
+	ImageSources  map[string]string `json:"image_sources,omitempty"`
+	ImageRuntimes map[string]string `json:"image_runtimes,omitempty"`
+}
+
+func (c *deployCommand) emitInputs(inputs deployInputs, runConfig deployRunConfig) {
+	if c.events == nil {
+		return
+	}
+	templates := make([]deployTemplateEvent, 0, len(inputs.Templates))
+	for _, tpl := range inputs.Templates {
+		templates = append(templates, deployTemplateEvent{
+			TemplatePath:  tpl.TemplatePath,
+			OutputDir:     tpl.OutputDir,
+			Parameters:    maskNoEchoParameters(tpl.Parameters, tpl.NoEchoParameters),
+			ImageSources:  tpl.ImageSources,
+			ImageRuntimes: tpl.ImageRuntimes,
+		})
+	}
+	c.events.Emit(ui.EventInputs, deployInputsEvent{
+		ProjectDir:   inputs.ProjectDir,
+		ArtifactRoot: inputs.ArtifactRoot,
+		Env:          inputs.Env,
+		EnvSource:    inputs.EnvSource,
+		Mode:         inputs.Mode,
+		Project:      inputs.Project,
+		ComposeFiles: inputs.ComposeFiles,
+		Templates:    templates,
+		BuildOnly:    runConfig.buildOnly,
+		DryRun:       runConfig.dryRun,
+		Functions:    runConfig.functions,
+		BuildImages:  runConfig.buildImages,
+		Tag:          runConfig.tag,
+	})
+}
@@ -0,0 +1,117 @@
+// Where: cli/internal/command/deploy_plan.go
+// What: Dry-run plan flow for deploy/diff commands.
+// Why: Preview per-entry runtime config changes before touching a shared environment.
+package command
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	domaincfg "github.com/poruru-code/esb-cli/internal/domain/config"
+	"github.com/poruru-code/esb-cli/internal/infra/ui"
+	"github.com/poruru-code/esb-cli/internal/usecase/deploy"
+)
+
+// runDiff executes the 'diff' command (deploy --dry-run).
+func runDiff(cli CLI, deps Dependencies, out io.Writer) int {
+	diffCLI := cli
+	diffCLI.Deploy = diffToDeployFlags(cli.Diff)
+	return runDeployWithOverrides(diffCLI, deps, out, deployRunOverrides{forceDryRun: true})
+}
+
+func diffToDeployFlags(cmd DiffCmd) DeployCmd {
+	return DeployCmd{
+		Mode:               cmd.Mode,
+		ArtifactRoot:       cmd.ArtifactRoot,
+		Project:            cmd.Project,
+		ComposeFiles:       append([]string(nil), cmd.ComposeFiles...),
+		ImageURI:           append([]string(nil), cmd.ImageURI...),
+		ImageRuntime:       append([]string(nil), cmd.ImageRuntime...),
+		ParameterOverrides: append([]string(nil), cmd.ParameterOverrides...),
+		ParametersFile:     cmd.ParametersFile,
+		DryRun:             true,
+		SecretEnv:          cmd.SecretEnv,
+		Verbose:            cmd.Verbose,
+		Emoji:              cmd.Emoji,
+		NoEmoji:            cmd.NoEmoji,
+		Force:              cmd.Force,
+		NoSave:             cmd.NoSave,
+	}
+}
+
+// runPlanPhase renders every template into a scratch artifact root, diffs the
+// merged config against the live runtime config and prints the plan.
+// No images are built and nothing is synced.
+func (c *deployCommand) runPlanPhase(
+	workflow deploy.Workflow,
+	inputs deployInputs,
+	flags DeployCmd,
+	runConfig deployRunConfig,
+) error {
+	planRoot, err := os.MkdirTemp("", "esb-plan-*")
+	if err != nil {
+		return fmt.Errorf("create plan artifact root: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(planRoot) }()
+
+	planInputs := planDeployInputs(inputs, planRoot)
+	for _, tpl := range planInputs.Templates {
+		request := c.newGenerateRequest(planInputs, tpl, flags, runConfig)
+		request.BundleManifest = false
+		if err := workflow.Render(request); err != nil {
+			return fmt.Errorf("deploy plan render (%s): %w", tpl.TemplatePath, err)
+		}
+	}
+	manifestPath, err := writeDeployArtifactManifest(planInputs, false)
+	if err != nil {
+		return fmt.Errorf("write plan artifact manifest: %w", err)
+	}
+
+	applyTemplate := planInputs.Templates[0]
+	applyReq := c.newApplyRequest(planInputs, applyTemplate, flags, runConfig, manifestPath)
+	result, err := workflow.Plan(applyReq)
+	if err != nil {
+		return fmt.Errorf("deploy plan (%s): %w", applyTemplate.TemplatePath, err)
+	}
+	c.renderConfigPlan(result)
+	return nil
+}
+
+// planDeployInputs redirects the artifact root and every template output
+// into planRoot so that dry runs never overwrite real artifacts.
+func planDeployInputs(inputs deployInputs, planRoot string) deployInputs {
+	planned := inputs
+	planned.ArtifactRoot = planRoot
+	planned.Templates = make([]deployTemplateInput, 0, len(inputs.Templates))
+	for idx, tpl := range inputs.Templates {
+		tpl.OutputDir = deriveTemplateArtifactOutputDir(planRoot, fmt.Sprintf("%d-%s", idx, filepath.Base(tpl.OutputDir)))
+		planned.Templates = append(planned.Templates, tpl)
+	}
+	return planned
+}
+
+func (c *deployCommand) renderConfigPlan(result deploy.PlanResult) {
+	if c.events != nil {
+		c.events.Emit(ui.EventConfigPlan, ui.ConfigPlanEvent{
+			LiveSource: result.LiveSource,
+			Entries:    result.Plan.Entries,
+		})
+	}
+	if c.ui == nil {
+		return
+	}
+	added, updated, removed := result.Plan.Counts()
+	c.ui.Block("🔍", "Config plan", []ui.KeyValue{
+		{Key: "Live config", Value: result.LiveSource},
+		{Key: "Changes", Value: fmt.Sprintf("added %d / updated %d / removed %d", added, updated, removed)},
+	})
+	if result.Plan.Empty() {
+		c.ui.Info("No runtime config changes.")
+		return
+	}
+	for _, line := range domaincfg.FormatPlan(result.Plan) {
+		c.ui.Info(line)
+	}
+}
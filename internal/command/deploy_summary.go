@@ -39,6 +39,9 @@ func confirmDeployInputs(inputs deployInputs, isTTY bool, prompter interaction.P
 		fmt.Sprintf("Mode: %s", inputs.Mode),
 		fmt.Sprintf("Artifact root: %s", inputs.ArtifactRoot),
 	)
+	if source := strings.TrimSpace(inputs.TemplateSource); source != "" {
+		summaryLines = append(summaryLines, fmt.Sprintf("Template source: %s", source))
+	}
 	if len(inputs.Templates) == 1 {
 		summaryLines = appendTemplateSummaryLines(
 			summaryLines,
@@ -104,7 +107,12 @@ func appendTemplateSummaryLines(
 		sort.Strings(keys)
 		lines = append(lines, "Parameters:")
 		for _, key := range keys {
-			lines = append(lines, fmt.Sprintf("  %s = %s", key, tpl.Parameters[key]))
+			// Values without a recorded source were entered at the prompt.
+			source := tpl.ParameterSources[key]
+			if source == "" {
+				source = parameterSourcePrompt
+			}
+			lines = append(lines, fmt.Sprintf("  %s = %s (%s)", key, tpl.Parameters[key], source))
 		}
 	}
 	if len(tpl.ImageRuntimes) == 0 {
@@ -56,12 +56,13 @@ func TestConfirmDeployInputsProceedAndSummaryContents(t *testing.T) {
 	templatePath := writeSummaryTemplate(t)
 	prompter := &summaryPrompter{choice: "proceed"}
 	inputs := deployInputs{
-		TargetStack:   "esb-dev",
-		Project:       "esb-dev",
-		ProjectSource: "stack",
-		Env:           "dev",
-		EnvSource:     "stack",
-		Mode:          "docker",
+		TargetStack:    "esb-dev",
+		Project:        "esb-dev",
+		ProjectSource:  "stack",
+		Env:            "dev",
+		EnvSource:      "stack",
+		Mode:           "docker",
+		TemplateSource: "samconfig",
 		Templates: []deployTemplateInput{
 			{
 				TemplatePath: templatePath,
@@ -70,6 +71,7 @@ func TestConfirmDeployInputsProceedAndSummaryContents(t *testing.T) {
 					"B": "2",
 					"A": "1",
 				},
+				ParameterSources: map[string]string{"B": "samconfig"},
 				ImageRuntimes: map[string]string{
 					"b-image": "python3.12",
 					"a-image": "java21",
@@ -101,8 +103,11 @@ func TestConfirmDeployInputsProceedAndSummaryContents(t *testing.T) {
 	if !strings.Contains(prompter.summary, "Env: dev (stack)") {
 		t.Fatalf("missing env source in summary: %q", prompter.summary)
 	}
-	idxA := strings.Index(prompter.summary, "  A = 1")
-	idxB := strings.Index(prompter.summary, "  B = 2")
+	if !strings.Contains(prompter.summary, "Template source: samconfig") {
+		t.Fatalf("missing template source in summary: %q", prompter.summary)
+	}
+	idxA := strings.Index(prompter.summary, "  A = 1 (prompt)")
+	idxB := strings.Index(prompter.summary, "  B = 2 (samconfig)")
 	if idxA < 0 || idxB < 0 || idxA > idxB {
 		t.Fatalf("expected sorted params in summary, got %q", prompter.summary)
 	}
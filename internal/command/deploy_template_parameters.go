@@ -0,0 +1,373 @@
+// Where: cli/internal/command/deploy_template_parameters.go
+// What: Non-interactive template parameter inputs and constraint validation.
+// Why: Let CI pass SAM parameters via flags or files and fail before building.
+package command
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/poruru-code/esb-cli/internal/infra/sam"
+	"github.com/poruru-code/esb/pkg/yamlshape"
+	"gopkg.in/yaml.v3"
+)
+
+var errInvalidParameterOverride = errors.New("invalid --parameter-overrides entry")
+
+// Parameter value sources shown in the deploy summary, lowest priority first.
+const (
+	parameterSourcePrompt          = "prompt"
+	parameterSourceTemplateDefault = "template-default"
+	parameterSourceSavedDefaults   = "saved-defaults"
+	parameterSourceSamconfig       = "samconfig"
+	parameterSourceFile            = "file"
+	parameterSourceFlag            = "flag"
+)
+
+// resolveParameterInputs layers samconfig parameter_overrides,
+// --parameters-file and --parameter-overrides (highest wins) and records
+// where each value came from.
+func resolveParameterInputs(
+	flags DeployCmd,
+	env string,
+	samconfigOverrides []string,
+) (map[string]string, map[string]string, error) {
+	values := map[string]string{}
+	sources := map[string]string{}
+	apply := func(layer map[string]string, source string) {
+		for key, value := range layer {
+			values[key] = value
+			sources[key] = source
+		}
+	}
+	if len(samconfigOverrides) > 0 {
+		fromSamconfig, err := parseParameterOverrides(strings.Join(samconfigOverrides, " "))
+		if err != nil {
+			return nil, nil, fmt.Errorf("samconfig parameter_overrides: %w", err)
+		}
+		apply(fromSamconfig, parameterSourceSamconfig)
+	}
+	if path := strings.TrimSpace(flags.ParametersFile); path != "" {
+		fromFile, err := loadParametersFile(path, env)
+		if err != nil {
+			return nil, nil, err
+		}
+		apply(fromFile, parameterSourceFile)
+	}
+	for _, raw := range flags.ParameterOverrides {
+		parsed, err := parseParameterOverrides(raw)
+		if err != nil {
+			return nil, nil, err
+		}
+		apply(parsed, parameterSourceFlag)
+	}
+	if len(values) == 0 {
+		return nil, nil, nil
+	}
+	return values, sources, nil
+}
+
+// parseParameterOverrides parses the SAM CLI forms "Key=Value Key2=Value2"
+// and "ParameterKey=Key,ParameterValue=Value". Values may be quoted.
+func parseParameterOverrides(raw string) (map[string]string, error) {
+	tokens, err := splitParameterTokens(raw)
+	if err != nil {
+		return nil, err
+	}
+	values := map[string]string{}
+	for _, token := range tokens {
+		key, value, ok := parseParameterToken(token)
+		if !ok {
+			return nil, fmt.Errorf("%w %q (expected Key=Value)", errInvalidParameterOverride, token)
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
+func parseParameterToken(token string) (string, string, bool) {
+	if rest, ok := strings.CutPrefix(token, "ParameterKey="); ok {
+		key, value, found := strings.Cut(rest, ",ParameterValue=")
+		if !found || strings.TrimSpace(key) == "" {
+			return "", "", false
+		}
+		return strings.TrimSpace(key), value, true
+	}
+	key, value, found := strings.Cut(token, "=")
+	if !found || strings.TrimSpace(key) == "" || strings.ContainsAny(key, " \t") {
+		return "", "", false
+	}
+	return strings.TrimSpace(key), value, true
+}
+
+// splitParameterTokens splits on whitespace outside single or double quotes
+// and strips the quotes.
+func splitParameterTokens(raw string) ([]string, error) {
+	var tokens []string
+	var current strings.Builder
+	inToken := false
+	var quote rune
+	for _, r := range raw {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+				continue
+			}
+			current.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote = r
+			inToken = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inToken {
+				tokens = append(tokens, current.String())
+				current.Reset()
+				inToken = false
+			}
+		default:
+			current.WriteRune(r)
+			inToken = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("%w %q (unterminated quote)", errInvalidParameterOverride, raw)
+	}
+	if inToken {
+		tokens = append(tokens, current.String())
+	}
+	return tokens, nil
+}
+
+// loadParametersFile reads template parameters from JSON or YAML. Accepted
+// shapes: a flat {"Key": "Value"} map, {"Parameters": ...}, a CloudFormation
+// [{"ParameterKey", "ParameterValue"}] list, or a samconfig file (TOML or
+// YAML) read with sam.LoadSamconfigDeploy.
+func loadParametersFile(path, env string) (map[string]string, error) {
+	if strings.EqualFold(filepath.Ext(path), ".toml") {
+		return loadSamconfigParameters(path, env)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read parameters file: %w", err)
+	}
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parse parameters file %s: %w", path, err)
+	}
+	if isSamconfigDocument(doc) {
+		return loadSamconfigParameters(path, env)
+	}
+	values, err := parameterValuesFromDocument(doc)
+	if err != nil {
+		return nil, fmt.Errorf("parameters file %s: %w", path, err)
+	}
+	return values, nil
+}
+
+// loadSamconfigParameters returns the parameter_overrides of the env (or
+// default) section of a samconfig file.
+func loadSamconfigParameters(path, env string) (map[string]string, error) {
+	deploy, err := sam.LoadSamconfigDeploy(path, env)
+	if err != nil {
+		return nil, err
+	}
+	values, err := parseParameterOverrides(strings.Join(deploy.ParameterOverrides, " "))
+	if err != nil {
+		return nil, fmt.Errorf("%s %s.parameter_overrides: %w", path, deploy.Env, err)
+	}
+	return values, nil
+}
+
+// isSamconfigDocument reports whether any top-level section carries
+// deploy.parameters or global.parameters, the samconfig layout.
+func isSamconfigDocument(doc any) bool {
+	for _, section := range yamlshape.AsMap(doc) {
+		for _, command := range []string{"deploy", "global"} {
+			if _, ok := yamlshape.AsMap(yamlshape.AsMap(section)[command])["parameters"]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func parameterValuesFromDocument(doc any) (map[string]string, error) {
+	if items, ok := doc.([]any); ok {
+		return parameterValuesFromList(items)
+	}
+	m := yamlshape.AsMap(doc)
+	if m == nil {
+		return nil, errors.New("expected a map or a ParameterKey/ParameterValue list")
+	}
+	if nested, ok := m["Parameters"]; ok {
+		return parameterValuesFromDocument(nested)
+	}
+	values := make(map[string]string, len(m))
+	for key, value := range m {
+		scalar, ok := parameterScalar(value)
+		if !ok {
+			return nil, fmt.Errorf("parameter %q must be a scalar value", key)
+		}
+		values[key] = scalar
+	}
+	return values, nil
+}
+
+func parameterValuesFromList(items []any) (map[string]string, error) {
+	values := make(map[string]string, len(items))
+	for _, item := range items {
+		m := yamlshape.AsMap(item)
+		key, _ := m["ParameterKey"].(string)
+		value, ok := parameterScalar(m["ParameterValue"])
+		if strings.TrimSpace(key) == "" || !ok {
+			return nil, errors.New("list entries need ParameterKey and ParameterValue")
+		}
+		values[strings.TrimSpace(key)] = value
+	}
+	return values, nil
+}
+
+func parameterScalar(value any) (string, bool) {
+	switch typed := value.(type) {
+	case nil:
+		return "", true
+	case string:
+		return typed, true
+	case bool, int, int64, uint64, float64:
+		return fmt.Sprint(typed), true
+	default:
+		return "", false
+	}
+}
+
+// templateParameterSources keeps the sources of parameters the template uses.
+func templateParameterSources(sources, prompted, params map[string]string) map[string]string {
+	selected := map[string]string{}
+	for key := range params {
+		if source, ok := sources[key]; ok {
+			selected[key] = source
+		} else if source, ok := prompted[key]; ok {
+			selected[key] = source
+		}
+	}
+	if len(selected) == 0 {
+		return nil
+	}
+	return selected
+}
+
+// unknownParameterOverrides returns override keys declared by no template.
+func unknownParameterOverrides(overrides map[string]string, templates []deployTemplateInput) []string {
+	var unknown []string
+	for key := range overrides {
+		declared := false
+		for _, tpl := range templates {
+			if _, ok := tpl.Parameters[key]; ok {
+				declared = true
+				break
+			}
+		}
+		if !declared {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
+
+// validateTemplateParameterValue enforces the CloudFormation parameter
+// constraints declared in the template.
+func validateTemplateParameterValue(name, value string, param samParameter) error {
+	if err := validateAllowedParameterValue(name, value, param.Allowed); err != nil {
+		return err
+	}
+	var problem string
+	switch {
+	case strings.EqualFold(strings.TrimSpace(param.Type), "Number"):
+		problem = numberParameterProblem(value, param)
+	case strings.EqualFold(strings.TrimSpace(param.Type), "String"):
+		problem = stringParameterProblem(value, param)
+	}
+	if problem == "" {
+		return nil
+	}
+	if desc := strings.TrimSpace(param.ConstraintDescription); desc != "" {
+		return fmt.Errorf("parameter %q %s: %s", name, problem, desc)
+	}
+	return fmt.Errorf("parameter %q %s", name, problem)
+}
+
+func stringParameterProblem(value string, param samParameter) string {
+	length := utf8.RuneCountInString(value)
+	if param.MinLength != nil && length < *param.MinLength {
+		return fmt.Sprintf("must be at least %d characters", *param.MinLength)
+	}
+	if param.MaxLength != nil && length > *param.MaxLength {
+		return fmt.Sprintf("must be at most %d characters", *param.MaxLength)
+	}
+	if param.Pattern != "" {
+		// CloudFormation patterns must match the whole value.
+		re, err := regexp.Compile("^(?:" + param.Pattern + ")$")
+		if err != nil {
+			return fmt.Sprintf("has an invalid AllowedPattern %q: %v", param.Pattern, err)
+		}
+		if !re.MatchString(value) {
+			return fmt.Sprintf("value %q does not match AllowedPattern %s", value, param.Pattern)
+		}
+	}
+	return ""
+}
+
+func numberParameterProblem(value string, param samParameter) string {
+	number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || math.IsNaN(number) {
+		return fmt.Sprintf("value %q is not a number", value)
+	}
+	if param.MinValue != nil && number < *param.MinValue {
+		return fmt.Sprintf("must be at least %s", strconv.FormatFloat(*param.MinValue, 'f', -1, 64))
+	}
+	if param.MaxValue != nil && number > *param.MaxValue {
+		return fmt.Sprintf("must be at most %s", strconv.FormatFloat(*param.MaxValue, 'f', -1, 64))
+	}
+	return ""
+}
+
+func extractSAMInt(raw any) *int {
+	number := extractSAMNumber(raw)
+	if number == nil {
+		return nil
+	}
+	value := int(*number)
+	return &value
+}
+
+func extractSAMNumber(raw any) *float64 {
+	var number float64
+	switch typed := raw.(type) {
+	case int:
+		number = float64(typed)
+	case int64:
+		number = float64(typed)
+	case uint64:
+		number = float64(typed)
+	case float64:
+		number = typed
+	case string:
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(typed), 64)
+		if err != nil {
+			return nil
+		}
+		number = parsed
+	default:
+		return nil
+	}
+	return &number
+}
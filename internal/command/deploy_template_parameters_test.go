@@ -0,0 +1,162 @@
+// Where: cli/internal/command/deploy_template_parameters_test.go
+// What: Tests for --parameter-overrides, --parameters-file and constraints.
+// Why: Non-interactive runs must resolve parameters and fail before building.
+package command
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseParameterOverridesFormats(t *testing.T) {
+	got, err := parseParameterOverrides(`Stage=prod Greeting="hello world" ParameterKey=Size,ParameterValue=a,b`)
+	if err != nil {
+		t.Fatalf("parse overrides: %v", err)
+	}
+	want := map[string]string{"Stage": "prod", "Greeting": "hello world", "Size": "a,b"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected overrides: %#v", got)
+	}
+	for _, bad := range []string{"Stage", "=prod", `Stage="prod`} {
+		if _, err := parseParameterOverrides(bad); err == nil {
+			t.Fatalf("expected error for %q", bad)
+		}
+	}
+}
+
+func TestLoadParametersFileShapes(t *testing.T) {
+	dir := t.TempDir()
+	cases := map[string]string{
+		"flat.json":     `{"Stage": "prod", "Size": 3}`,
+		"nested.yaml":   "Parameters:\n  Stage: prod\n  Size: 3\n",
+		"cfn.json":      `[{"ParameterKey": "Stage", "ParameterValue": "prod"}, {"ParameterKey": "Size", "ParameterValue": "3"}]`,
+		"samconfig.yml": "version: 0.1\ndefault:\n  deploy:\n    parameters:\n      parameter_overrides: Stage=dev\nprod:\n  deploy:\n    parameters:\n      parameter_overrides:\n        - Stage=prod\n        - Size=3\n",
+	}
+	want := map[string]string{"Stage": "prod", "Size": "3"}
+	for name, content := range cases {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+		got, err := loadParametersFile(path, "prod")
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Fatalf("%s: unexpected parameters %#v", name, got)
+		}
+	}
+
+	got, err := loadParametersFile(filepath.Join(dir, "samconfig.yml"), "staging")
+	if err != nil || got["Stage"] != "dev" {
+		t.Fatalf("expected default samconfig section, got %#v err=%v", got, err)
+	}
+	toml := filepath.Join(dir, "samconfig.toml")
+	content := "version = 0.1\n[prod.deploy.parameters]\nparameter_overrides = \"Stage=prod Size=3\"\n"
+	if err := os.WriteFile(toml, []byte(content), 0o600); err != nil {
+		t.Fatalf("write toml: %v", err)
+	}
+	if got, err := loadParametersFile(toml, "prod"); err != nil || !reflect.DeepEqual(got, want) {
+		t.Fatalf("expected samconfig.toml parameters, got %#v err=%v", got, err)
+	}
+	bad := filepath.Join(dir, "bad.yaml")
+	if err := os.WriteFile(bad, []byte("Stage:\n  nested: true\n"), 0o600); err != nil {
+		t.Fatalf("write bad: %v", err)
+	}
+	if _, err := loadParametersFile(bad, "prod"); err == nil || !strings.Contains(err.Error(), `parameter "Stage" must be a scalar value`) {
+		t.Fatalf("expected scalar error, got %v", err)
+	}
+}
+
+func TestResolveParameterInputsLayersSources(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "params.dev.json")
+	if err := os.WriteFile(path, []byte(`{"Stage": "dev", "Size": "1"}`), 0o600); err != nil {
+		t.Fatalf("write params: %v", err)
+	}
+	values, sources, err := resolveParameterInputs(DeployCmd{
+		ParametersFile:     path,
+		ParameterOverrides: []string{"Stage=prod"},
+	}, "dev", []string{"Size=0 Region=ap-northeast-1"})
+	if err != nil {
+		t.Fatalf("resolve parameters: %v", err)
+	}
+	if !reflect.DeepEqual(values, map[string]string{"Stage": "prod", "Size": "1", "Region": "ap-northeast-1"}) {
+		t.Fatalf("unexpected parameters: %#v", values)
+	}
+	wantSources := map[string]string{"Stage": "flag", "Size": "file", "Region": "samconfig"}
+	if !reflect.DeepEqual(sources, wantSources) {
+		t.Fatalf("unexpected sources: %#v", sources)
+	}
+}
+
+func TestValidateTemplateParameterValueConstraints(t *testing.T) {
+	minLen, maxLen := 2, 5
+	minValue, maxValue := 1.0, 10.0
+	str := samParameter{Type: "String", Pattern: "[a-z]+", MinLength: &minLen, MaxLength: &maxLen}
+	num := samParameter{Type: "Number", MinValue: &minValue, MaxValue: &maxValue, ConstraintDescription: "between 1 and 10"}
+	cases := []struct {
+		param samParameter
+		value string
+		want  string
+	}{
+		{str, "abc", ""},
+		{str, "a", "must be at least 2 characters"},
+		{str, "abcdef", "must be at most 5 characters"},
+		{str, "ab1", `value "ab1" does not match AllowedPattern [a-z]+`},
+		{num, "5", ""},
+		{num, "x", `value "x" is not a number: between 1 and 10`},
+		{num, "11", "must be at most 10: between 1 and 10"},
+	}
+	for _, tc := range cases {
+		err := validateTemplateParameterValue("P", tc.value, tc.param)
+		if tc.want == "" {
+			if err != nil {
+				t.Fatalf("%q: unexpected error %v", tc.value, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tc.want) {
+			t.Fatalf("%q: expected %q, got %v", tc.value, tc.want, err)
+		}
+	}
+}
+
+func TestPromptTemplateParametersUsesOverridesWithoutPrompting(t *testing.T) {
+	templatePath := writePromptTemplateFile(t, `
+Parameters:
+  Stage:
+    Type: String
+    AllowedPattern: "[a-z]+"
+  Size:
+    Type: Number
+    Default: 1
+`)
+	prompter := &templateParamPrompter{inputs: []string{"2"}}
+	got, _, err := promptTemplateParameters(templatePath, true, prompter, nil, parameterSourceSavedDefaults, map[string]string{"Stage": "prod"}, &bytes.Buffer{})
+	if err != nil {
+		t.Fatalf("prompt template parameters: %v", err)
+	}
+	if got["Stage"] != "prod" || got["Size"] != "2" || len(prompter.titles) != 1 {
+		t.Fatalf("unexpected values %#v (prompts %v)", got, prompter.titles)
+	}
+
+	_, _, err = promptTemplateParameters(templatePath, true, prompter, nil, parameterSourceSavedDefaults, map[string]string{"Stage": "Prod1"}, &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "does not match AllowedPattern") {
+		t.Fatalf("expected invalid override to fail without prompting, got %v", err)
+	}
+}
+
+func TestUnknownParameterOverrides(t *testing.T) {
+	templates := []deployTemplateInput{
+		{Parameters: map[string]string{"Stage": "prod"}},
+		{Parameters: map[string]string{"Size": "1"}},
+	}
+	got := unknownParameterOverrides(map[string]string{"Stage": "x", "Size": "2", "Typo": "y"}, templates)
+	if !reflect.DeepEqual(got, []string{"Typo"}) {
+		t.Fatalf("unexpected unknown parameters: %v", got)
+	}
+}
@@ -20,21 +20,23 @@ func promptTemplateParameters(
 	isTTY bool,
 	prompter interaction.Prompter,
 	previous map[string]string,
+	previousSource string,
+	overrides map[string]string,
 	errOut io.Writer,
-) (map[string]string, error) {
+) (map[string]string, map[string]string, error) {
 	content, err := os.ReadFile(templatePath)
 	if err != nil {
-		return map[string]string{}, fmt.Errorf("read template: %w", err)
+		return map[string]string{}, nil, fmt.Errorf("read template: %w", err)
 	}
 
 	data, err := sam.DecodeYAML(string(content))
 	if err != nil {
-		return map[string]string{}, fmt.Errorf("decode template: %w", err)
+		return map[string]string{}, nil, fmt.Errorf("decode template: %w", err)
 	}
 
 	params := extractSAMParameters(data)
 	if len(params) == 0 {
-		return map[string]string{}, nil
+		return map[string]string{}, nil, nil
 	}
 
 	names := make([]string, 0, len(params))
@@ -44,6 +46,8 @@ func promptTemplateParameters(
 	sort.Strings(names)
 
 	values := make(map[string]string, len(params))
+	// sources covers prompted names only; override sources are the caller's.
+	sources := make(map[string]string, len(params))
 	for _, name := range names {
 		param := params[name]
 		hasDefault := param.Default != nil
@@ -52,6 +56,13 @@ func promptTemplateParameters(
 			defaultStr = fmt.Sprint(param.Default)
 		}
 		allowsEmpty := parameterAllowsEmpty(param)
+		if override, ok := overrides[name]; ok {
+			if err := validateTemplateParameterValue(name, override, param); err != nil {
+				return nil, nil, err
+			}
+			values[name] = override
+			continue
+		}
 		prevValue := ""
 		if previous != nil {
 			prevValue = strings.TrimSpace(previous[name])
@@ -59,17 +70,19 @@ func promptTemplateParameters(
 
 		if !isTTY || prompter == nil {
 			value := ""
+			source := parameterSourcePrompt
 			if hasDefault {
-				value = defaultStr
+				value, source = defaultStr, parameterSourceTemplateDefault
 			} else if prevValue != "" {
-				value = prevValue
+				value, source = prevValue, previousSource
 			} else if !allowsEmpty {
-				return nil, fmt.Errorf("%w: %s", errParameterRequiresValue, name)
+				return nil, nil, fmt.Errorf("%w: %s", errParameterRequiresValue, name)
 			}
-			if err := validateAllowedParameterValue(name, value, param.Allowed); err != nil {
-				return nil, err
+			if err := validateTemplateParameterValue(name, value, param); err != nil {
+				return nil, nil, err
 			}
 			values[name] = value
+			sources[name] = source
 			continue
 		}
 
@@ -107,33 +120,36 @@ func promptTemplateParameters(
 		for {
 			input, err := prompter.Input(title, suggestions)
 			if err != nil {
-				return nil, fmt.Errorf("prompt parameter %s: %w", name, err)
+				return nil, nil, fmt.Errorf("prompt parameter %s: %w", name, err)
 			}
 			input = strings.TrimSpace(input)
+			source := parameterSourcePrompt
 			if input == "" && hasDefault {
-				input = defaultStr
+				input, source = defaultStr, parameterSourceTemplateDefault
 			}
 			if input == "" && prevValue != "" {
-				input = prevValue
+				input, source = prevValue, previousSource
 			}
 			if input == "" && allowsEmpty {
 				values[name] = ""
+				sources[name] = parameterSourcePrompt
 				break
 			}
 			if input == "" && !hasDefault {
 				writeWarningf(errOut, "Parameter %q is required.\n", name)
 				continue
 			}
-			if err := validateAllowedParameterValue(name, input, param.Allowed); err != nil {
+			if err := validateTemplateParameterValue(name, input, param); err != nil {
 				writeWarningf(errOut, "%v\n", err)
 				continue
 			}
 			values[name] = input
+			sources[name] = source
 			break
 		}
 	}
 
-	return values, nil
+	return values, sources, nil
 }
 
 // extractSAMParameters extracts parameter definitions from SAM template data.
@@ -159,12 +175,62 @@ func extractSAMParameters(data map[string]any) map[string]samParameter {
 		}
 		param.Default = m["Default"]
 		param.Allowed = extractSAMAllowedValues(m["AllowedValues"])
+		if pattern, ok := m["AllowedPattern"].(string); ok {
+			param.Pattern = pattern
+		}
+		if desc, ok := m["ConstraintDescription"].(string); ok {
+			param.ConstraintDescription = desc
+		}
+		param.MinLength = extractSAMInt(m["MinLength"])
+		param.MaxLength = extractSAMInt(m["MaxLength"])
+		param.MinValue = extractSAMNumber(m["MinValue"])
+		param.MaxValue = extractSAMNumber(m["MaxValue"])
+		param.NoEcho = strings.EqualFold(strings.TrimSpace(fmt.Sprint(m["NoEcho"])), "true")
 		result[name] = param
 	}
 
 	return result
 }
 
+// templateNoEchoParameters returns the sorted names of parameters declared
+// NoEcho: true in the template.
+func templateNoEchoParameters(templatePath string) ([]string, error) {
+	content, err := os.ReadFile(templatePath)
+	if err != nil {
+		return nil, fmt.Errorf("read template: %w", err)
+	}
+	data, err := sam.DecodeYAML(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("decode template: %w", err)
+	}
+	var names []string
+	for name, param := range extractSAMParameters(data) {
+		if param.NoEcho {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// maskNoEchoParameters returns params with NoEcho values replaced by
+// maskedParameterValue. params is returned as-is when nothing is masked.
+func maskNoEchoParameters(params map[string]string, noEcho []string) map[string]string {
+	if len(noEcho) == 0 || len(params) == 0 {
+		return params
+	}
+	masked := make(map[string]string, len(params))
+	for key, value := range params {
+		masked[key] = value
+	}
+	for _, key := range noEcho {
+		if _, ok := masked[key]; ok {
+			masked[key] = maskedParameterValue
+		}
+	}
+	return masked
+}
+
 func extractSAMAllowedValues(raw any) []string {
 	items, ok := raw.([]any)
 	if !ok {
@@ -46,7 +46,7 @@ func (p *templateParamPrompter) SelectValue(_ string, _ []interaction.SelectOpti
 func TestPromptTemplateParametersNoParameters(t *testing.T) {
 	templatePath := writePromptTemplateFile(t, "Resources: {}\n")
 
-	got, err := promptTemplateParameters(templatePath, false, nil, nil, &bytes.Buffer{})
+	got, _, err := promptTemplateParameters(templatePath, false, nil, nil, parameterSourceSavedDefaults, nil, &bytes.Buffer{})
 	if err != nil {
 		t.Fatalf("prompt template parameters: %v", err)
 	}
@@ -66,7 +66,7 @@ Parameters:
 `)
 	previous := map[string]string{"Beta": "beta-prev"}
 
-	got, err := promptTemplateParameters(templatePath, false, nil, previous, &bytes.Buffer{})
+	got, sources, err := promptTemplateParameters(templatePath, false, nil, previous, parameterSourceSavedDefaults, nil, &bytes.Buffer{})
 	if err != nil {
 		t.Fatalf("prompt template parameters: %v", err)
 	}
@@ -76,6 +76,9 @@ Parameters:
 	if got["Beta"] != "beta-prev" {
 		t.Fatalf("expected previous value for Beta, got %q", got["Beta"])
 	}
+	if sources["Alpha"] != parameterSourceTemplateDefault || sources["Beta"] != parameterSourceSavedDefaults {
+		t.Fatalf("unexpected parameter sources: %#v", sources)
+	}
 }
 
 func TestPromptTemplateParametersNonTTYRequiresValue(t *testing.T) {
@@ -85,7 +88,7 @@ Parameters:
     Type: Number
 `)
 
-	_, err := promptTemplateParameters(templatePath, false, nil, nil, &bytes.Buffer{})
+	_, _, err := promptTemplateParameters(templatePath, false, nil, nil, parameterSourceSavedDefaults, nil, &bytes.Buffer{})
 	if !errors.Is(err, errParameterRequiresValue) {
 		t.Fatalf("expected errParameterRequiresValue, got %v", err)
 	}
@@ -101,7 +104,7 @@ Parameters:
     Type: String
 `)
 
-	got, err := promptTemplateParameters(templatePath, false, nil, nil, &bytes.Buffer{})
+	got, _, err := promptTemplateParameters(templatePath, false, nil, nil, parameterSourceSavedDefaults, nil, &bytes.Buffer{})
 	if err != nil {
 		t.Fatalf("prompt template parameters: %v", err)
 	}
@@ -121,7 +124,7 @@ Parameters:
 `)
 	previous := map[string]string{"DeployTier": "staging"}
 
-	_, err := promptTemplateParameters(templatePath, false, nil, previous, &bytes.Buffer{})
+	_, _, err := promptTemplateParameters(templatePath, false, nil, previous, parameterSourceSavedDefaults, nil, &bytes.Buffer{})
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -143,7 +146,7 @@ Parameters:
 	}
 	var errOut bytes.Buffer
 
-	got, err := promptTemplateParameters(templatePath, true, prompter, nil, &errOut)
+	got, _, err := promptTemplateParameters(templatePath, true, prompter, nil, parameterSourceSavedDefaults, nil, &errOut)
 	if err != nil {
 		t.Fatalf("prompt template parameters: %v", err)
 	}
@@ -175,7 +178,7 @@ Parameters:
 `)
 	prompter := &templateParamPrompter{inputs: []string{""}}
 
-	got, err := promptTemplateParameters(templatePath, true, prompter, nil, &bytes.Buffer{})
+	got, _, err := promptTemplateParameters(templatePath, true, prompter, nil, parameterSourceSavedDefaults, nil, &bytes.Buffer{})
 	if err != nil {
 		t.Fatalf("prompt template parameters: %v", err)
 	}
@@ -204,7 +207,7 @@ Parameters:
 	}
 	var errOut bytes.Buffer
 
-	got, err := promptTemplateParameters(templatePath, true, prompter, nil, &errOut)
+	got, _, err := promptTemplateParameters(templatePath, true, prompter, nil, parameterSourceSavedDefaults, nil, &errOut)
 	if err != nil {
 		t.Fatalf("prompt template parameters: %v", err)
 	}
@@ -230,7 +233,7 @@ Parameters:
 `)
 	prompter := &templateParamPrompter{err: errors.New("boom")}
 
-	_, err := promptTemplateParameters(templatePath, true, prompter, nil, &bytes.Buffer{})
+	_, _, err := promptTemplateParameters(templatePath, true, prompter, nil, parameterSourceSavedDefaults, nil, &bytes.Buffer{})
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
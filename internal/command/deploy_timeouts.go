@@ -0,0 +1,81 @@
+// Where: cli/internal/command/deploy_timeouts.go
+// What: Deploy timeout budget resolution from flags, env and project config.
+// Why: Stalled builds and provisioners must fail instead of hanging forever.
+package command
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/poruru-code/esb-cli/internal/constants"
+	"github.com/poruru-code/esb-cli/internal/domain/timeout"
+	"github.com/poruru-code/esb-cli/internal/infra/config"
+	"github.com/poruru-code/esb-cli/internal/infra/envutil"
+)
+
+// resolveDeployTimeouts layers the timeout budget: <repo>/.<brand>/config.yaml
+// timeouts, then <PREFIX>_DEPLOY_TIMEOUT / <PREFIX>_PHASE_TIMEOUTS, then
+// --timeout / --phase-timeout.
+func resolveDeployTimeouts(projectRoot string, flags DeployCmd) (timeout.Budget, error) {
+	budget := timeout.Budget{}
+	stored := loadTimeoutDefaults(projectRoot)
+	if err := applyTimeoutLayer(&budget, stored.Deploy, "config timeouts.deploy"); err != nil {
+		return timeout.Budget{}, err
+	}
+	phases, err := timeout.ParsePhaseMap(stored.Phases)
+	if err != nil {
+		return timeout.Budget{}, fmt.Errorf("config timeouts.phases: %w", err)
+	}
+	budget = budget.With(phases)
+
+	envTotal, _ := envutil.GetHostEnv(constants.HostSuffixDeployTimeout)
+	envTotalKey, _ := envutil.HostEnvKey(constants.HostSuffixDeployTimeout)
+	if err := applyTimeoutLayer(&budget, envTotal, envTotalKey); err != nil {
+		return timeout.Budget{}, err
+	}
+	if envPhases, _ := envutil.GetHostEnv(constants.HostSuffixPhaseTimeouts); strings.TrimSpace(envPhases) != "" {
+		phases, err := timeout.ParsePhases(strings.Split(envPhases, ","))
+		if err != nil {
+			envPhasesKey, _ := envutil.HostEnvKey(constants.HostSuffixPhaseTimeouts)
+			return timeout.Budget{}, fmt.Errorf("%s: %w", envPhasesKey, err)
+		}
+		budget = budget.With(phases)
+	}
+
+	if err := applyTimeoutLayer(&budget, flags.Timeout, "--timeout"); err != nil {
+		return timeout.Budget{}, err
+	}
+	phases, err = timeout.ParsePhases(flags.PhaseTimeouts)
+	if err != nil {
+		return timeout.Budget{}, fmt.Errorf("--phase-timeout: %w", err)
+	}
+	return budget.With(phases), nil
+}
+
+// applyTimeoutLayer overrides the total budget when value is set.
+func applyTimeoutLayer(budget *timeout.Budget, value, source string) error {
+	if strings.TrimSpace(value) == "" {
+		return nil
+	}
+	total, err := timeout.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("%s: %w", source, err)
+	}
+	budget.Total = total
+	return nil
+}
+
+func loadTimeoutDefaults(projectRoot string) config.TimeoutDefaults {
+	if strings.TrimSpace(projectRoot) == "" {
+		return config.TimeoutDefaults{}
+	}
+	cfgPath, err := config.ProjectConfigPath(projectRoot)
+	if err != nil {
+		return config.TimeoutDefaults{}
+	}
+	cfg, err := config.LoadGlobalConfig(cfgPath)
+	if err != nil {
+		return config.TimeoutDefaults{}
+	}
+	return cfg.Timeouts
+}
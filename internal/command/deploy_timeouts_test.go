@@ -0,0 +1,100 @@
+// Where: cli/internal/command/deploy_timeouts_test.go
+// What: Unit tests for deploy timeout budget resolution.
+// Why: Keep config < env < flag precedence and the timeout exit code stable.
+package command
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/poruru-code/esb-cli/internal/domain/timeout"
+	"github.com/poruru-code/esb-cli/internal/infra/config"
+)
+
+func writeTimeoutConfig(t *testing.T, projectRoot string, defaults config.TimeoutDefaults) {
+	t.Helper()
+	cfgPath, err := config.ProjectConfigPath(projectRoot)
+	if err != nil {
+		t.Fatalf("project config path: %v", err)
+	}
+	cfg := config.DefaultGlobalConfig()
+	cfg.Timeouts = defaults
+	if err := config.SaveGlobalConfig(cfgPath, cfg); err != nil {
+		t.Fatalf("save config: %v", err)
+	}
+}
+
+func TestResolveDeployTimeoutsLayersConfigEnvAndFlags(t *testing.T) {
+	t.Setenv("ENV_PREFIX", "ESB")
+	t.Setenv("ESB_DEPLOY_TIMEOUT", "")
+	t.Setenv("ESB_PHASE_TIMEOUTS", "provision=5m,sync=30s")
+	projectRoot := t.TempDir()
+	writeTimeoutConfig(t, projectRoot, config.TimeoutDefaults{
+		Deploy: "1h",
+		Phases: map[string]string{"provision": "20m", "base_build": "15m"},
+	})
+
+	budget, err := resolveDeployTimeouts(projectRoot, DeployCmd{
+		Timeout:       "45m",
+		PhaseTimeouts: []string{"sync=1m", "function-build=10m"},
+	})
+	if err != nil {
+		t.Fatalf("resolve timeouts: %v", err)
+	}
+	want := map[timeout.Phase]time.Duration{
+		timeout.PhaseTotal:         45 * time.Minute,
+		timeout.PhaseBaseBuild:     15 * time.Minute,
+		timeout.PhaseProvision:     5 * time.Minute,
+		timeout.PhaseSync:          time.Minute,
+		timeout.PhaseFunctionBuild: 10 * time.Minute,
+		timeout.PhaseGenerate:      0,
+	}
+	for phase, limit := range want {
+		if got := budget.For(phase); got != limit {
+			t.Fatalf("%s budget = %v, want %v", phase, got, limit)
+		}
+	}
+}
+
+func TestResolveDeployTimeoutsUsesEnvTotal(t *testing.T) {
+	t.Setenv("ENV_PREFIX", "ESB")
+	t.Setenv("ESB_DEPLOY_TIMEOUT", "20m")
+	t.Setenv("ESB_PHASE_TIMEOUTS", "")
+
+	budget, err := resolveDeployTimeouts(t.TempDir(), DeployCmd{})
+	if err != nil {
+		t.Fatalf("resolve timeouts: %v", err)
+	}
+	if budget.Total != 20*time.Minute {
+		t.Fatalf("total = %v, want 20m", budget.Total)
+	}
+}
+
+func TestResolveDeployTimeoutsRejectsInvalidValues(t *testing.T) {
+	t.Setenv("ENV_PREFIX", "ESB")
+	t.Setenv("ESB_DEPLOY_TIMEOUT", "")
+	t.Setenv("ESB_PHASE_TIMEOUTS", "")
+	cases := []DeployCmd{
+		{Timeout: "soon"},
+		{PhaseTimeouts: []string{"deploy=1m"}},
+		{PhaseTimeouts: []string{"generate"}},
+	}
+	for _, flags := range cases {
+		if _, err := resolveDeployTimeouts(t.TempDir(), flags); err == nil {
+			t.Fatalf("expected error for %#v", flags)
+		}
+	}
+}
+
+func TestExitWithErrorReturnsTimeoutExitCode(t *testing.T) {
+	var buf bytes.Buffer
+	err := fmt.Errorf("deploy apply: %w", timeout.Exceeded(timeout.PhaseProvision, time.Minute, nil))
+	if code := exitWithError(&buf, err); code != timeoutExitCode {
+		t.Fatalf("exit code = %d, want %d", code, timeoutExitCode)
+	}
+	if got := buf.String(); got != "✗ deploy apply: provision phase exceeded its 1m0s budget\n" {
+		t.Fatalf("output = %q", got)
+	}
+}
@@ -0,0 +1,199 @@
+// Where: cli/internal/command/deploy_watch.go
+// What: Watch loop for deploy --watch.
+// Why: Rebuild only the functions touched by an edit and hot-sync runtime config.
+package command
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/poruru-code/esb-cli/internal/domain/template"
+	"github.com/poruru-code/esb-cli/internal/infra/ui"
+	"github.com/poruru-code/esb-cli/internal/infra/watch"
+	"github.com/poruru-code/esb-cli/internal/usecase/deploy"
+)
+
+// runWatch polls template, CodeUri and layer paths until interrupted and
+// redeploys on every settled change. Cycle failures are reported and the
+// loop keeps watching so the next edit can fix them.
+func (c *deployCommand) runWatch(
+	workflow deploy.Workflow,
+	inputs deployInputs,
+	flags DeployCmd,
+	runConfig deployRunConfig,
+) error {
+	ctx := c.runContext()
+
+	targets, err := resolveWatchTargets(inputs)
+	if err != nil {
+		return err
+	}
+	poller := &watch.Poller{Paths: watchPaths(targets), Exclude: watchExcludes(inputs)}
+	if err := poller.Reset(); err != nil {
+		return fmt.Errorf("watch: %w", err)
+	}
+	c.renderWatchBlock(len(poller.Paths))
+
+	for {
+		changed, err := poller.Wait(ctx)
+		if errors.Is(err, context.Canceled) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("watch: %w", err)
+		}
+		templateChanged, err := c.runWatchCycle(workflow, inputs, flags, runConfig, targets, changed)
+		if err != nil {
+			c.warn(fmt.Sprintf("Warning: watch rebuild failed: %v", err))
+		}
+		if templateChanged {
+			refreshed, refreshErr := resolveWatchTargets(inputs)
+			if refreshErr != nil {
+				c.warn(fmt.Sprintf("Warning: keep previous watch paths: %v", refreshErr))
+				continue
+			}
+			targets = refreshed
+			poller.Paths = watchPaths(targets)
+		}
+		if err := poller.Reset(); err != nil {
+			return fmt.Errorf("watch: %w", err)
+		}
+	}
+}
+
+// runWatchCycle rebuilds the functions owning changed paths. A template edit
+// rebuilds that whole template and runs the full apply phase; otherwise only
+// the runtime config is resynced.
+func (c *deployCommand) runWatchCycle(
+	workflow deploy.Workflow,
+	inputs deployInputs,
+	flags DeployCmd,
+	runConfig deployRunConfig,
+	targets [][]deploy.WatchTarget,
+	changed []string,
+) (bool, error) {
+	rebuilt := false
+	templateChanged := false
+	for idx, tpl := range inputs.Templates {
+		functions, fullRebuild := deploy.AffectedFunctions(targets[idx], changed)
+		functions = filterSelectedFunctions(functions, runConfig.functions)
+		if !fullRebuild && len(functions) == 0 {
+			continue
+		}
+		request := c.newGenerateRequest(inputs, tpl, flags, runConfig)
+		if fullRebuild {
+			templateChanged = true
+			c.info(fmt.Sprintf("Template changed, rebuilding %s", tpl.TemplatePath))
+		} else {
+			request.Functions = functions
+			c.info(fmt.Sprintf("Rebuilding %s", strings.Join(functions, ", ")))
+		}
+		if err := workflow.Run(request); err != nil {
+			return templateChanged, fmt.Errorf("deploy workflow (%s): %w", tpl.TemplatePath, err)
+		}
+		rebuilt = true
+	}
+	if !rebuilt {
+		return false, nil
+	}
+
+	manifestPath, err := c.writeArtifactManifest(inputs, flags)
+	if err != nil {
+		return templateChanged, err
+	}
+	if templateChanged {
+		return true, c.runApplyPhase(workflow, inputs, flags, runConfig, manifestPath)
+	}
+	applyTemplate := inputs.Templates[0]
+	applyReq := c.newApplyRequest(inputs, applyTemplate, flags, runConfig, manifestPath)
+	if err := workflow.Resync(applyReq); err != nil {
+		return false, fmt.Errorf("deploy resync (%s): %w", applyTemplate.TemplatePath, err)
+	}
+	return false, nil
+}
+
+// filterSelectedFunctions drops functions outside a --function selection.
+func filterSelectedFunctions(functions []string, patterns []string) []string {
+	if len(patterns) == 0 {
+		return functions
+	}
+	selector := template.FunctionSelector(patterns)
+	filtered := make([]string, 0, len(functions))
+	for _, name := range functions {
+		if selector.Match(name) {
+			filtered = append(filtered, name)
+		}
+	}
+	return filtered
+}
+
+func resolveWatchTargets(inputs deployInputs) ([][]deploy.WatchTarget, error) {
+	targets := make([][]deploy.WatchTarget, 0, len(inputs.Templates))
+	for _, tpl := range inputs.Templates {
+		resolved, err := deploy.WatchTargets(tpl.TemplatePath, tpl.Parameters)
+		if err != nil {
+			return nil, fmt.Errorf("watch (%s): %w", tpl.TemplatePath, err)
+		}
+		targets = append(targets, resolved)
+	}
+	return targets, nil
+}
+
+func watchPaths(targets [][]deploy.WatchTarget) []string {
+	seen := map[string]struct{}{}
+	paths := []string{}
+	for _, group := range targets {
+		for _, target := range group {
+			if _, ok := seen[target.Path]; ok {
+				continue
+			}
+			seen[target.Path] = struct{}{}
+			paths = append(paths, target.Path)
+		}
+	}
+	return paths
+}
+
+// watchExcludes keeps generated artifacts out of the watch set so a CodeUri
+// of "." does not retrigger on its own output.
+func watchExcludes(inputs deployInputs) []string {
+	candidates := []string{inputs.ArtifactRoot}
+	for _, tpl := range inputs.Templates {
+		candidates = append(candidates, tpl.OutputDir)
+	}
+	excludes := []string{}
+	for _, candidate := range candidates {
+		if strings.TrimSpace(candidate) == "" {
+			continue
+		}
+		if abs, err := filepath.Abs(candidate); err == nil {
+			excludes = append(excludes, abs)
+		}
+	}
+	return excludes
+}
+
+func (c *deployCommand) renderWatchBlock(pathCount int) {
+	if c.ui == nil {
+		return
+	}
+	c.ui.Block("👀", "Watch", []ui.KeyValue{
+		{Key: "Paths", Value: pathCount},
+		{Key: "Stop", Value: "Ctrl-C"},
+	})
+}
+
+func (c *deployCommand) info(message string) {
+	if c.ui != nil {
+		c.ui.Info(message)
+	}
+}
+
+func (c *deployCommand) warn(message string) {
+	if c.ui != nil {
+		c.ui.Warn(message)
+	}
+}
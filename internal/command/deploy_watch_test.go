@@ -0,0 +1,123 @@
+// Where: cli/internal/command/deploy_watch_test.go
+// What: Tests for deploy --watch rebuild cycles.
+// Why: Ensure edits rebuild only owning functions and template edits run a full apply.
+package command
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/poruru-code/esb-cli/internal/domain/state"
+)
+
+func TestDeployWatchCycleRebuildsOnlyChangedFunctions(t *testing.T) {
+	tmp := t.TempDir()
+	setWorkingDir(t, tmp)
+	if err := os.WriteFile(filepath.Join(tmp, "docker-compose.docker.yml"), []byte("services: {}\n"), 0o600); err != nil {
+		t.Fatalf("write compose marker: %v", err)
+	}
+	templatePath := filepath.Join(tmp, "template.yaml")
+	template := `
+Resources:
+  HelloFunction:
+    Type: AWS::Serverless::Function
+    Properties:
+      FunctionName: lambda-hello
+      CodeUri: functions/hello/
+      Handler: app.handler
+      Runtime: python3.12
+  WorldFunction:
+    Type: AWS::Serverless::Function
+    Properties:
+      FunctionName: lambda-world
+      CodeUri: functions/world/
+      Handler: app.handler
+      Runtime: python3.12
+`
+	if err := os.WriteFile(templatePath, []byte(template), 0o600); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+	writeTestRuntimeAssets(t, tmp)
+
+	builder := &deployEntryBuilder{}
+	provisioner := &deployEntryProvisioner{}
+	cmd := &deployCommand{
+		build:         builder.Build,
+		applyRuntime:  func(state.Context) error { return nil },
+		ui:            deployEntryUI{},
+		composeRunner: deployEntryRunner{},
+		workflow: deployWorkflowDeps{
+			composeProvisioner: provisioner,
+			registryWaiter:     func(context.Context, string, time.Duration) error { return nil },
+		},
+	}
+	inputs := deployInputs{
+		ProjectDir:   tmp,
+		ArtifactRoot: filepath.Join(tmp, "artifact-root"),
+		Env:          "dev",
+		Mode:         "docker",
+		Project:      "esb-dev",
+		Templates:    []deployTemplateInput{{TemplatePath: templatePath, OutputDir: ".out/a"}},
+	}
+	flags := DeployCmd{Watch: true}
+	runConfig, err := resolveDeployRunConfig(flags, deployRunOverrides{})
+	if err != nil {
+		t.Fatalf("resolve run config: %v", err)
+	}
+	targets, err := resolveWatchTargets(inputs)
+	if err != nil {
+		t.Fatalf("resolve watch targets: %v", err)
+	}
+	workflow := cmd.newWorkflow()
+
+	templateChanged, err := cmd.runWatchCycle(workflow, inputs, flags, runConfig, targets, []string{
+		filepath.Join(tmp, "functions", "world", "app.py"),
+	})
+	if err != nil {
+		t.Fatalf("code change cycle: %v", err)
+	}
+	if templateChanged {
+		t.Fatalf("code change must not be reported as template change")
+	}
+	if len(builder.requests) != 1 || !reflect.DeepEqual(builder.requests[0].Functions, []string{"lambda-world"}) {
+		t.Fatalf("expected a single lambda-world rebuild, got %#v", builder.requests)
+	}
+	if provisioner.runCalls != 0 {
+		t.Fatalf("code change must only resync config, provisioner ran %d times", provisioner.runCalls)
+	}
+
+	templateChanged, err = cmd.runWatchCycle(workflow, inputs, flags, runConfig, targets, []string{templatePath})
+	if err != nil {
+		t.Fatalf("template change cycle: %v", err)
+	}
+	if !templateChanged {
+		t.Fatalf("expected template change")
+	}
+	if len(builder.requests) != 2 || builder.requests[1].Functions != nil {
+		t.Fatalf("template change must rebuild every function, got %#v", builder.requests)
+	}
+	if provisioner.runCalls != 1 {
+		t.Fatalf("template change must run the full apply, provisioner ran %d times", provisioner.runCalls)
+	}
+
+	if _, err := cmd.runWatchCycle(workflow, inputs, flags, runConfig, targets, []string{
+		filepath.Join(tmp, "README.md"),
+	}); err != nil {
+		t.Fatalf("unrelated change cycle: %v", err)
+	}
+	if len(builder.requests) != 2 {
+		t.Fatalf("unrelated change must not rebuild, got %d requests", len(builder.requests))
+	}
+}
+
+func TestResolveDeployRunConfigRejectsWatchWithBuildOnly(t *testing.T) {
+	_, err := resolveDeployRunConfig(DeployCmd{Watch: true, BuildOnly: true}, deployRunOverrides{})
+	if err == nil || !strings.Contains(err.Error(), "--watch cannot be used") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -4,13 +4,21 @@
 package command
 
 import (
+	"errors"
 	"fmt"
 	"io"
+
+	"github.com/poruru-code/esb-cli/internal/domain/timeout"
 )
 
 // exitWithError prints an error message to the output writer and returns
-// exit code 1 for CLI error handling.
+// exit code 1 for CLI error handling, or 124 when a timeout budget ran out.
 func exitWithError(out io.Writer, err error) int {
+	recordCommandError(out, err)
 	legacyUI(out).Warn(fmt.Sprintf("✗ %v", err))
+	var exceeded *timeout.ExceededError
+	if errors.As(err, &exceeded) {
+		return timeoutExitCode
+	}
 	return 1
 }
@@ -0,0 +1,174 @@
+// Where: cli/internal/command/history.go
+// What: CLI adapters for esb history and esb history show.
+// Why: Audit what was deployed when and pick a config to return to.
+package command
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	domaincfg "github.com/poruru-code/esb-cli/internal/domain/config"
+	"github.com/poruru-code/esb-cli/internal/infra/history"
+)
+
+var errHistoryRepoResolverMissing = errors.New("history: repo resolver not configured")
+
+func runHistoryList(cli CLI, deps Dependencies, out io.Writer) int {
+	repoRoot, err := resolveHistoryRepoRoot(deps)
+	if err != nil {
+		return exitWithError(out, err)
+	}
+	filter := history.Filter{Project: cli.History.List.Project, Env: cli.EnvFlag}
+	entries, err := history.List(repoRoot, filter)
+	if err != nil {
+		return exitWithError(out, fmt.Errorf("history: %w", err))
+	}
+	deps.Events.SetDetail("entries", entries)
+	if len(entries) == 0 {
+		legacyUI(out).Info("No deploy history recorded yet.")
+		return 0
+	}
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "ID\tDEPLOYED\tPROJECT\tENV\tKIND\tUSER\tGIT\tCHANGES")
+	for _, entry := range entries {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			entry.ID,
+			entry.CreatedAt.Local().Format(time.DateTime),
+			entry.Project,
+			entry.Env,
+			entry.Kind,
+			valueOrDash(entry.User),
+			formatHistoryGit(entry.Git),
+			formatHistoryChanges(entry.ConfigDiff),
+		)
+	}
+	_ = writer.Flush()
+	return 0
+}
+
+func runHistoryShow(cli CLI, deps Dependencies, out io.Writer) int {
+	repoRoot, err := resolveHistoryRepoRoot(deps)
+	if err != nil {
+		return exitWithError(out, err)
+	}
+	filter := history.Filter{Project: cli.History.Show.Project, Env: cli.EnvFlag}
+	entry, err := history.Find(repoRoot, cli.History.Show.ID, filter)
+	if err != nil {
+		return exitWithError(out, fmt.Errorf("history show: %w", err))
+	}
+	deps.Events.SetDetail("entry", entry)
+
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "ID:\t%s\n", entry.ID)
+	fmt.Fprintf(writer, "Deployed:\t%s\n", entry.CreatedAt.Local().Format(time.DateTime))
+	fmt.Fprintf(writer, "Kind:\t%s\n", entry.Kind)
+	if entry.RolledBackTo != "" {
+		fmt.Fprintf(writer, "Restored:\t%s\n", entry.RolledBackTo)
+	}
+	fmt.Fprintf(writer, "Project:\t%s (env: %s, mode: %s)\n", entry.Project, entry.Env, entry.Mode)
+	fmt.Fprintf(writer, "User:\t%s\n", valueOrDash(entry.User))
+	fmt.Fprintf(writer, "Git:\t%s\n", formatHistoryGit(entry.Git))
+	fmt.Fprintf(writer, "Template:\t%s (sha256: %s)\n", entry.Template.Path, valueOrDash(entry.Template.Sha256))
+	fmt.Fprintf(writer, "Parameters:\t%s\n", formatHistoryParameters(entry.Parameters))
+	fmt.Fprintf(writer, "Changes:\t%s\n", formatHistoryChanges(entry.ConfigDiff))
+	_ = writer.Flush()
+	if len(entry.Images) > 0 {
+		fmt.Fprintln(out, "Images:")
+		images := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		for _, image := range entry.Images {
+			fmt.Fprintf(images, "  %s\t%s\t%s\n", image.Function, image.Image, valueOrDash(image.Digest))
+		}
+		_ = images.Flush()
+	}
+	for _, name := range entry.Files {
+		data, err := os.ReadFile(filepath.Join(entry.ConfigDir(), name))
+		if err != nil {
+			return exitWithError(out, fmt.Errorf("history show: read %s: %w", name, err))
+		}
+		fmt.Fprintf(out, "\n--- %s ---\n", name)
+		_, _ = out.Write(data)
+		if len(data) > 0 && data[len(data)-1] != '\n' {
+			fmt.Fprintln(out)
+		}
+	}
+	return 0
+}
+
+func resolveHistoryRepoRoot(deps Dependencies) (string, error) {
+	if deps.RepoResolver == nil {
+		return "", errHistoryRepoResolverMissing
+	}
+	repoRoot, err := deps.RepoResolver("")
+	if err != nil {
+		return "", fmt.Errorf("resolve repo root: %w", err)
+	}
+	return repoRoot, nil
+}
+
+// formatHistoryGit renders "<short commit>" with a "(dirty)" marker.
+func formatHistoryGit(git history.Git) string {
+	commit := strings.TrimSpace(git.Commit)
+	if commit == "" {
+		return "-"
+	}
+	if len(commit) > 12 {
+		commit = commit[:12]
+	}
+	if git.Dirty {
+		return commit + " (dirty)"
+	}
+	return commit
+}
+
+// formatHistoryChanges renders "functions +1 ~0 -0, routes ..., resources ..."
+// with the resource sections summed.
+func formatHistoryChanges(diff history.ConfigDiff) string {
+	resources := domaincfg.Counts{}
+	for _, counts := range diff.Resources {
+		resources.Added += counts.Added
+		resources.Updated += counts.Updated
+		resources.Removed += counts.Removed
+	}
+	parts := []string{
+		"functions " + formatHistoryCounts(diff.Functions),
+		"routes " + formatHistoryCounts(diff.Routes),
+	}
+	if resources != (domaincfg.Counts{}) {
+		parts = append(parts, "resources "+formatHistoryCounts(resources))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatHistoryCounts(counts domaincfg.Counts) string {
+	return fmt.Sprintf("+%d ~%d -%d", counts.Added, counts.Updated, counts.Removed)
+}
+
+func formatHistoryParameters(parameters map[string]string) string {
+	if len(parameters) == 0 {
+		return "-"
+	}
+	keys := make([]string, 0, len(parameters))
+	for key := range parameters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, key+"="+parameters[key])
+	}
+	return strings.Join(pairs, " ")
+}
+
+func valueOrDash(value string) string {
+	if strings.TrimSpace(value) == "" {
+		return "-"
+	}
+	return value
+}
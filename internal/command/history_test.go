@@ -0,0 +1,96 @@
+// Where: cli/internal/command/history_test.go
+// What: Tests for the history command adapters.
+// Why: Keep the ledger table and the applied-config dump stable.
+package command
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	domaincfg "github.com/poruru-code/esb-cli/internal/domain/config"
+	"github.com/poruru-code/esb-cli/internal/infra/history"
+)
+
+func recordHistoryFixture(t *testing.T, repoRoot string, createdAt time.Time, env string) history.Entry {
+	t.Helper()
+	configDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(configDir, "functions.yml"), []byte("functions: {hello: {}}\n"), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	entry, err := history.Record(repoRoot, history.Entry{
+		CreatedAt:  createdAt,
+		Kind:       history.KindDeploy,
+		Project:    "esb-" + env,
+		Env:        env,
+		Mode:       "docker",
+		User:       "alice",
+		Git:        history.Git{Commit: "0123456789abcdef0123", Dirty: true},
+		Template:   history.Template{Path: "template.yaml", Sha256: "abc"},
+		Parameters: map[string]string{"Stage": env},
+		Images:     []history.Image{{Function: "hello", Image: "esb-hello:latest", Digest: "sha256:feed"}},
+		ConfigDiff: history.ConfigDiff{Functions: domaincfg.Counts{Added: 1, Total: 1}},
+	}, func(dir string) ([]string, error) {
+		data, err := os.ReadFile(filepath.Join(configDir, "functions.yml"))
+		if err != nil {
+			return nil, err
+		}
+		return []string{"functions.yml"}, os.WriteFile(filepath.Join(dir, "functions.yml"), data, 0o600)
+	})
+	if err != nil {
+		t.Fatalf("record history: %v", err)
+	}
+	return entry
+}
+
+func TestRunHistoryListFiltersByEnv(t *testing.T) {
+	repoRoot := newRollbackRepo(t)
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	recordHistoryFixture(t, repoRoot, createdAt, "dev")
+	recordHistoryFixture(t, repoRoot, createdAt.Add(time.Minute), "stg")
+
+	var out bytes.Buffer
+	if code := Run([]string{"history", "--env", "dev"}, rollbackDeps(&out, repoRoot)); code != 0 {
+		t.Fatalf("unexpected exit code %d: %s", code, out.String())
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "ID") {
+		t.Fatalf("unexpected output:\n%s", out.String())
+	}
+	for _, want := range []string{"20260102T030405Z", "esb-dev", "alice", "0123456789ab (dirty)", "functions +1 ~0 -0, routes +0 ~0 -0"} {
+		if !strings.Contains(lines[1], want) {
+			t.Fatalf("row %q missing %q", lines[1], want)
+		}
+	}
+}
+
+func TestRunHistoryShowPrintsAppliedConfig(t *testing.T) {
+	repoRoot := newRollbackRepo(t)
+	entry := recordHistoryFixture(t, repoRoot, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), "dev")
+
+	var out bytes.Buffer
+	if code := Run([]string{"history", "show", entry.ID}, rollbackDeps(&out, repoRoot)); code != 0 {
+		t.Fatalf("unexpected exit code %d: %s", code, out.String())
+	}
+	for _, want := range []string{
+		"Project:     esb-dev (env: dev, mode: docker)",
+		"Parameters:  Stage=dev",
+		"hello  esb-hello:latest  sha256:feed",
+		"--- functions.yml ---\nfunctions: {hello: {}}\n",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("output missing %q:\n%s", want, out.String())
+		}
+	}
+	if strings.Contains(out.String(), "routing.yml") {
+		t.Fatalf("files absent at apply time must not be printed:\n%s", out.String())
+	}
+
+	out.Reset()
+	if code := Run([]string{"history", "show", "unknown"}, rollbackDeps(&out, repoRoot)); code == 0 {
+		t.Fatalf("expected failure for unknown id: %s", out.String())
+	}
+}
@@ -0,0 +1,153 @@
+// Where: cli/internal/command/invoke.go
+// What: CLI adapters for function invocation and sample events.
+// Why: Invoke deployed functions locally without hand-written curl calls.
+package command
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/poruru-code/esb-cli/internal/domain/event"
+	"github.com/poruru-code/esb-cli/internal/infra/ui"
+	"github.com/poruru-code/esb-cli/internal/usecase/invoke"
+)
+
+var errInvokeHTTPClientNotConfigured = errors.New("invoke: http client not configured")
+
+type invokeResultDetail struct {
+	Function      string          `json:"function"`
+	GatewayURL    string          `json:"gateway_url"`
+	StatusCode    int             `json:"status_code"`
+	FunctionError string          `json:"function_error,omitempty"`
+	DurationMs    int64           `json:"duration_ms"`
+	Payload       json.RawMessage `json:"payload,omitempty"`
+	Logs          string          `json:"logs,omitempty"`
+}
+
+// runInvoke executes the 'invoke' command.
+func runInvoke(cli CLI, deps Dependencies, out io.Writer) int {
+	if deps.Invoke.HTTPClient == nil {
+		return exitWithError(out, errInvokeHTTPClientNotConfigured)
+	}
+	payload, err := readInvokePayload(cli.Invoke.Event, deps.In)
+	if err != nil {
+		return exitWithError(out, err)
+	}
+	workflow := invoke.Workflow{
+		DockerClient: invoke.DockerClientFactory(deps.Invoke.DockerClient),
+		HTTPClient:   deps.Invoke.HTTPClient,
+	}
+	result, err := workflow.Run(invoke.Request{
+		Function:       cli.Invoke.Function,
+		Payload:        payload,
+		ComposeProject: strings.TrimSpace(cli.Invoke.Project),
+		GatewayURL:     cli.Invoke.URL,
+		Timeout:        cli.Invoke.Timeout,
+		NoLogs:         cli.Invoke.NoLogs,
+	})
+	if err != nil {
+		return exitWithError(out, err)
+	}
+
+	deps.Events.SetDetail("invoke", newInvokeResultDetail(result))
+	writeInvokeResult(out, result)
+	if result.Failed() {
+		return 1
+	}
+	return 0
+}
+
+func readInvokePayload(path string, in io.Reader) ([]byte, error) {
+	trimmed := strings.TrimSpace(path)
+	if trimmed == "" {
+		return nil, nil
+	}
+	var (
+		data []byte
+		err  error
+	)
+	if trimmed == "-" {
+		if in == nil {
+			in = os.Stdin
+		}
+		data, err = io.ReadAll(in)
+	} else {
+		data, err = os.ReadFile(trimmed)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read event %s: %w", trimmed, err)
+	}
+	if len(strings.TrimSpace(string(data))) > 0 && !json.Valid(data) {
+		return nil, fmt.Errorf("event %s is not valid JSON", trimmed)
+	}
+	return data, nil
+}
+
+func writeInvokeResult(out io.Writer, result invoke.Result) {
+	console := legacyUI(out)
+	status := "ok"
+	if result.Failed() {
+		status = "failed"
+	}
+	rows := []ui.KeyValue{
+		{Key: "Function", Value: result.Function},
+		{Key: "Gateway", Value: result.GatewayURL},
+		{Key: "Status", Value: fmt.Sprintf("%d (%s)", result.StatusCode, status)},
+		{Key: "Duration", Value: result.Duration.Round(time.Millisecond).String()},
+	}
+	if result.FunctionError != "" {
+		rows = append(rows, ui.KeyValue{Key: "FunctionError", Value: result.FunctionError})
+	}
+	console.Block("⚡", "Invoke result", rows)
+	if result.Logs != "" {
+		console.Info("Logs:")
+		for _, line := range strings.Split(result.Logs, "\n") {
+			console.Info("  " + line)
+		}
+	}
+	console.Info(strings.TrimRight(string(result.Payload), "\n"))
+}
+
+func newInvokeResultDetail(result invoke.Result) invokeResultDetail {
+	detail := invokeResultDetail{
+		Function:      result.Function,
+		GatewayURL:    result.GatewayURL,
+		StatusCode:    result.StatusCode,
+		FunctionError: result.FunctionError,
+		DurationMs:    result.Duration.Milliseconds(),
+		Logs:          result.Logs,
+	}
+	if json.Valid(result.Payload) {
+		detail.Payload = json.RawMessage(result.Payload)
+	}
+	return detail
+}
+
+// runEvent prints a sample event payload for the requested kind.
+func runEvent(cli CLI, deps Dependencies, out io.Writer) int {
+	payload, err := event.Generate(cli.Event.Kind, event.Options{
+		Method: cli.Event.Method,
+		Path:   cli.Event.Path,
+		Body:   cli.Event.Body,
+		Name:   cli.Event.Name,
+		Region: cli.Event.Region,
+	})
+	if err != nil {
+		return exitWithError(out, err)
+	}
+	if deps.Events != nil {
+		deps.Events.SetDetail("event", payload)
+		return 0
+	}
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(payload); err != nil {
+		return exitWithError(out, err)
+	}
+	return 0
+}
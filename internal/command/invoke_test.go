@@ -0,0 +1,99 @@
+// Where: cli/internal/command/invoke_test.go
+// What: Tests for invoke/event command adapters.
+// Why: Keep payload loading and output wiring stable.
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunInvokeWithExplicitURL(t *testing.T) {
+	var gotPath, gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	eventPath := filepath.Join(t.TempDir(), "event.json")
+	if err := os.WriteFile(eventPath, []byte(`{"hello":"world"}`), 0o600); err != nil {
+		t.Fatalf("write event: %v", err)
+	}
+
+	var out bytes.Buffer
+	exitCode := Run(
+		[]string{"invoke", "hello", "--url", server.URL, "--event", eventPath},
+		Dependencies{Out: &out, Invoke: InvokeDeps{HTTPClient: server.Client()}},
+	)
+	if exitCode != 0 {
+		t.Fatalf("unexpected exit code %d: %s", exitCode, out.String())
+	}
+	if gotPath != "/2015-03-31/functions/hello/invocations" {
+		t.Fatalf("unexpected path: %q", gotPath)
+	}
+	if gotBody != `{"hello":"world"}` {
+		t.Fatalf("unexpected body: %q", gotBody)
+	}
+	if !strings.Contains(out.String(), `{"ok":true}`) {
+		t.Fatalf("expected payload in output: %q", out.String())
+	}
+}
+
+func TestRunInvokeFunctionErrorExitsNonZero(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-Amz-Function-Error", "Unhandled")
+		_, _ = w.Write([]byte(`{"errorMessage":"boom"}`))
+	}))
+	defer server.Close()
+
+	var out bytes.Buffer
+	exitCode := Run(
+		[]string{"invoke", "hello", "--url", server.URL},
+		Dependencies{Out: &out, Invoke: InvokeDeps{HTTPClient: server.Client()}},
+	)
+	if exitCode == 0 {
+		t.Fatalf("expected non-zero exit code for function error")
+	}
+	if !strings.Contains(out.String(), "Unhandled") {
+		t.Fatalf("expected function error in output: %q", out.String())
+	}
+}
+
+func TestReadInvokePayloadFromStdinRejectsInvalidJSON(t *testing.T) {
+	if _, err := readInvokePayload("-", strings.NewReader("not json")); err == nil {
+		t.Fatalf("expected error for invalid JSON")
+	}
+	data, err := readInvokePayload("-", strings.NewReader(`{"a":1}`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != `{"a":1}` {
+		t.Fatalf("unexpected payload: %q", string(data))
+	}
+}
+
+func TestRunEventPrintsJSON(t *testing.T) {
+	var out bytes.Buffer
+	exitCode := Run([]string{"event", "sqs", "--body", "hi"}, Dependencies{Out: &out})
+	if exitCode != 0 {
+		t.Fatalf("unexpected exit code %d: %s", exitCode, out.String())
+	}
+	var payload map[string]any
+	if err := json.Unmarshal(out.Bytes(), &payload); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out.String())
+	}
+	records, ok := payload["Records"].([]any)
+	if !ok || len(records) != 1 {
+		t.Fatalf("unexpected records: %#v", payload["Records"])
+	}
+}
@@ -0,0 +1,83 @@
+// Where: cli/internal/command/logs.go
+// What: CLI adapter for function log queries.
+// Why: Read and follow function logs without opening the VictoriaLogs UI.
+package command
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/poruru-code/esb-cli/internal/infra/ui"
+	"github.com/poruru-code/esb-cli/internal/usecase/logs"
+)
+
+var errLogsHTTPClientNotConfigured = errors.New("logs: http client not configured")
+
+const logTimeLayout = "2006-01-02 15:04:05.000"
+
+// runLogs executes the 'logs' command.
+func runLogs(cli CLI, deps Dependencies, out io.Writer) int {
+	if deps.Logs.HTTPClient == nil {
+		return exitWithError(out, errLogsHTTPClientNotConfigured)
+	}
+	ctx := commandContext(deps)
+
+	workflow := logs.Workflow{
+		DockerClient: logs.DockerClientFactory(deps.Logs.DockerClient),
+		HTTPClient:   deps.Logs.HTTPClient,
+	}
+	count := 0
+	err := workflow.Run(ctx, logs.Request{
+		Function:       cli.Logs.Function,
+		RequestID:      cli.Logs.RequestID,
+		Filter:         cli.Logs.Filter,
+		Since:          cli.Logs.Since,
+		Limit:          cli.Logs.Limit,
+		Follow:         cli.Logs.Follow,
+		ComposeProject: strings.TrimSpace(cli.Logs.Project),
+		URL:            cli.Logs.URL,
+	}, func(entry logs.Entry) {
+		count++
+		if deps.Events != nil {
+			deps.Events.Emit(ui.EventLogEntry, ui.LogEntryEvent{
+				Time:      entry.Time,
+				Function:  entry.Function,
+				RequestID: entry.RequestID,
+				Level:     entry.Level,
+				Message:   entry.Message,
+				Fields:    entry.Fields,
+			})
+			return
+		}
+		fmt.Fprintln(out, formatLogEntry(entry))
+	})
+	if err != nil {
+		return exitWithError(out, err)
+	}
+	deps.Events.SetDetail("logs", map[string]int{"count": count})
+	if count == 0 && !cli.Logs.Follow && deps.Events == nil {
+		legacyUI(out).Info(fmt.Sprintf("No logs found in the last %s.", cli.Logs.Since))
+	}
+	return 0
+}
+
+// formatLogEntry renders "<time> <function> [<request id>] <LEVEL> <message>".
+func formatLogEntry(entry logs.Entry) string {
+	parts := make([]string, 0, 5)
+	if !entry.Time.IsZero() {
+		parts = append(parts, entry.Time.Local().Format(logTimeLayout))
+	}
+	if entry.Function != "" {
+		parts = append(parts, entry.Function)
+	}
+	if entry.RequestID != "" {
+		parts = append(parts, "["+entry.RequestID+"]")
+	}
+	if entry.Level != "" {
+		parts = append(parts, strings.ToUpper(entry.Level))
+	}
+	parts = append(parts, strings.TrimRight(entry.Message, "\n"))
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,54 @@
+// Where: cli/internal/command/logs_test.go
+// What: Tests for the logs command adapter.
+// Why: Keep log line formatting and routing stable.
+package command
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/poruru-code/esb-cli/internal/usecase/logs"
+)
+
+func TestRunLogsPrintsFormattedLines(t *testing.T) {
+	var gotQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		form, _ := url.ParseQuery(string(body))
+		gotQuery = form.Get("query")
+		_, _ = io.WriteString(w, `{"_time":"2026-01-01T00:00:01Z","_msg":"hello world","function_name":"hello","request_id":"r1","level":"info"}`+"\n")
+	}))
+	defer server.Close()
+
+	var out bytes.Buffer
+	exitCode := Run(
+		[]string{"logs", "hello", "--since", "5m", "--url", server.URL},
+		Dependencies{Out: &out, Logs: LogsDeps{HTTPClient: server.Client()}},
+	)
+	if exitCode != 0 {
+		t.Fatalf("unexpected exit code %d: %s", exitCode, out.String())
+	}
+	if !strings.HasPrefix(gotQuery, `_time:300s function_name:="hello"`) {
+		t.Fatalf("unexpected query: %q", gotQuery)
+	}
+	if !strings.Contains(out.String(), `hello [r1] INFO hello world`) {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+}
+
+func TestFormatLogEntryOmitsEmptyFields(t *testing.T) {
+	got := formatLogEntry(logs.Entry{Message: "plain\n"})
+	if got != "plain" {
+		t.Fatalf("unexpected line: %q", got)
+	}
+	entry := logs.Entry{Time: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), Function: "fn", Message: "m"}
+	if !strings.HasSuffix(formatLogEntry(entry), " fn m") {
+		t.Fatalf("unexpected line: %q", formatLogEntry(entry))
+	}
+}
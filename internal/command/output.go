@@ -11,6 +11,35 @@ import (
 	"github.com/poruru-code/esb-cli/internal/infra/ui"
 )
 
+const outputModeJSON = "json"
+
+// jsonModeOutput carries human-readable output (stderr) in JSON mode and
+// lets error helpers record failures on the event stream.
+type jsonModeOutput struct {
+	io.Writer
+	events *ui.JSONEventStream
+}
+
+// enableJSONOutput routes events to out and human-readable text to ErrOut.
+func enableJSONOutput(deps Dependencies, out io.Writer) (Dependencies, io.Writer) {
+	deps.Events = ui.NewJSONEventStream(out)
+	return deps, jsonModeOutput{Writer: resolveErrWriter(deps.ErrOut), events: deps.Events}
+}
+
+// eventSink returns the JSON event stream as a sink, or nil in text mode.
+func eventSink(deps Dependencies) ui.EventSink {
+	if deps.Events == nil {
+		return nil
+	}
+	return deps.Events
+}
+
+func recordCommandError(out io.Writer, err error) {
+	if output, ok := out.(jsonModeOutput); ok {
+		output.events.RecordError(err)
+	}
+}
+
 func legacyUI(out io.Writer) ui.UserInterface {
 	return ui.NewLegacyUI(out)
 }
@@ -0,0 +1,173 @@
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/poruru-code/esb-cli/internal/domain/state"
+	"github.com/poruru-code/esb-cli/internal/infra/ui"
+	"github.com/poruru-code/esb-cli/internal/version"
+)
+
+type jsonTestEvent struct {
+	Event string          `json:"event"`
+	Data  json.RawMessage `json:"data"`
+}
+
+func decodeJSONEvents(t *testing.T, raw string) []jsonTestEvent {
+	t.Helper()
+	var events []jsonTestEvent
+	for _, line := range strings.Split(strings.TrimSpace(raw), "\n") {
+		var event jsonTestEvent
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("decode event line %q: %v", line, err)
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestRunVersionJSONOutput(t *testing.T) {
+	var out, errOut bytes.Buffer
+
+	exitCode := Run([]string{"--output", "json", "version"}, Dependencies{Out: &out, ErrOut: &errOut})
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d", exitCode)
+	}
+	events := decodeJSONEvents(t, out.String())
+	if len(events) != 1 || events[0].Event != ui.EventResult {
+		t.Fatalf("expected single result event, got %s", out.String())
+	}
+	var result ui.ResultEvent
+	if err := json.Unmarshal(events[0].Data, &result); err != nil {
+		t.Fatalf("decode result: %v", err)
+	}
+	if result.Command != "version" || result.Status != "ok" || result.Details["version"] != version.GetVersion() {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if !strings.Contains(errOut.String(), version.GetVersion()) {
+		t.Fatalf("expected human output on stderr, got %q", errOut.String())
+	}
+}
+
+func TestExitWithErrorRecordsJSONError(t *testing.T) {
+	var out, errOut bytes.Buffer
+	deps, humanOut := enableJSONOutput(Dependencies{ErrOut: &errOut}, &out)
+
+	exitCode := exitWithError(humanOut, errors.New("boom"))
+	deps.Events.Finish("deploy", exitCode)
+
+	events := decodeJSONEvents(t, out.String())
+	var result ui.ResultEvent
+	if err := json.Unmarshal(events[len(events)-1].Data, &result); err != nil {
+		t.Fatalf("decode result: %v", err)
+	}
+	if result.Status != "error" || result.ExitCode != 1 || result.Error != "boom" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if !strings.Contains(errOut.String(), "boom") {
+		t.Fatalf("expected error text on stderr, got %q", errOut.String())
+	}
+}
+
+func TestDeployCommandEmitsJSONEvents(t *testing.T) {
+	tmp := t.TempDir()
+	setWorkingDir(t, tmp)
+	if err := os.WriteFile(filepath.Join(tmp, "docker-compose.docker.yml"), []byte("services: {}\n"), 0o600); err != nil {
+		t.Fatalf("write compose marker: %v", err)
+	}
+	templatePath := filepath.Join(tmp, "template.yaml")
+	if err := os.WriteFile(templatePath, []byte("Resources: {}"), 0o600); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+	writeTestRuntimeAssets(t, tmp)
+
+	var out bytes.Buffer
+	stream := ui.NewJSONEventStream(&out)
+	builder := &deployEntryBuilder{}
+	cmd := &deployCommand{
+		build:         builder.Build,
+		applyRuntime:  func(state.Context) error { return nil },
+		ui:            deployEntryUI{},
+		composeRunner: deployEntryRunner{},
+		events:        stream,
+	}
+
+	err := cmd.runWithOverrides(
+		deployInputs{
+			ProjectDir:   tmp,
+			ArtifactRoot: filepath.Join(tmp, "artifact-root"),
+			Env:          "dev",
+			Mode:         "docker",
+			Project:      "esb-dev",
+			Templates:    []deployTemplateInput{{TemplatePath: templatePath, OutputDir: ".out"}},
+		},
+		DeployCmd{BuildOnly: true},
+		deployRunOverrides{},
+	)
+	if err != nil {
+		t.Fatalf("run deploy command: %v", err)
+	}
+	if len(builder.requests) != 1 || builder.requests[0].Events == nil {
+		t.Fatalf("expected events to be forwarded to build request: %#v", builder.requests)
+	}
+	stream.Finish("deploy", 0)
+
+	names := []string{}
+	for _, event := range decodeJSONEvents(t, out.String()) {
+		names = append(names, event.Event)
+	}
+	expected := []string{ui.EventInputs, ui.EventConfigDiff, ui.EventResult}
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Fatalf("expected events %v, got %v", expected, names)
+	}
+}
+
+func TestDeployInputsEventMasksNoEchoParameters(t *testing.T) {
+	templatePath := filepath.Join(t.TempDir(), "template.yaml")
+	template := "Parameters:\n  DbPassword:\n    Type: String\n    NoEcho: true\n  Stage:\n    Type: String\nResources: {}\n"
+	if err := os.WriteFile(templatePath, []byte(template), 0o600); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+	noEcho, err := templateNoEchoParameters(templatePath)
+	if err != nil {
+		t.Fatalf("read NoEcho parameters: %v", err)
+	}
+	if strings.Join(noEcho, ",") != "DbPassword" {
+		t.Fatalf("unexpected NoEcho parameters: %v", noEcho)
+	}
+
+	var out bytes.Buffer
+	stream := ui.NewJSONEventStream(&out)
+	parameters := map[string]string{"DbPassword": "s3cret", "Stage": "dev"}
+	cmd := &deployCommand{events: stream}
+	cmd.emitInputs(deployInputs{
+		Templates: []deployTemplateInput{{
+			TemplatePath:     templatePath,
+			Parameters:       parameters,
+			NoEchoParameters: noEcho,
+		}},
+	}, deployRunConfig{})
+	stream.Finish("deploy", 0)
+
+	if strings.Contains(out.String(), "s3cret") {
+		t.Fatalf("NoEcho value leaked into JSON output: %s", out.String())
+	}
+	events := decodeJSONEvents(t, out.String())
+	var result ui.ResultEvent
+	if err := json.Unmarshal(events[len(events)-1].Data, &result); err != nil {
+		t.Fatalf("decode result: %v", err)
+	}
+	raw, _ := json.Marshal(result.Inputs)
+	if !strings.Contains(string(raw), `"DbPassword":"****"`) || !strings.Contains(string(raw), `"Stage":"dev"`) {
+		t.Fatalf("expected masked parameters in result inputs, got %s", raw)
+	}
+	if parameters["DbPassword"] != "s3cret" {
+		t.Fatal("masking must not modify the deploy parameters")
+	}
+}
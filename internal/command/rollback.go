@@ -0,0 +1,64 @@
+// Where: cli/internal/command/rollback.go
+// What: CLI adapter for esb rollback.
+// Why: Return the gateway to a recorded deploy, e.g. when an automatic
+// rollback failed.
+package command
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/poruru-code/esb-cli/internal/infra/ui"
+	usecasedeploy "github.com/poruru-code/esb-cli/internal/usecase/deploy"
+)
+
+func runRollback(cli CLI, deps Dependencies, out io.Writer) int {
+	if deps.RepoResolver == nil {
+		return exitWithError(out, errors.New("rollback: repo resolver not configured"))
+	}
+	repoRoot, err := deps.RepoResolver("")
+	if err != nil {
+		return exitWithError(out, fmt.Errorf("resolve repo root: %w", err))
+	}
+	target := resolveStackTarget(cli, cli.Rollback.Project, deps, true)
+	rollbackUI := ui.NewEventUI(legacyUI(out), eventSink(deps))
+	workflow := usecasedeploy.Workflow{
+		UserInterface: rollbackUI,
+		ComposeRunner: deps.Deploy.Provision.ComposeRunner,
+		DockerClient:  usecasedeploy.DockerClientFactory(deps.Deploy.Runtime.DockerClient),
+	}
+	entry, err := workflow.Rollback(usecasedeploy.RollbackRequest{
+		Ctx:      commandContext(deps),
+		RepoRoot: repoRoot,
+		Project:  target.project,
+		Env:      target.env,
+		ID:       cli.Rollback.To,
+	})
+	if errors.Is(err, usecasedeploy.ErrNoRuntimeConfigSnapshot) {
+		return exitWithError(out, fmt.Errorf("rollback: %w; run `esb deploy` first", err))
+	}
+	if err != nil {
+		return exitWithError(out, fmt.Errorf("rollback: %w", err))
+	}
+	deps.Events.SetDetail("project", target.project)
+	deps.Events.SetDetail("env", target.env)
+	deps.Events.SetDetail("history_id", entry.ID)
+	deps.Events.SetDetail("history_created_at", entry.CreatedAt.Format(time.RFC3339))
+	deps.Events.SetDetail("files", entry.Files)
+	files := "no config files"
+	if len(entry.Files) > 0 {
+		files = strings.Join(entry.Files, ", ")
+	}
+	rollbackUI.Success(fmt.Sprintf(
+		"Restored runtime config of %s (env: %s) from history entry %s, deployed %s (%s)",
+		target.project,
+		target.env,
+		entry.ID,
+		entry.CreatedAt.Local().Format(time.DateTime),
+		files,
+	))
+	return 0
+}
@@ -0,0 +1,80 @@
+// Where: cli/internal/command/rollback_test.go
+// What: Tests for the rollback command adapter.
+// Why: Keep the history restore and its missing-history message stable.
+package command
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/poruru-code/esb-cli/internal/infra/staging"
+)
+
+func newRollbackRepo(t *testing.T) string {
+	t.Helper()
+	repoRoot := t.TempDir()
+	if err := os.WriteFile(filepath.Join(repoRoot, "docker-compose.docker.yml"), []byte("services: {}\n"), 0o600); err != nil {
+		t.Fatalf("write compose marker: %v", err)
+	}
+	return repoRoot
+}
+
+func rollbackDeps(out *bytes.Buffer, repoRoot string) Dependencies {
+	return Dependencies{
+		Out:    out,
+		ErrOut: out,
+		RepoResolver: func(string) (string, error) {
+			return repoRoot, nil
+		},
+	}
+}
+
+func TestRunRollbackRestoresHistoryEntry(t *testing.T) {
+	t.Setenv("ENV_PREFIX", "ESB")
+	t.Setenv("ESB_ENV", "")
+	repoRoot := newRollbackRepo(t)
+	first := recordHistoryFixture(t, repoRoot, time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), "dev")
+	recordHistoryFixture(t, repoRoot, time.Date(2026, 1, 2, 4, 4, 5, 0, time.UTC), "dev")
+	configDir, err := staging.ConfigDir(repoRoot, "esb-dev", "dev")
+	if err != nil {
+		t.Fatalf("config dir: %v", err)
+	}
+	if err := os.MkdirAll(configDir, 0o755); err != nil {
+		t.Fatalf("mkdir config: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(configDir, "routing.yml"), []byte("routes: [broken]\n"), 0o644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	var out bytes.Buffer
+	args := []string{"rollback", "-p", "esb-dev", "-e", "dev", "--to", first.ID}
+	if code := Run(args, rollbackDeps(&out, repoRoot)); code != 0 {
+		t.Fatalf("unexpected exit code %d: %s", code, out.String())
+	}
+	if !strings.Contains(out.String(), "Restored runtime config of esb-dev (env: dev) from history entry "+first.ID) {
+		t.Fatalf("unexpected output: %s", out.String())
+	}
+	data, err := os.ReadFile(filepath.Join(configDir, "functions.yml"))
+	if err != nil || string(data) != "functions: {hello: {}}\n" {
+		t.Fatalf("functions.yml not restored: %q (%v)", data, err)
+	}
+	if _, err := os.Stat(filepath.Join(configDir, "routing.yml")); !os.IsNotExist(err) {
+		t.Fatalf("routing.yml must be removed: %v", err)
+	}
+}
+
+func TestRunRollbackWithoutSnapshotFails(t *testing.T) {
+	t.Setenv("ENV_PREFIX", "ESB")
+	var out bytes.Buffer
+	code := Run([]string{"rollback", "-p", "esb-dev", "-e", "dev"}, rollbackDeps(&out, newRollbackRepo(t)))
+	if code == 0 {
+		t.Fatalf("expected failure, got output: %s", out.String())
+	}
+	if !strings.Contains(out.String(), "run `esb deploy` first") {
+		t.Fatalf("unexpected output: %s", out.String())
+	}
+}
@@ -0,0 +1,212 @@
+// Where: cli/internal/command/stack.go
+// What: CLI adapters for stack up/down/status.
+// Why: Start, stop and inspect a project-env stack without raw docker compose.
+package command
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/poruru-code/esb-cli/internal/constants"
+	runtimecfg "github.com/poruru-code/esb-cli/internal/domain/runtime"
+	"github.com/poruru-code/esb-cli/internal/infra/envutil"
+	"github.com/poruru-code/esb-cli/internal/infra/interaction"
+	"github.com/poruru-code/esb-cli/internal/infra/ui"
+	"github.com/poruru-code/esb-cli/internal/usecase/stack"
+)
+
+var errStackRunnerNotConfigured = errors.New("stack: command runner not configured")
+
+// stackTarget is the resolved project/env of a stack command.
+type stackTarget struct {
+	project string
+	env     string
+}
+
+func runUp(cli CLI, deps Dependencies, out io.Writer) int {
+	if deps.Stack.Runner == nil {
+		return exitWithError(out, errStackRunnerNotConfigured)
+	}
+	repoRoot, err := deps.RepoResolver("")
+	if err != nil {
+		return exitWithError(out, fmt.Errorf("resolve repo root: %w", err))
+	}
+	mode, err := resolveStackMode(cli.Up.Mode)
+	if err != nil {
+		return exitWithError(out, err)
+	}
+	target := resolveStackTarget(cli, cli.Up.Project, deps, false)
+	ctx := commandContext(deps)
+
+	stackUI := ui.NewEventUI(legacyUI(out), eventSink(deps))
+	stackUI.Info(fmt.Sprintf("Starting %s (env: %s, mode: %s)", target.project, target.env, mode))
+	services, err := newStackWorkflow(deps).Up(ctx, stack.UpRequest{
+		Request: stack.Request{
+			RepoRoot:     repoRoot,
+			Project:      target.project,
+			Env:          target.env,
+			Mode:         mode,
+			ComposeFiles: cli.Up.ComposeFiles,
+		},
+		Wait:    !cli.Up.NoWait,
+		Timeout: cli.Up.Timeout,
+		Build:   cli.Up.Build,
+	})
+	if len(services) > 0 {
+		writeStackStatus(out, deps, target, services)
+	}
+	if err != nil {
+		return exitWithError(out, fmt.Errorf("up: %w", err))
+	}
+	stackUI.Success(fmt.Sprintf("Stack %s is up", target.project))
+	return 0
+}
+
+func runDown(cli CLI, deps Dependencies, out io.Writer) int {
+	if deps.Stack.Runner == nil {
+		return exitWithError(out, errStackRunnerNotConfigured)
+	}
+	repoRoot, _ := deps.RepoResolver("")
+	mode, err := resolveStackMode(cli.Down.Mode)
+	if err != nil {
+		return exitWithError(out, err)
+	}
+	target := resolveStackTarget(cli, cli.Down.Project, deps, true)
+	err = newStackWorkflow(deps).Down(commandContext(deps), stack.DownRequest{
+		Request: stack.Request{
+			RepoRoot:     repoRoot,
+			Project:      target.project,
+			Env:          target.env,
+			Mode:         mode,
+			ComposeFiles: cli.Down.ComposeFiles,
+		},
+		Volumes: cli.Down.Volumes,
+	})
+	if err != nil {
+		return exitWithError(out, fmt.Errorf("down: %w", err))
+	}
+	deps.Events.SetDetail("project", target.project)
+	ui.NewEventUI(legacyUI(out), eventSink(deps)).Success(fmt.Sprintf("Stack %s is down", target.project))
+	return 0
+}
+
+func runStatus(cli CLI, deps Dependencies, out io.Writer) int {
+	target := resolveStackTarget(cli, cli.Status.Project, deps, true)
+	services, err := newStackWorkflow(deps).Status(commandContext(deps), stack.Request{Project: target.project})
+	if err != nil {
+		return exitWithError(out, fmt.Errorf("status: %w", err))
+	}
+	if len(services) == 0 {
+		deps.Events.SetDetail("project", target.project)
+		legacyUI(out).Info(fmt.Sprintf("No containers found for project %s.", target.project))
+		return 0
+	}
+	writeStackStatus(out, deps, target, services)
+	return 0
+}
+
+func newStackWorkflow(deps Dependencies) stack.Workflow {
+	return stack.Workflow{
+		Runner:          deps.Stack.Runner,
+		DockerClient:    stack.DockerClientFactory(deps.Stack.DockerClient),
+		ApplyRuntimeEnv: deps.Deploy.Runtime.ApplyRuntimeEnv,
+	}
+}
+
+// resolveStackTarget resolves the project from --project, ENV_PROJECT_NAME or
+// <PREFIX>_PROJECT; with discover, a running stack (prompting when several
+// run); otherwise "<brand>-<env>". The env comes from --env, <PREFIX>_ENV or
+// the running stack.
+func resolveStackTarget(cli CLI, flagProject string, deps Dependencies, discover bool) stackTarget {
+	env := strings.TrimSpace(cli.EnvFlag)
+	if env == "" {
+		if hostEnv, err := envutil.GetHostEnv(constants.HostSuffixEnv); err == nil {
+			env = strings.TrimSpace(hostEnv)
+		}
+	}
+	project, _, explicit := resolveProjectValue(flagProject)
+	if !explicit && discover && env == "" {
+		errOut := resolveErrWriter(deps.ErrOut)
+		stacks, err := discoverRunningDeployTargetStacks(deps.Stack.DockerClient)
+		if err != nil {
+			writeWarningf(errOut, "Warning: failed to discover running stacks: %v\n", err)
+		}
+		selected, err := resolveDeployTargetStack(stacks, interaction.IsTerminal(os.Stdin), deps.Prompter, errOut)
+		if err != nil {
+			writeWarningf(errOut, "Warning: %v\n", err)
+		}
+		project = strings.TrimSpace(selected.Project)
+		env = strings.TrimSpace(selected.Env)
+	}
+	if project == "" {
+		project = defaultDeployProject(env)
+	}
+	if env == "" {
+		env = inferEnvFromStackName(project)
+	}
+	return stackTarget{project: project, env: env}
+}
+
+func resolveStackMode(flagMode string) (string, error) {
+	mode := strings.TrimSpace(flagMode)
+	if mode == "" {
+		if hostMode, err := envutil.GetHostEnv(constants.HostSuffixMode); err == nil {
+			mode = strings.TrimSpace(hostMode)
+		}
+	}
+	if mode == "" {
+		return runtimecfg.ModeDocker, nil
+	}
+	normalized, err := runtimecfg.NormalizeMode(mode)
+	if err != nil {
+		return "", fmt.Errorf("normalize mode: %w", err)
+	}
+	return normalized, nil
+}
+
+// writeStackStatus prints a service table, or records it as a JSON detail.
+func writeStackStatus(out io.Writer, deps Dependencies, target stackTarget, services []stack.Service) {
+	if deps.Events != nil {
+		deps.Events.SetDetail("project", target.project)
+		deps.Events.SetDetail("services", services)
+	}
+	fmt.Fprintf(out, "Project: %s (env: %s)\n", target.project, target.env)
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "SERVICE\tCONTAINER\tSTATE\tHEALTH\tPORTS")
+	for _, service := range services {
+		health := service.Health
+		if health == "" {
+			health = "-"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n",
+			service.Name, service.Container, service.State, health, formatStackPorts(service.Ports))
+	}
+	_ = writer.Flush()
+}
+
+// formatStackPorts renders "443->8443/tcp (PORT_GATEWAY_HTTPS)" entries.
+func formatStackPorts(ports []stack.Port) string {
+	if len(ports) == 0 {
+		return "-"
+	}
+	seen := map[string]struct{}{}
+	parts := make([]string, 0, len(ports))
+	for _, port := range ports {
+		entry := strconv.Itoa(port.HostPort) + "->" + strconv.Itoa(port.ContainerPort) + "/" + port.Protocol
+		if port.EnvVar != "" {
+			entry += " (" + port.EnvVar + ")"
+		}
+		// Docker lists IPv4 and IPv6 bindings separately.
+		if _, ok := seen[entry]; ok {
+			continue
+		}
+		seen[entry] = struct{}{}
+		parts = append(parts, entry)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,122 @@
+// Where: cli/internal/command/stack_test.go
+// What: Tests for the up/down/status command adapters.
+// Why: Keep stack target resolution and the status table stable.
+package command
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/poruru-code/esb-cli/internal/infra/compose"
+)
+
+type stackCommandRunner struct {
+	commands []string
+}
+
+func (r *stackCommandRunner) Run(_ context.Context, _, name string, args ...string) error {
+	r.commands = append(r.commands, strings.Join(append([]string{name}, args...), " "))
+	return nil
+}
+
+func (r *stackCommandRunner) RunQuiet(ctx context.Context, dir, name string, args ...string) error {
+	return r.Run(ctx, dir, name, args...)
+}
+
+func (r *stackCommandRunner) RunOutput(context.Context, string, string, ...string) ([]byte, error) {
+	return nil, nil
+}
+
+func stackDeps(out *bytes.Buffer, runner *stackCommandRunner, containers []container.Summary) Dependencies {
+	client := &stackDockerClient{containers: containers}
+	return Dependencies{
+		Out:    out,
+		ErrOut: out,
+		RepoResolver: func(string) (string, error) {
+			return "", errStackRunnerNotConfigured
+		},
+		Stack: StackDeps{
+			Runner: runner,
+			DockerClient: func() (compose.DockerClient, error) {
+				return client, nil
+			},
+		},
+	}
+}
+
+func TestRunStatusPrintsServiceTable(t *testing.T) {
+	containers := []container.Summary{
+		{
+			Names:  []string{"/esb-dev-gateway"},
+			State:  "running",
+			Status: "Up 2 minutes (healthy)",
+			Ports: []container.Port{
+				{IP: "0.0.0.0", PrivatePort: 8443, PublicPort: 443, Type: "tcp"},
+				{IP: "::", PrivatePort: 8443, PublicPort: 443, Type: "tcp"},
+			},
+			Labels: map[string]string{compose.ComposeProjectLabel: "esb-dev", compose.ComposeServiceLabel: "gateway"},
+		},
+		{
+			Names:  []string{"/esb-dev-agent"},
+			State:  "exited",
+			Status: "Exited (1) 5 seconds ago",
+			Labels: map[string]string{compose.ComposeProjectLabel: "esb-dev", compose.ComposeServiceLabel: "agent"},
+		},
+	}
+	var out bytes.Buffer
+	exitCode := Run([]string{"status", "-p", "esb-dev"}, stackDeps(&out, &stackCommandRunner{}, containers))
+	if exitCode != 0 {
+		t.Fatalf("unexpected exit code %d: %s", exitCode, out.String())
+	}
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 4 || lines[0] != "Project: esb-dev (env: dev)" {
+		t.Fatalf("unexpected output:\n%s", out.String())
+	}
+	if !strings.HasPrefix(lines[1], "SERVICE") || !strings.Contains(lines[2], "agent") || !strings.Contains(lines[2], "exited") {
+		t.Fatalf("unexpected table:\n%s", out.String())
+	}
+	if !strings.HasSuffix(lines[3], "healthy  443->8443/tcp (PORT_GATEWAY_HTTPS)") {
+		t.Fatalf("unexpected gateway row: %q", lines[3])
+	}
+}
+
+func TestRunDownUsesDiscoveredStack(t *testing.T) {
+	t.Setenv("ENV_PREFIX", "ESB")
+	t.Setenv("ESB_ENV", "")
+	t.Setenv("ESB_PROJECT", "")
+	t.Setenv("PROJECT_NAME", "")
+	containers := []container.Summary{{
+		Names:  []string{"/esb-staging-gateway"},
+		State:  "running",
+		Labels: map[string]string{compose.ComposeProjectLabel: "esb-staging", compose.ComposeServiceLabel: "gateway"},
+	}}
+	runner := &stackCommandRunner{}
+	var out bytes.Buffer
+	exitCode := Run([]string{"down", "--volumes"}, stackDeps(&out, runner, containers))
+	if exitCode != 0 {
+		t.Fatalf("unexpected exit code %d: %s", exitCode, out.String())
+	}
+	if len(runner.commands) != 1 || runner.commands[0] != "docker compose -p esb-staging down --remove-orphans --volumes" {
+		t.Fatalf("unexpected commands: %v", runner.commands)
+	}
+	if !strings.Contains(out.String(), "Stack esb-staging is down") {
+		t.Fatalf("unexpected output: %s", out.String())
+	}
+}
+
+func TestResolveStackTargetDefaultsFromEnv(t *testing.T) {
+	t.Setenv("PROJECT_NAME", "")
+	t.Setenv("ENV_PREFIX", "ESB")
+	t.Setenv("ESB_PROJECT", "")
+	target := resolveStackTarget(CLI{EnvFlag: "dev"}, "", Dependencies{}, true)
+	if target.project != "esb-dev" || target.env != "dev" {
+		t.Fatalf("unexpected target: %#v", target)
+	}
+	target = resolveStackTarget(CLI{}, "custom-stack", Dependencies{}, true)
+	if target.project != "custom-stack" {
+		t.Fatalf("unexpected target: %#v", target)
+	}
+}
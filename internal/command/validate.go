@@ -0,0 +1,112 @@
+// Where: cli/internal/command/validate.go
+// What: CLI adapter for offline template validation.
+// Why: Gate template changes without building images or touching Docker.
+package command
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/poruru-code/esb-cli/internal/usecase/validate"
+)
+
+const (
+	validateFormatText  = "text"
+	validateFormatJSON  = "json"
+	validateFormatSARIF = "sarif"
+)
+
+func runValidate(cli CLI, _ Dependencies, out io.Writer) int {
+	paths := cli.Template
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+	// Same layering as deploy, minus samconfig: --parameters-file (the --env
+	// section for samconfig files), then --parameter-overrides.
+	parameters, _, err := resolveParameterInputs(DeployCmd{
+		ParameterOverrides: cli.Validate.ParameterOverrides,
+		ParametersFile:     cli.Validate.ParametersFile,
+	}, cli.EnvFlag, nil)
+	if err != nil {
+		return exitWithError(out, err)
+	}
+
+	report := validate.Report{}
+	for _, raw := range paths {
+		templatePath, err := normalizeTemplatePath(strings.TrimSpace(raw))
+		if err != nil {
+			report.Findings = append(report.Findings, validate.Finding{
+				Rule:     validate.RuleTemplateParse,
+				Severity: validate.SeverityError,
+				Template: raw,
+				Message:  err.Error(),
+			})
+			continue
+		}
+		result := validate.Template(validate.Input{TemplatePath: templatePath, Parameters: parameters})
+		report.Findings = append(report.Findings, result.Findings...)
+	}
+
+	switch cli.Validate.Format {
+	case validateFormatJSON:
+		err = writeValidateJSON(out, report)
+	case validateFormatSARIF:
+		err = writeValidateSARIF(out, report)
+	default:
+		writeValidateText(out, report)
+	}
+	if err != nil {
+		return exitWithError(out, err)
+	}
+	if report.Failed(cli.Validate.Strict) {
+		return 1
+	}
+	return 0
+}
+
+func writeValidateText(out io.Writer, report validate.Report) {
+	ui := legacyUI(out)
+	for _, finding := range report.Findings {
+		location := finding.Template
+		if finding.LogicalID != "" {
+			location += ": " + finding.LogicalID
+		}
+		ui.Warn(fmt.Sprintf("%s [%s] %s: %s", finding.Severity, finding.Rule, location, finding.Message))
+	}
+	errors := report.Count(validate.SeverityError)
+	warnings := report.Count(validate.SeverityWarning)
+	if errors == 0 && warnings == 0 {
+		ui.Success("✓ Template is valid")
+		return
+	}
+	ui.Info(fmt.Sprintf("%d error(s), %d warning(s)", errors, warnings))
+}
+
+type validateJSONReport struct {
+	Findings []validate.Finding `json:"findings"`
+	Errors   int                `json:"errors"`
+	Warnings int                `json:"warnings"`
+}
+
+func writeValidateJSON(out io.Writer, report validate.Report) error {
+	findings := report.Findings
+	if findings == nil {
+		findings = []validate.Finding{}
+	}
+	return writeIndentedJSON(out, validateJSONReport{
+		Findings: findings,
+		Errors:   report.Count(validate.SeverityError),
+		Warnings: report.Count(validate.SeverityWarning),
+	})
+}
+
+func writeIndentedJSON(out io.Writer, payload any) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(payload); err != nil {
+		return fmt.Errorf("encode json output: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,190 @@
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeValidateFixture(t *testing.T, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "functions", "hello"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	path := filepath.Join(dir, "template.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+	return path
+}
+
+const validateCleanTemplate = `
+Resources:
+  HelloFunction:
+    Type: AWS::Serverless::Function
+    Properties:
+      CodeUri: functions/hello/
+      Handler: app.handler
+      Runtime: python3.12
+`
+
+const validateBrokenTemplate = `
+Resources:
+  NightlyRule:
+    Type: AWS::Events::Rule
+    Properties: {}
+  HelloFunction:
+    Type: AWS::Serverless::Function
+    Properties:
+      CodeUri: functions/missing/
+      Handler: app.handler
+      Runtime: python3.12
+`
+
+func TestRunValidateCleanTemplate(t *testing.T) {
+	path := writeValidateFixture(t, validateCleanTemplate)
+	var out bytes.Buffer
+
+	exitCode := Run([]string{"validate", "-t", path}, Dependencies{Out: &out})
+	if exitCode != 0 {
+		t.Fatalf("expected exit code 0, got %d: %s", exitCode, out.String())
+	}
+	if !strings.Contains(out.String(), "Template is valid") {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+}
+
+func TestRunValidateTextFindings(t *testing.T) {
+	path := writeValidateFixture(t, validateBrokenTemplate)
+	var out bytes.Buffer
+
+	exitCode := Run([]string{"validate", "-t", path}, Dependencies{Out: &out})
+	if exitCode != 1 {
+		t.Fatalf("expected exit code 1, got %d", exitCode)
+	}
+	output := out.String()
+	for _, want := range []string{
+		"error [code-uri-missing] " + path + ": HelloFunction:",
+		"warning [unsupported-resource] " + path + ": NightlyRule:",
+		"1 error(s), 1 warning(s)",
+	} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected %q in output:\n%s", want, output)
+		}
+	}
+}
+
+func TestRunValidateJSON(t *testing.T) {
+	path := writeValidateFixture(t, validateBrokenTemplate)
+	var out bytes.Buffer
+
+	exitCode := Run([]string{"validate", "-t", path, "--format", "json"}, Dependencies{Out: &out})
+	if exitCode != 1 {
+		t.Fatalf("expected exit code 1, got %d", exitCode)
+	}
+	var payload validateJSONReport
+	if err := json.Unmarshal(out.Bytes(), &payload); err != nil {
+		t.Fatalf("decode json output: %v\n%s", err, out.String())
+	}
+	if payload.Errors != 1 || payload.Warnings != 1 || len(payload.Findings) != 2 {
+		t.Fatalf("unexpected json report: %+v", payload)
+	}
+}
+
+func TestRunValidateSARIF(t *testing.T) {
+	path := writeValidateFixture(t, validateBrokenTemplate)
+	var out bytes.Buffer
+
+	Run([]string{"validate", "-t", path, "--format", "sarif"}, Dependencies{Out: &out})
+
+	var payload sarifLog
+	if err := json.Unmarshal(out.Bytes(), &payload); err != nil {
+		t.Fatalf("decode sarif output: %v\n%s", err, out.String())
+	}
+	if payload.Version != sarifVersion || len(payload.Runs) != 1 {
+		t.Fatalf("unexpected sarif log: %+v", payload)
+	}
+	run := payload.Runs[0]
+	if len(run.Tool.Driver.Rules) != 2 || run.Tool.Driver.Rules[0].ID != "code-uri-missing" {
+		t.Fatalf("unexpected sarif rules: %+v", run.Tool.Driver.Rules)
+	}
+	if len(run.Results) != 2 {
+		t.Fatalf("expected 2 sarif results, got %+v", run.Results)
+	}
+	result := run.Results[1]
+	if result.Level != "error" || result.Locations[0].LogicalLocations[0].Name != "HelloFunction" {
+		t.Fatalf("unexpected sarif result: %+v", result)
+	}
+}
+
+func TestRunValidateStrictFailsOnWarnings(t *testing.T) {
+	path := writeValidateFixture(t, validateCleanTemplate+`
+  NightlyRule:
+    Type: AWS::Events::Rule
+    Properties: {}
+`)
+	var out bytes.Buffer
+
+	if exitCode := Run([]string{"validate", "-t", path}, Dependencies{Out: &out}); exitCode != 0 {
+		t.Fatalf("expected warnings to pass without --strict, got %d", exitCode)
+	}
+	if exitCode := Run([]string{"validate", "-t", path, "--strict"}, Dependencies{Out: &out}); exitCode != 1 {
+		t.Fatalf("expected --strict to fail on warnings, got %d", exitCode)
+	}
+}
+
+func TestRunValidateStrictIgnoresPermissionResources(t *testing.T) {
+	path := writeValidateFixture(t, validateCleanTemplate+`
+  HelloRole:
+    Type: AWS::IAM::Role
+    Properties: {}
+  HelloPermission:
+    Type: AWS::Lambda::Permission
+    Properties:
+      FunctionName: !Ref HelloFunction
+      Action: lambda:InvokeFunction
+      Principal: events.amazonaws.com
+`)
+	var out bytes.Buffer
+
+	if exitCode := Run([]string{"validate", "-t", path, "--strict"}, Dependencies{Out: &out}); exitCode != 0 {
+		t.Fatalf("IAM roles and Lambda permissions must not fail --strict, got %d: %s", exitCode, out.String())
+	}
+}
+
+func TestRunValidateUsesParameterFlags(t *testing.T) {
+	path := writeValidateFixture(t, `
+Parameters:
+  Stage:
+    Type: String
+Conditions:
+  IsProd: !Equals [!Ref Stage, prod]
+Resources:
+  HelloFunction:
+    Type: AWS::Serverless::Function
+    Properties:
+      CodeUri: !If [IsProd, functions/hello/, functions/missing/]
+      Handler: app.handler
+      Runtime: python3.12
+`)
+	var out bytes.Buffer
+	if exitCode := Run([]string{"validate", "-t", path, "--parameter-overrides", "Stage=prod"}, Dependencies{Out: &out}); exitCode != 0 {
+		t.Fatalf("expected --parameter-overrides to select the prod CodeUri, got %d: %s", exitCode, out.String())
+	}
+
+	paramsFile := filepath.Join(filepath.Dir(path), "params.json")
+	if err := os.WriteFile(paramsFile, []byte(`{"Stage": "dev"}`), 0o600); err != nil {
+		t.Fatalf("write parameters file: %v", err)
+	}
+	out.Reset()
+	if exitCode := Run([]string{"validate", "-t", path, "--parameters-file", paramsFile}, Dependencies{Out: &out}); exitCode != 1 {
+		t.Fatalf("expected --parameters-file to select the missing CodeUri, got %d: %s", exitCode, out.String())
+	}
+	if !strings.Contains(out.String(), "code-uri-missing") {
+		t.Fatalf("expected code-uri-missing finding, got:\n%s", out.String())
+	}
+}
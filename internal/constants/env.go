@@ -36,6 +36,9 @@ const (
 	HostSuffixTag              = "TAG"
 	HostSuffixRegistry         = "REGISTRY"
 	HostSuffixProvisionerTrace = "PROVISIONER_TRACE"
+	HostSuffixBundleVerifyKey  = "BUNDLE_VERIFY_KEY"
+	HostSuffixDeployTimeout    = "DEPLOY_TIMEOUT"
+	HostSuffixPhaseTimeouts    = "PHASE_TIMEOUTS"
 
 	// Default registry (internal service name).
 	DefaultContainerRegistry = "registry:5010"
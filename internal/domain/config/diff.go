@@ -17,10 +17,10 @@ type Snapshot struct {
 
 // Counts stores diff counters for a category.
 type Counts struct {
-	Added   int
-	Updated int
-	Removed int
-	Total   int
+	Added   int `json:"added"`
+	Updated int `json:"updated"`
+	Removed int `json:"removed"`
+	Total   int `json:"total"`
 }
 
 // Diff aggregates counts for all config sections.
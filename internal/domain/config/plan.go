@@ -0,0 +1,209 @@
+// Where: cli/internal/domain/config/plan.go
+// What: Per-entry, field-level config plan between two snapshots.
+// Why: Show exactly which functions/routes/resources change before deploying.
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// ChangeKind classifies a planned entry change.
+type ChangeKind string
+
+const (
+	ChangeAdded   ChangeKind = "added"
+	ChangeUpdated ChangeKind = "updated"
+	ChangeRemoved ChangeKind = "removed"
+)
+
+// FieldChange describes a single differing field inside an updated entry.
+// Before is nil for added fields and After is nil for removed fields.
+type FieldChange struct {
+	Path   string `json:"path"`
+	Before any    `json:"before,omitempty"`
+	After  any    `json:"after,omitempty"`
+}
+
+// EntryChange describes a planned change to one named config entry.
+type EntryChange struct {
+	Section string        `json:"section"`
+	Name    string        `json:"name"`
+	Kind    ChangeKind    `json:"kind"`
+	Fields  []FieldChange `json:"fields,omitempty"`
+}
+
+// Plan lists entry changes ordered by section and name.
+type Plan struct {
+	Entries []EntryChange `json:"entries"`
+}
+
+// Empty reports whether the plan contains no changes.
+func (p Plan) Empty() bool {
+	return len(p.Entries) == 0
+}
+
+// Counts returns the number of added, updated and removed entries.
+func (p Plan) Counts() (added, updated, removed int) {
+	for _, entry := range p.Entries {
+		switch entry.Kind {
+		case ChangeAdded:
+			added++
+		case ChangeUpdated:
+			updated++
+		case ChangeRemoved:
+			removed++
+		}
+	}
+	return added, updated, removed
+}
+
+// FormatPlan renders the plan as unified diff style lines.
+func FormatPlan(plan Plan) []string {
+	var lines []string
+	for _, entry := range plan.Entries {
+		label := entry.Section + "/" + entry.Name
+		switch entry.Kind {
+		case ChangeAdded:
+			lines = append(lines, "+ "+label)
+		case ChangeRemoved:
+			lines = append(lines, "- "+label)
+		default:
+			lines = append(lines, "~ "+label)
+			for _, field := range entry.Fields {
+				if field.Before != nil {
+					lines = append(lines, fmt.Sprintf("    - %s: %s", field.Path, formatPlanValue(field.Before)))
+				}
+				if field.After != nil {
+					lines = append(lines, fmt.Sprintf("    + %s: %s", field.Path, formatPlanValue(field.After)))
+				}
+			}
+		}
+	}
+	return lines
+}
+
+// PlanSnapshots computes entry and field level changes from before to after.
+func PlanSnapshots(before, after Snapshot) Plan {
+	plan := Plan{Entries: []EntryChange{}}
+	plan.Entries = append(plan.Entries, planSection("functions", before.Functions, after.Functions)...)
+	plan.Entries = append(plan.Entries, planSection("routes", before.Routes, after.Routes)...)
+	for _, key := range []string{"dynamodb", "s3", "layers"} {
+		section := "resources." + key
+		plan.Entries = append(plan.Entries, planSection(section, resourceMap(before, key), resourceMap(after, key))...)
+	}
+	return plan
+}
+
+func planSection(section string, before, after map[string]any) []EntryChange {
+	names := map[string]struct{}{}
+	for name := range before {
+		names[name] = struct{}{}
+	}
+	for name := range after {
+		names[name] = struct{}{}
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	var changes []EntryChange
+	for _, name := range sorted {
+		prev, hadPrev := before[name]
+		next, hasNext := after[name]
+		switch {
+		case !hadPrev:
+			changes = append(changes, EntryChange{Section: section, Name: name, Kind: ChangeAdded})
+		case !hasNext:
+			changes = append(changes, EntryChange{Section: section, Name: name, Kind: ChangeRemoved})
+		case !reflect.DeepEqual(prev, next):
+			changes = append(changes, EntryChange{
+				Section: section,
+				Name:    name,
+				Kind:    ChangeUpdated,
+				Fields:  diffFields("", prev, next),
+			})
+		}
+	}
+	return changes
+}
+
+func diffFields(path string, before, after any) []FieldChange {
+	if reflect.DeepEqual(before, after) {
+		return nil
+	}
+	beforeMap, beforeIsMap := asStringMap(before)
+	afterMap, afterIsMap := asStringMap(after)
+	if beforeIsMap && afterIsMap {
+		keys := map[string]struct{}{}
+		for key := range beforeMap {
+			keys[key] = struct{}{}
+		}
+		for key := range afterMap {
+			keys[key] = struct{}{}
+		}
+		sorted := make([]string, 0, len(keys))
+		for key := range keys {
+			sorted = append(sorted, key)
+		}
+		sort.Strings(sorted)
+		var fields []FieldChange
+		for _, key := range sorted {
+			fields = append(fields, diffFields(joinFieldPath(path, key), beforeMap[key], afterMap[key])...)
+		}
+		return fields
+	}
+	beforeList, beforeIsList := before.([]any)
+	afterList, afterIsList := after.([]any)
+	if beforeIsList && afterIsList {
+		var fields []FieldChange
+		for i := 0; i < len(beforeList) || i < len(afterList); i++ {
+			var prev, next any
+			if i < len(beforeList) {
+				prev = beforeList[i]
+			}
+			if i < len(afterList) {
+				next = afterList[i]
+			}
+			fields = append(fields, diffFields(fmt.Sprintf("%s[%d]", path, i), prev, next)...)
+		}
+		return fields
+	}
+	return []FieldChange{{Path: path, Before: before, After: after}}
+}
+
+func asStringMap(value any) (map[string]any, bool) {
+	switch typed := value.(type) {
+	case map[string]any:
+		return typed, true
+	case map[any]any:
+		out := make(map[string]any, len(typed))
+		for key, item := range typed {
+			out[fmt.Sprint(key)] = item
+		}
+		return out, true
+	default:
+		return nil, false
+	}
+}
+
+func joinFieldPath(base, key string) string {
+	if base == "" {
+		return key
+	}
+	return base + "." + key
+}
+
+func formatPlanValue(value any) string {
+	if text, ok := value.(string); ok {
+		return text
+	}
+	if data, err := json.Marshal(value); err == nil {
+		return string(data)
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPlanSnapshots(t *testing.T) {
+	before := Snapshot{
+		Functions: map[string]any{
+			"same":    map[string]any{"handler": "a.handler"},
+			"updated": map[string]any{"timeout": 30, "environment": map[string]any{"LOG_LEVEL": "info", "OLD": "x"}},
+			"removed": map[string]any{"handler": "r.handler"},
+		},
+		Routes: map[string]any{
+			"GET /items": map[string]any{"function": "list", "events": []any{"a"}},
+		},
+		Resources: map[string]map[string]any{
+			"s3": {"assets": map[string]any{"BucketName": "assets"}},
+		},
+	}
+	after := Snapshot{
+		Functions: map[string]any{
+			"same":    map[string]any{"handler": "a.handler"},
+			"updated": map[string]any{"timeout": 60, "environment": map[string]any{"LOG_LEVEL": "debug"}},
+			"added":   map[string]any{"handler": "n.handler"},
+		},
+		Routes: map[string]any{
+			"GET /items": map[string]any{"function": "list", "events": []any{"a", "b"}},
+		},
+		Resources: map[string]map[string]any{
+			"dynamodb": {"orders": map[string]any{"TableName": "orders"}},
+		},
+	}
+
+	plan := PlanSnapshots(before, after)
+
+	expected := []EntryChange{
+		{Section: "functions", Name: "added", Kind: ChangeAdded},
+		{Section: "functions", Name: "removed", Kind: ChangeRemoved},
+		{Section: "functions", Name: "updated", Kind: ChangeUpdated, Fields: []FieldChange{
+			{Path: "environment.LOG_LEVEL", Before: "info", After: "debug"},
+			{Path: "environment.OLD", Before: "x"},
+			{Path: "timeout", Before: 30, After: 60},
+		}},
+		{Section: "routes", Name: "GET /items", Kind: ChangeUpdated, Fields: []FieldChange{
+			{Path: "events[1]", After: "b"},
+		}},
+		{Section: "resources.dynamodb", Name: "orders", Kind: ChangeAdded},
+		{Section: "resources.s3", Name: "assets", Kind: ChangeRemoved},
+	}
+	if !reflect.DeepEqual(plan.Entries, expected) {
+		t.Fatalf("unexpected plan:\n got: %#v\nwant: %#v", plan.Entries, expected)
+	}
+}
+
+func TestPlanSnapshotsEmpty(t *testing.T) {
+	snapshot := Snapshot{Functions: map[string]any{"a": map[string]any{"handler": "a"}}}
+	if plan := PlanSnapshots(snapshot, snapshot); !plan.Empty() {
+		t.Fatalf("expected empty plan, got %#v", plan.Entries)
+	}
+}
+
+func TestFormatPlan(t *testing.T) {
+	plan := Plan{Entries: []EntryChange{
+		{Section: "functions", Name: "added", Kind: ChangeAdded},
+		{Section: "functions", Name: "updated", Kind: ChangeUpdated, Fields: []FieldChange{
+			{Path: "environment.OLD", Before: "x"},
+			{Path: "timeout", Before: 30, After: 60},
+			{Path: "events[1]", After: map[string]any{"type": "sqs"}},
+		}},
+		{Section: "resources.s3", Name: "assets", Kind: ChangeRemoved},
+	}}
+
+	expected := []string{
+		"+ functions/added",
+		"~ functions/updated",
+		"    - environment.OLD: x",
+		"    - timeout: 30",
+		"    + timeout: 60",
+		`    + events[1]: {"type":"sqs"}`,
+		"- resources.s3/assets",
+	}
+	if lines := FormatPlan(plan); !reflect.DeepEqual(lines, expected) {
+		t.Fatalf("unexpected lines:\n got: %q\nwant: %q", lines, expected)
+	}
+	if added, updated, removed := plan.Counts(); added != 1 || updated != 1 || removed != 1 {
+		t.Fatalf("unexpected counts: %d/%d/%d", added, updated, removed)
+	}
+}
@@ -3,10 +3,14 @@
 // Why: Share a single interface definition across command/usecase/infra without layer leaks.
 package deployport
 
+import "context"
+
 // ComposeProvisioner defines compose-related operational behavior consumed by deploy flows.
+// Cancelling ctx stops the underlying docker compose commands.
 type ComposeProvisioner interface {
-	CheckServicesStatus(composeProject, mode string)
+	CheckServicesStatus(ctx context.Context, composeProject, mode string)
 	RunProvisioner(
+		ctx context.Context,
 		composeProject string,
 		mode string,
 		noDeps bool,
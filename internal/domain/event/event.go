@@ -0,0 +1,237 @@
+// Where: cli/internal/domain/event/event.go
+// What: Sample Lambda event payload generators.
+// Why: Craft invoke payloads for common trigger shapes without hand-written JSON.
+package event
+
+import (
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Supported event kinds.
+const (
+	KindAPI      = "api"
+	KindHTTPAPI  = "httpapi"
+	KindSchedule = "schedule"
+	KindSQS      = "sqs"
+)
+
+const (
+	defaultAccountID = "123456789012"
+	defaultRegion    = "ap-northeast-1"
+	defaultQueue     = "queue"
+	defaultRule      = "schedule"
+)
+
+// Options customizes generated payloads. Zero values fall back to defaults.
+type Options struct {
+	Method string
+	Path   string
+	Body   string
+	// Name is the queue name (sqs) or rule name (schedule).
+	Name   string
+	Region string
+	ID     string
+	Now    time.Time
+}
+
+// Kinds returns the supported event kinds in display order.
+func Kinds() []string {
+	return []string{KindAPI, KindHTTPAPI, KindSchedule, KindSQS}
+}
+
+// Generate builds a sample event payload of the given kind.
+func Generate(kind string, opts Options) (map[string]any, error) {
+	opts = withDefaults(opts)
+	switch strings.ToLower(strings.TrimSpace(kind)) {
+	case KindAPI:
+		return apiEvent(opts), nil
+	case KindHTTPAPI:
+		return httpAPIEvent(opts), nil
+	case KindSchedule:
+		return scheduleEvent(opts), nil
+	case KindSQS:
+		return sqsEvent(opts), nil
+	default:
+		return nil, fmt.Errorf("unsupported event kind %q (supported: %s)", kind, strings.Join(Kinds(), ", "))
+	}
+}
+
+func withDefaults(opts Options) Options {
+	if strings.TrimSpace(opts.Method) == "" {
+		opts.Method = "GET"
+	}
+	opts.Method = strings.ToUpper(strings.TrimSpace(opts.Method))
+	if strings.TrimSpace(opts.Path) == "" {
+		opts.Path = "/"
+	}
+	if strings.TrimSpace(opts.Region) == "" {
+		opts.Region = defaultRegion
+	}
+	if strings.TrimSpace(opts.ID) == "" {
+		opts.ID = newID()
+	}
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	opts.Now = opts.Now.UTC()
+	return opts
+}
+
+func apiEvent(opts Options) map[string]any {
+	path, query := splitPath(opts.Path)
+	var body any
+	if opts.Body != "" {
+		body = opts.Body
+	}
+	var queryParams any
+	if len(query) > 0 {
+		queryParams = query
+	}
+	return map[string]any{
+		"resource":              path,
+		"path":                  path,
+		"httpMethod":            opts.Method,
+		"headers":               map[string]any{"Content-Type": "application/json"},
+		"queryStringParameters": queryParams,
+		"pathParameters":        nil,
+		"body":                  body,
+		"isBase64Encoded":       false,
+		"requestContext": map[string]any{
+			"accountId":        defaultAccountID,
+			"resourcePath":     path,
+			"httpMethod":       opts.Method,
+			"path":             path,
+			"stage":            "local",
+			"requestId":        opts.ID,
+			"requestTimeEpoch": opts.Now.UnixMilli(),
+			"identity":         map[string]any{"sourceIp": "127.0.0.1"},
+		},
+	}
+}
+
+func httpAPIEvent(opts Options) map[string]any {
+	path, query := splitPath(opts.Path)
+	event := map[string]any{
+		"version":         "2.0",
+		"routeKey":        opts.Method + " " + path,
+		"rawPath":         path,
+		"rawQueryString":  encodeQuery(query),
+		"headers":         map[string]any{"content-type": "application/json"},
+		"isBase64Encoded": false,
+		"requestContext": map[string]any{
+			"accountId": defaultAccountID,
+			"stage":     "$default",
+			"requestId": opts.ID,
+			"timeEpoch": opts.Now.UnixMilli(),
+			"http": map[string]any{
+				"method":   opts.Method,
+				"path":     path,
+				"protocol": "HTTP/1.1",
+				"sourceIp": "127.0.0.1",
+			},
+		},
+	}
+	if len(query) > 0 {
+		event["queryStringParameters"] = query
+	}
+	if opts.Body != "" {
+		event["body"] = opts.Body
+	}
+	return event
+}
+
+func scheduleEvent(opts Options) map[string]any {
+	rule := strings.TrimSpace(opts.Name)
+	if rule == "" {
+		rule = defaultRule
+	}
+	return map[string]any{
+		"version":     "0",
+		"id":          opts.ID,
+		"detail-type": "Scheduled Event",
+		"source":      "aws.events",
+		"account":     defaultAccountID,
+		"time":        opts.Now.Format(time.RFC3339),
+		"region":      opts.Region,
+		"resources": []any{
+			fmt.Sprintf("arn:aws:events:%s:%s:rule/%s", opts.Region, defaultAccountID, rule),
+		},
+		"detail": map[string]any{},
+	}
+}
+
+func sqsEvent(opts Options) map[string]any {
+	queue := strings.TrimSpace(opts.Name)
+	if queue == "" {
+		queue = defaultQueue
+	}
+	sum := md5.Sum([]byte(opts.Body))
+	sentAt := fmt.Sprintf("%d", opts.Now.UnixMilli())
+	return map[string]any{
+		"Records": []any{
+			map[string]any{
+				"messageId":     opts.ID,
+				"receiptHandle": "local-" + opts.ID,
+				"body":          opts.Body,
+				"attributes": map[string]any{
+					"ApproximateReceiveCount":          "1",
+					"SentTimestamp":                    sentAt,
+					"SenderId":                         defaultAccountID,
+					"ApproximateFirstReceiveTimestamp": sentAt,
+				},
+				"messageAttributes": map[string]any{},
+				"md5OfBody":         hex.EncodeToString(sum[:]),
+				"eventSource":       "aws:sqs",
+				"eventSourceARN":    fmt.Sprintf("arn:aws:sqs:%s:%s:%s", opts.Region, defaultAccountID, queue),
+				"awsRegion":         opts.Region,
+			},
+		},
+	}
+}
+
+func splitPath(raw string) (string, map[string]any) {
+	path, rawQuery, _ := strings.Cut(raw, "?")
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	values, err := url.ParseQuery(rawQuery)
+	if err != nil || len(values) == 0 {
+		return path, nil
+	}
+	query := make(map[string]any, len(values))
+	for key, items := range values {
+		query[key] = strings.Join(items, ",")
+	}
+	return path, query
+}
+
+func encodeQuery(query map[string]any) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		parts = append(parts, url.QueryEscape(key)+"="+url.QueryEscape(fmt.Sprint(query[key])))
+	}
+	return strings.Join(parts, "&")
+}
+
+func newID() string {
+	var buf [16]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return "00000000-0000-0000-0000-000000000000"
+	}
+	buf[6] = (buf[6] & 0x0f) | 0x40
+	buf[8] = (buf[8] & 0x3f) | 0x80
+	encoded := hex.EncodeToString(buf[:])
+	return encoded[0:8] + "-" + encoded[8:12] + "-" + encoded[12:16] + "-" + encoded[16:20] + "-" + encoded[20:]
+}
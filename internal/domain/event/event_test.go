@@ -0,0 +1,70 @@
+package event
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGenerateAPIEventSplitsQuery(t *testing.T) {
+	got, err := Generate(KindAPI, Options{Method: "post", Path: "/items?limit=5", Body: `{"a":1}`, ID: "req-1"})
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	if got["httpMethod"] != "POST" || got["path"] != "/items" || got["body"] != `{"a":1}` {
+		t.Fatalf("unexpected api event: %#v", got)
+	}
+	query, _ := got["queryStringParameters"].(map[string]any)
+	if query["limit"] != "5" {
+		t.Fatalf("expected query params, got %#v", got["queryStringParameters"])
+	}
+}
+
+func TestGenerateHTTPAPIEvent(t *testing.T) {
+	got, err := Generate(KindHTTPAPI, Options{Path: "/items?b=2&a=1"})
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	if got["routeKey"] != "GET /items" || got["rawQueryString"] != "a=1&b=2" {
+		t.Fatalf("unexpected httpapi event: %#v", got)
+	}
+}
+
+func TestGenerateScheduleEvent(t *testing.T) {
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	got, err := Generate(KindSchedule, Options{Name: "nightly", Region: "us-east-1", Now: now})
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	if got["time"] != "2026-01-02T03:04:05Z" || got["detail-type"] != "Scheduled Event" {
+		t.Fatalf("unexpected schedule event: %#v", got)
+	}
+	resources, _ := got["resources"].([]any)
+	if len(resources) != 1 || resources[0] != "arn:aws:events:us-east-1:123456789012:rule/nightly" {
+		t.Fatalf("unexpected resources: %#v", got["resources"])
+	}
+}
+
+func TestGenerateSQSEvent(t *testing.T) {
+	got, err := Generate(KindSQS, Options{Body: "hello", Name: "orders"})
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	records, _ := got["Records"].([]any)
+	if len(records) != 1 {
+		t.Fatalf("expected one record, got %#v", got)
+	}
+	record := records[0].(map[string]any)
+	if record["body"] != "hello" || record["md5OfBody"] != "5d41402abc4b2a76b9719d911017c592" {
+		t.Fatalf("unexpected record: %#v", record)
+	}
+	if !strings.HasSuffix(record["eventSourceARN"].(string), ":orders") {
+		t.Fatalf("unexpected queue arn: %v", record["eventSourceARN"])
+	}
+}
+
+func TestGenerateRejectsUnknownKind(t *testing.T) {
+	if _, err := Generate("kinesis", Options{}); err == nil {
+		t.Fatal("expected error for unsupported kind")
+	}
+}
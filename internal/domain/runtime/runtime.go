@@ -14,6 +14,7 @@ type Kind string
 const (
 	KindPython Kind = "python"
 	KindJava   Kind = "java"
+	KindNode   Kind = "nodejs"
 )
 
 const defaultPythonRuntime = "python3.12"
@@ -26,6 +27,9 @@ type Profile struct {
 	NestPythonLayers  bool
 	PythonVersion     string
 	JavaBaseImage     string
+	UsesNpm           bool
+	NestNodeLayers    bool
+	NodeBaseImage     string
 }
 
 func (p Profile) CodeUriTargetDir(sourcePath string) string {
@@ -72,5 +76,20 @@ func Resolve(runtime string) (Profile, error) {
 		}
 	}
 
+	if strings.HasPrefix(normalized, "nodejs") {
+		switch normalized {
+		case "nodejs20.x", "nodejs22.x":
+			major := strings.TrimSuffix(strings.TrimPrefix(normalized, "nodejs"), ".x")
+			return Profile{
+				Name:           normalized,
+				Kind:           KindNode,
+				UsesNpm:        true,
+				NestNodeLayers: true,
+				NodeBaseImage:  "public.ecr.aws/lambda/nodejs:" + major,
+			}, nil
+		default:
+			return Profile{}, fmt.Errorf("unsupported nodejs runtime: %s", runtime)
+		}
+	}
 	return Profile{}, fmt.Errorf("unsupported runtime: %s", runtime)
 }
@@ -43,6 +43,31 @@ func TestResolveJava21(t *testing.T) {
 	}
 }
 
+func TestResolveNodejs(t *testing.T) {
+	cases := map[string]string{
+		"nodejs20.x": "public.ecr.aws/lambda/nodejs:20",
+		"nodejs22.x": "public.ecr.aws/lambda/nodejs:22",
+	}
+	for name, base := range cases {
+		profile, err := Resolve(name)
+		if err != nil {
+			t.Fatalf("expected no error for %s, got %v", name, err)
+		}
+		if profile.Kind != KindNode {
+			t.Fatalf("expected nodejs kind, got %s", profile.Kind)
+		}
+		if profile.NodeBaseImage != base {
+			t.Fatalf("expected node base image %s, got %s", base, profile.NodeBaseImage)
+		}
+		if !profile.UsesNpm || !profile.NestNodeLayers {
+			t.Fatalf("expected npm install and node layer nesting for %s", name)
+		}
+		if profile.UsesSitecustomize || profile.UsesPip {
+			t.Fatalf("did not expect python hooks for %s", name)
+		}
+	}
+}
+
 func TestResolveUnknownRuntime(t *testing.T) {
 	if _, err := Resolve("nodejs18.x"); err == nil {
 		t.Fatalf("expected error for unknown runtime")
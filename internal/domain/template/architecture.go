@@ -0,0 +1,120 @@
+// Where: cli/internal/domain/template/architecture.go
+// What: Lambda architecture to container platform mapping and validation.
+// Why: Build images for the declared function architecture instead of the host.
+package template
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Supported Lambda architectures.
+const (
+	ArchitectureX86_64 = "x86_64"
+	ArchitectureARM64  = "arm64"
+)
+
+var architecturePlatforms = map[string]string{
+	ArchitectureX86_64: "linux/amd64",
+	ArchitectureARM64:  "linux/arm64",
+}
+
+// PlatformForArchitecture maps a Lambda architecture to a buildx platform.
+func PlatformForArchitecture(arch string) (string, error) {
+	platform, ok := architecturePlatforms[strings.ToLower(strings.TrimSpace(arch))]
+	if !ok {
+		return "", fmt.Errorf("unsupported architecture %q (expected %s or %s)", arch, ArchitectureX86_64, ArchitectureARM64)
+	}
+	return platform, nil
+}
+
+// FunctionPlatforms maps function names to buildx platforms. It returns nil
+// when no function declares Architectures so images keep the host platform.
+// Otherwise functions without Architectures use the Lambda default (x86_64),
+// matching how AWS would run them.
+func FunctionPlatforms(functions []FunctionSpec) (map[string]string, error) {
+	declared := false
+	for _, fn := range functions {
+		if len(fn.Architectures) > 0 {
+			declared = true
+			break
+		}
+	}
+	if !declared {
+		return nil, nil
+	}
+	platforms := make(map[string]string, len(functions))
+	for _, fn := range functions {
+		arch := ArchitectureX86_64
+		if len(fn.Architectures) > 0 {
+			arch = fn.Architectures[0]
+		}
+		platform, err := PlatformForArchitecture(arch)
+		if err != nil {
+			return nil, fmt.Errorf("function %s: %w", fn.Name, err)
+		}
+		platforms[fn.Name] = platform
+	}
+	return platforms, nil
+}
+
+// PlatformList returns the sorted, de-duplicated platforms in platforms.
+func PlatformList(platforms map[string]string) []string {
+	if len(platforms) == 0 {
+		return nil
+	}
+	seen := map[string]struct{}{}
+	list := make([]string, 0, len(platforms))
+	for _, platform := range platforms {
+		if _, ok := seen[platform]; ok {
+			continue
+		}
+		seen[platform] = struct{}{}
+		list = append(list, platform)
+	}
+	sort.Strings(list)
+	return list
+}
+
+// ValidateArchitectures checks that each function declares at most one
+// supported architecture and that its layers list that architecture in
+// CompatibleArchitectures (when the layer declares any).
+func ValidateArchitectures(functions []FunctionSpec) error {
+	for _, fn := range functions {
+		if len(fn.Architectures) > 1 {
+			return fmt.Errorf("function %s: Architectures must contain exactly one value, got %v", fn.Name, fn.Architectures)
+		}
+		if len(fn.Architectures) == 0 {
+			continue
+		}
+		if _, err := PlatformForArchitecture(fn.Architectures[0]); err != nil {
+			return fmt.Errorf("function %s: %w", fn.Name, err)
+		}
+		arch := strings.ToLower(strings.TrimSpace(fn.Architectures[0]))
+		for _, layer := range fn.Layers {
+			if len(layer.CompatibleArchitectures) == 0 {
+				continue
+			}
+			if !containsArchitecture(layer.CompatibleArchitectures, arch) {
+				return fmt.Errorf(
+					"function %s (%s) is not compatible with layer %s (CompatibleArchitectures: %s)",
+					fn.Name,
+					arch,
+					layer.Name,
+					strings.Join(layer.CompatibleArchitectures, ", "),
+				)
+			}
+		}
+	}
+	return nil
+}
+
+func containsArchitecture(values []string, arch string) bool {
+	for _, value := range values {
+		if strings.EqualFold(strings.TrimSpace(value), arch) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+// Where: cli/internal/domain/template/architecture_test.go
+// What: Tests for architecture-to-platform mapping and layer compatibility.
+// Why: Keep mixed arm64/x86_64 templates building for the declared platform.
+package template
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/poruru-code/esb-cli/internal/domain/manifest"
+)
+
+func TestFunctionPlatformsMapsArchitectures(t *testing.T) {
+	platforms, err := FunctionPlatforms([]FunctionSpec{
+		{Name: "a", Architectures: []string{"arm64"}},
+		{Name: "b"},
+		{Name: "c", Architectures: []string{"ARM64"}},
+	})
+	if err != nil {
+		t.Fatalf("FunctionPlatforms: %v", err)
+	}
+	want := map[string]string{"a": "linux/arm64", "b": "linux/amd64", "c": "linux/arm64"}
+	if !reflect.DeepEqual(platforms, want) {
+		t.Fatalf("unexpected platforms: %v", platforms)
+	}
+	if got := PlatformList(platforms); !reflect.DeepEqual(got, []string{"linux/amd64", "linux/arm64"}) {
+		t.Fatalf("unexpected platform list: %v", got)
+	}
+
+	hostOnly, err := FunctionPlatforms([]FunctionSpec{{Name: "a"}, {Name: "b"}})
+	if err != nil || hostOnly != nil {
+		t.Fatalf("expected host platform when no architecture is declared, got %v (%v)", hostOnly, err)
+	}
+	if _, err := PlatformForArchitecture("riscv64"); err == nil {
+		t.Fatalf("expected error for unsupported architecture")
+	}
+}
+
+func TestValidateArchitecturesChecksLayerCompatibility(t *testing.T) {
+	layer := manifest.LayerSpec{Name: "common", CompatibleArchitectures: []string{"x86_64"}}
+	ok := []FunctionSpec{
+		{Name: "x86", Architectures: []string{"x86_64"}, Layers: []manifest.LayerSpec{layer}},
+		{Name: "host", Layers: []manifest.LayerSpec{layer}},
+		{Name: "any", Architectures: []string{"arm64"}, Layers: []manifest.LayerSpec{{Name: "shared"}}},
+	}
+	if err := ValidateArchitectures(ok); err != nil {
+		t.Fatalf("ValidateArchitectures: %v", err)
+	}
+
+	err := ValidateArchitectures([]FunctionSpec{
+		{Name: "arm", Architectures: []string{"arm64"}, Layers: []manifest.LayerSpec{layer}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "not compatible with layer common") {
+		t.Fatalf("expected layer compatibility error, got %v", err)
+	}
+
+	err = ValidateArchitectures([]FunctionSpec{
+		{Name: "both", Architectures: []string{"arm64", "x86_64"}},
+	})
+	if err == nil || !strings.Contains(err.Error(), "exactly one value") {
+		t.Fatalf("expected single-architecture error, got %v", err)
+	}
+}
@@ -0,0 +1,130 @@
+// Where: cli/internal/domain/template/dockerfile_template.go
+// What: Runtime Dockerfile template overrides and required-field validation.
+// Why: Let repositories customize function images without dropping ESB runtime hooks.
+package template
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/Masterminds/sprig/v3"
+	"github.com/poruru-code/esb-cli/internal/domain/runtime"
+)
+
+// DockerfileTemplate is a Dockerfile template supplied by the target repository.
+type DockerfileTemplate struct {
+	// Source identifies the template in errors (usually its file path).
+	Source string
+	// Text is the raw text/template content.
+	Text string
+}
+
+// Empty reports whether no override is configured.
+func (t DockerfileTemplate) Empty() bool {
+	return strings.TrimSpace(t.Text) == ""
+}
+
+var commonDockerfileFields = []string{"BaseImage", "Layers", "CodeURI", "Handler"}
+
+var runtimeDockerfileFields = map[runtime.Kind][]string{
+	runtime.KindPython: {"SitecustomizeSource"},
+	runtime.KindNode:   {"NodePreloadSource"},
+	runtime.KindJava:   {"JavaWrapperSource", "JavaAgentSource", "OriginalHandler"},
+}
+
+// RequiredDockerfileFields lists the template fields an override must use for
+// the runtime kind. Dropping them would lose the base image, layers, code or
+// the ESB runtime hooks.
+func RequiredDockerfileFields(kind runtime.Kind) []string {
+	fields := append([]string{}, commonDockerfileFields...)
+	fields = append(fields, runtimeDockerfileFields[kind]...)
+	sort.Strings(fields)
+	return fields
+}
+
+// ParseDockerfileTemplate parses an override and checks that it references
+// every required field for the runtime kind.
+func ParseDockerfileTemplate(kind runtime.Kind, override DockerfileTemplate) (*template.Template, error) {
+	tmpl, err := template.New("dockerfile.tmpl").Funcs(sprig.TxtFuncMap()).Parse(override.Text)
+	if err != nil {
+		return nil, fmt.Errorf("parse dockerfile template %s: %w", override.Source, err)
+	}
+	used := map[string]struct{}{}
+	for _, tree := range tmpl.Templates() {
+		if tree.Tree != nil && tree.Root != nil {
+			collectTemplateFields(tree.Root, true, used)
+		}
+	}
+	var missing []string
+	for _, field := range RequiredDockerfileFields(kind) {
+		if _, ok := used[field]; !ok {
+			missing = append(missing, "."+field)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf(
+			"dockerfile template %s for %s runtime must use %s",
+			override.Source,
+			kind,
+			strings.Join(missing, ", "),
+		)
+	}
+	return tmpl, nil
+}
+
+// collectTemplateFields records top-level data fields referenced by node.
+// Fields inside range/with bodies refer to a different dot and are skipped
+// unless accessed through $.
+func collectTemplateFields(node parse.Node, rootDot bool, used map[string]struct{}) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			collectTemplateFields(child, rootDot, used)
+		}
+	case *parse.ActionNode:
+		collectTemplateFields(n.Pipe, rootDot, used)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			collectTemplateFields(cmd, rootDot, used)
+		}
+	case *parse.CommandNode:
+		for _, arg := range n.Args {
+			collectTemplateFields(arg, rootDot, used)
+		}
+	case *parse.FieldNode:
+		if rootDot && len(n.Ident) > 0 {
+			used[n.Ident[0]] = struct{}{}
+		}
+	case *parse.ChainNode:
+		collectTemplateFields(n.Node, rootDot, used)
+	case *parse.VariableNode:
+		if len(n.Ident) > 1 && n.Ident[0] == "$" {
+			used[n.Ident[1]] = struct{}{}
+		}
+	case *parse.IfNode:
+		collectBranchFields(&n.BranchNode, rootDot, rootDot, used)
+	case *parse.RangeNode:
+		collectBranchFields(&n.BranchNode, rootDot, false, used)
+	case *parse.WithNode:
+		collectBranchFields(&n.BranchNode, rootDot, false, used)
+	case *parse.TemplateNode:
+		collectTemplateFields(n.Pipe, rootDot, used)
+	}
+}
+
+func collectBranchFields(n *parse.BranchNode, rootDot, bodyRootDot bool, used map[string]struct{}) {
+	collectTemplateFields(n.Pipe, rootDot, used)
+	collectTemplateFields(n.List, bodyRootDot, used)
+	if n.ElseList != nil {
+		collectTemplateFields(n.ElseList, rootDot, used)
+	}
+}
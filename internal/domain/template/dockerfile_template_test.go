@@ -0,0 +1,76 @@
+// Where: cli/internal/domain/template/dockerfile_template_test.go
+// What: Tests for repository Dockerfile template overrides.
+// Why: Ensure overrides render and cannot silently drop required fields.
+package template
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/poruru-code/esb-cli/internal/domain/manifest"
+	"github.com/poruru-code/esb-cli/internal/domain/runtime"
+)
+
+const pythonOverrideTemplate = `FROM {{ .BaseImage }}
+RUN dnf install -y ca-certificates
+COPY {{ .SitecustomizeSource }} /opt/python/sitecustomize.py
+{{- range .Layers }}
+COPY {{ .ContentURI }}/ /opt/
+{{- end }}
+{{- if not .ImageWrapper }}
+COPY {{ .CodeURI }} ${LAMBDA_TASK_ROOT}/
+CMD [ "{{ $.Handler }}" ]
+{{- end }}
+`
+
+func TestRenderDockerfileUsesTemplateOverride(t *testing.T) {
+	fn := FunctionSpec{
+		Name:    "lambda-hello",
+		CodeURI: "functions/hello/",
+		Handler: "app.handler",
+		Runtime: "python3.12",
+		Layers:  []manifest.LayerSpec{{Name: "common", ContentURI: "functions/lambda-hello/layers/common"}},
+	}
+	content, err := RenderDockerfile(fn, DockerConfig{
+		Template: DockerfileTemplate{Source: ".esb/runtime-templates/python/dockerfile.tmpl", Text: pythonOverrideTemplate},
+	}, "", "latest")
+	if err != nil {
+		t.Fatalf("RenderDockerfile: %v", err)
+	}
+	for _, want := range []string{
+		"RUN dnf install -y ca-certificates",
+		"COPY " + DefaultSitecustomizeSource,
+		"COPY functions/lambda-hello/layers/common/ /opt/",
+		`CMD [ "app.handler" ]`,
+	} {
+		if !strings.Contains(content, want) {
+			t.Fatalf("expected %q in dockerfile, got:\n%s", want, content)
+		}
+	}
+}
+
+func TestParseDockerfileTemplateRequiresRuntimeFields(t *testing.T) {
+	withoutHooks := strings.Replace(pythonOverrideTemplate, "COPY {{ .SitecustomizeSource }} /opt/python/sitecustomize.py\n", "", 1)
+	_, err := ParseDockerfileTemplate(runtime.KindPython, DockerfileTemplate{Source: "custom.tmpl", Text: withoutHooks})
+	if err == nil || !strings.Contains(err.Error(), "custom.tmpl for python runtime must use .SitecustomizeSource") {
+		t.Fatalf("expected missing field error, got %v", err)
+	}
+
+	// .Name inside range refers to the layer, not the function data.
+	layerOnly := "FROM {{ .BaseImage }}\n{{ range .Layers }}{{ .CodeURI }}{{ .Handler }}{{ end }}"
+	_, err = ParseDockerfileTemplate(runtime.KindNode, DockerfileTemplate{Source: "node.tmpl", Text: layerOnly})
+	if err == nil || !strings.Contains(err.Error(), ".CodeURI, .Handler, .NodePreloadSource") {
+		t.Fatalf("expected fields inside range to be ignored, got %v", err)
+	}
+
+	if _, err := ParseDockerfileTemplate(runtime.KindPython, DockerfileTemplate{Source: "bad.tmpl", Text: "{{ .BaseImage"}); err == nil {
+		t.Fatalf("expected parse error")
+	}
+}
+
+func TestRequiredDockerfileFieldsPerRuntime(t *testing.T) {
+	java := strings.Join(RequiredDockerfileFields(runtime.KindJava), ",")
+	if java != "BaseImage,CodeURI,Handler,JavaAgentSource,JavaWrapperSource,Layers,OriginalHandler" {
+		t.Fatalf("unexpected java fields: %s", java)
+	}
+}
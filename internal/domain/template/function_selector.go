@@ -0,0 +1,73 @@
+// Where: cli/internal/domain/template/function_selector.go
+// What: Function name selection by exact name or glob pattern.
+// Why: Share --function matching between staging, image builds and input checks.
+package template
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// FunctionSelector matches function names against exact names or
+// path.Match-style globs (e.g. "api-*"). An empty selector matches nothing;
+// callers treat it as "all functions".
+type FunctionSelector []string
+
+// NewFunctionSelector trims patterns, drops empties and rejects malformed
+// globs. It returns nil when no pattern remains.
+func NewFunctionSelector(patterns []string) (FunctionSelector, error) {
+	var selector FunctionSelector
+	for _, pattern := range patterns {
+		trimmed := strings.TrimSpace(pattern)
+		if trimmed == "" {
+			continue
+		}
+		if _, err := path.Match(trimmed, ""); err != nil {
+			return nil, fmt.Errorf("invalid function pattern %q: %w", trimmed, err)
+		}
+		selector = append(selector, trimmed)
+	}
+	return selector, nil
+}
+
+// Empty reports whether the selector has no patterns.
+func (s FunctionSelector) Empty() bool {
+	return len(s) == 0
+}
+
+// Match reports whether name matches any pattern.
+func (s FunctionSelector) Match(name string) bool {
+	for _, pattern := range s {
+		if matchFunctionPattern(pattern, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Unmatched returns the patterns that match none of names.
+func (s FunctionSelector) Unmatched(names []string) []string {
+	unmatched := []string{}
+	for _, pattern := range s {
+		found := false
+		for _, name := range names {
+			if matchFunctionPattern(pattern, name) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			unmatched = append(unmatched, pattern)
+		}
+	}
+	return unmatched
+}
+
+func matchFunctionPattern(pattern, name string) bool {
+	if pattern == name {
+		return true
+	}
+	matched, err := path.Match(pattern, name)
+	return err == nil && matched
+}
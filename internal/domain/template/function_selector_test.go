@@ -0,0 +1,38 @@
+// Where: cli/internal/domain/template/function_selector_test.go
+// What: Tests for function name selection.
+// Why: Keep exact-name and glob matching for --function stable.
+package template
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFunctionSelectorMatchesNamesAndGlobs(t *testing.T) {
+	selector, err := NewFunctionSelector([]string{" lambda-hello ", "api-*", ""})
+	if err != nil {
+		t.Fatalf("NewFunctionSelector: %v", err)
+	}
+	if !reflect.DeepEqual(selector, FunctionSelector{"lambda-hello", "api-*"}) {
+		t.Fatalf("unexpected selector: %#v", selector)
+	}
+	for name, want := range map[string]bool{
+		"lambda-hello": true,
+		"api-users":    true,
+		"api":          false,
+		"lambda-world": false,
+	} {
+		if got := selector.Match(name); got != want {
+			t.Fatalf("Match(%q) = %v, want %v", name, got, want)
+		}
+	}
+	if got := selector.Unmatched([]string{"lambda-hello"}); !reflect.DeepEqual(got, []string{"api-*"}) {
+		t.Fatalf("unexpected unmatched patterns: %v", got)
+	}
+}
+
+func TestNewFunctionSelectorRejectsBadPattern(t *testing.T) {
+	if _, err := NewFunctionSelector([]string{"api-["}); err == nil {
+		t.Fatalf("expected error for malformed pattern")
+	}
+}
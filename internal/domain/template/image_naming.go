@@ -6,6 +6,8 @@ package template
 import (
 	"fmt"
 	"strings"
+
+	"github.com/poruru-code/esb-cli/internal/meta"
 )
 
 func imageSafeName(name string) (string, error) {
@@ -64,3 +66,14 @@ func ApplyImageNames(functions []FunctionSpec) error {
 	}
 	return nil
 }
+
+// SourceImageRef names the image built from SAM Metadata for fn. The function
+// Dockerfile uses it in FROM and bake maps it to the source build target, so
+// the reference never has to exist in a registry.
+func SourceImageRef(fn FunctionSpec) string {
+	tag := "latest"
+	if fn.ImageBuild != nil && strings.TrimSpace(fn.ImageBuild.Tag) != "" {
+		tag = strings.TrimSpace(fn.ImageBuild.Tag)
+	}
+	return fmt.Sprintf("%s-source-%s:%s", meta.ImagePrefix, fn.ImageName, tag)
+}
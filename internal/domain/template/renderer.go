@@ -60,6 +60,11 @@ func RenderDockerfile(
 				return "", fmt.Errorf("java base image is required for runtime %s", profile.Name)
 			}
 			baseImage = profile.JavaBaseImage
+		} else if profile.Kind == runtime.KindNode {
+			if profile.NodeBaseImage == "" {
+				return "", fmt.Errorf("nodejs base image is required for runtime %s", profile.Name)
+			}
+			baseImage = profile.NodeBaseImage
 		} else if registry != "" {
 			baseImage = fmt.Sprintf("%s%s:%s", registry, lambdaBase, tag)
 		}
@@ -80,12 +85,20 @@ func RenderDockerfile(
 		}
 	}
 
+	nodePreloadSource := ""
+	if profile.Kind == runtime.KindNode {
+		nodePreloadSource = path.Join("functions", fn.Name, "esb-preload.js")
+	}
+
 	data := dockerfileTemplateData{
 		Name:                fn.Name,
+		FunctionDir:         path.Join("functions", fn.Name) + "/",
 		ImageWrapper:        isImageWrapper,
 		BaseImage:           baseImage,
 		SitecustomizeSource: sitecustomize,
 		UsePip:              !isImageWrapper && profile.UsesPip && fn.HasRequirements,
+		UseNpm:              !isImageWrapper && profile.UsesNpm && fn.HasPackageJSON,
+		NodePreloadSource:   nodePreloadSource,
 		JavaWrapperSource:   javaWrapperSource,
 		UseJavaAgent:        useJavaAgent,
 		JavaAgentSource:     javaAgentSource,
@@ -97,9 +110,24 @@ func RenderDockerfile(
 		PythonVersion:       profile.PythonVersion,
 	}
 
+	if !dockerConfig.Template.Empty() {
+		tmpl, err := ParseDockerfileTemplate(profile.Kind, dockerConfig.Template)
+		if err != nil {
+			return "", err
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return "", fmt.Errorf("render dockerfile template %s: %w", dockerConfig.Template.Source, err)
+		}
+		return buf.String(), nil
+	}
+
 	templateName := "python/dockerfile.tmpl"
-	if profile.Kind == runtime.KindJava {
+	switch profile.Kind {
+	case runtime.KindJava:
 		templateName = "java/dockerfile.tmpl"
+	case runtime.KindNode:
+		templateName = "nodejs/dockerfile.tmpl"
 	}
 	return renderTemplate(templateName, data)
 }
@@ -120,21 +148,21 @@ func RenderFunctionsYml(functions []FunctionSpec, registry, tag string) (string,
 			return "", fmt.Errorf("image name is required for function %s", fn.Name)
 		}
 
-		hasSchedules := false
+		hasTriggers := false
 		for _, e := range fn.Events {
-			if e.Type == "Schedule" {
-				hasSchedules = true
+			if e.IsTrigger() {
+				hasTriggers = true
 				break
 			}
 		}
 		entry := functionTemplateContext{
-			Name:         fn.Name,
-			Image:        imageRef,
-			Timeout:      optionalInt(fn.Timeout),
-			MemorySize:   optionalInt(fn.MemorySize),
-			Environment:  fn.Environment,
-			Events:       fn.Events,
-			HasSchedules: hasSchedules,
+			Name:        fn.Name,
+			Image:       imageRef,
+			Timeout:     optionalInt(fn.Timeout),
+			MemorySize:  optionalInt(fn.MemorySize),
+			Environment: fn.Environment,
+			Events:      fn.Events,
+			HasTriggers: hasTriggers,
 		}
 		if fn.Scaling.MaxCapacity != nil || fn.Scaling.MinCapacity != nil {
 			scaling := map[string]any{}
@@ -155,15 +183,32 @@ func RenderFunctionsYml(functions []FunctionSpec, registry, tag string) (string,
 func RenderRoutingYml(functions []FunctionSpec) (string, error) {
 	data := routingTemplateData{}
 	for _, fn := range functions {
-		entry := routingFunction{
-			Name:   fn.Name,
-			Events: fn.Events,
+		for _, e := range fn.Events {
+			if strings.TrimSpace(e.Path) == "" {
+				continue
+			}
+			data.Routes = append(data.Routes, routingRoute{
+				Path:       joinRoutePath(e.BasePath, e.Path),
+				Method:     e.Method,
+				Function:   fn.Name,
+				API:        e.API,
+				Stage:      e.StageName,
+				Authorizer: e.Authorizer,
+			})
 		}
-		data.Functions = append(data.Functions, entry)
 	}
 	return renderTemplate("routing.yml.tmpl", data)
 }
 
+// joinRoutePath prefixes a route path with the API base path mapping.
+func joinRoutePath(basePath, routePath string) string {
+	basePath = strings.Trim(strings.TrimSpace(basePath), "/")
+	if basePath == "" {
+		return routePath
+	}
+	return "/" + basePath + "/" + strings.TrimLeft(routePath, "/")
+}
+
 func RenderResourcesYml(spec manifest.ResourcesSpec) (string, error) {
 	resources := map[string]any{}
 	if len(spec.DynamoDB) > 0 {
@@ -237,6 +282,8 @@ func resolveTemplateSource(name string) (embed.FS, string) {
 		return runtimeassets.RuntimeTemplatesFS, "runtime-templates/python/templates/dockerfile.tmpl"
 	case "java/dockerfile.tmpl":
 		return runtimeassets.RuntimeTemplatesFS, "runtime-templates/java/templates/dockerfile.tmpl"
+	case "nodejs/dockerfile.tmpl":
+		return runtimeassets.RuntimeTemplatesFS, "runtime-templates/nodejs/templates/dockerfile.tmpl"
 	default:
 		return templateFS, "templates/" + name
 	}
@@ -244,10 +291,13 @@ func resolveTemplateSource(name string) (embed.FS, string) {
 
 type dockerfileTemplateData struct {
 	Name                string
+	FunctionDir         string
 	ImageWrapper        bool
 	BaseImage           string
 	SitecustomizeSource string
 	UsePip              bool
+	UseNpm              bool
+	NodePreloadSource   string
 	JavaWrapperSource   string
 	UseJavaAgent        bool
 	JavaAgentSource     string
@@ -264,23 +314,27 @@ type functionsTemplateData struct {
 }
 
 type functionTemplateContext struct {
-	Name         string
-	Image        string
-	Timeout      *int
-	MemorySize   *int
-	Environment  map[string]string
-	Scaling      map[string]any
-	Events       []EventSpec
-	HasSchedules bool
+	Name        string
+	Image       string
+	Timeout     *int
+	MemorySize  *int
+	Environment map[string]string
+	Scaling     map[string]any
+	Events      []EventSpec
+	HasTriggers bool
 }
 
 type routingTemplateData struct {
-	Functions []routingFunction
+	Routes []routingRoute
 }
 
-type routingFunction struct {
-	Name   string
-	Events []EventSpec
+type routingRoute struct {
+	Path       string
+	Method     string
+	Function   string
+	API        string
+	Stage      string
+	Authorizer string
 }
 
 func normalizeRegistry(value string) string {
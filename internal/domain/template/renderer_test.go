@@ -145,6 +145,77 @@ func TestRenderDockerfileJavaRuntimeSingleJarCodeURI(t *testing.T) {
 	}
 }
 
+func TestRenderDockerfileNodejsRuntime(t *testing.T) {
+	fn := FunctionSpec{
+		Name:           "lambda-node",
+		CodeURI:        "functions/lambda-node/src/",
+		Handler:        "index.handler",
+		Runtime:        "nodejs20.x",
+		HasPackageJSON: true,
+		Layers: []manifest.LayerSpec{
+			{Name: "shared", ContentURI: "functions/lambda-node/layers/shared"},
+		},
+	}
+
+	content, err := RenderDockerfile(fn, DockerConfig{}, "", "latest")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.Contains(content, "FROM public.ecr.aws/lambda/nodejs:20") {
+		t.Fatalf("expected nodejs base image, got: %s", content)
+	}
+	if !strings.Contains(content, "COPY functions/lambda-node/esb-preload.js /opt/esb/nodejs/esb-preload.js") {
+		t.Fatalf("expected preload hook copy")
+	}
+	if !strings.Contains(content, `ENV NODE_OPTIONS="--require /opt/esb/nodejs/esb-preload.js`) {
+		t.Fatalf("expected NODE_OPTIONS preload")
+	}
+	if !strings.Contains(content, "COPY functions/lambda-node/src/package*.json ${LAMBDA_TASK_ROOT}/") {
+		t.Fatalf("expected package manifest copy")
+	}
+	if !strings.Contains(content, "COPY functions/lambda-node/layers/shared/ /opt/") {
+		t.Fatalf("expected layer copy")
+	}
+	if !strings.Contains(content, `CMD [ "index.handler" ]`) {
+		t.Fatalf("expected handler in dockerfile")
+	}
+	if strings.Contains(content, "sitecustomize.py") || strings.Contains(content, "pip install") {
+		t.Fatalf("did not expect python hooks for nodejs runtime")
+	}
+
+	fn.HasPackageJSON = false
+	content, err = RenderDockerfile(fn, DockerConfig{}, "", "latest")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if strings.Contains(content, "npm ") {
+		t.Fatalf("did not expect npm install without package.json")
+	}
+}
+
+func TestRenderDockerfileImageWrapperNodejs(t *testing.T) {
+	fn := FunctionSpec{
+		Name:           "lambda-image-node",
+		ImageSource:    "public.ecr.aws/example/node:v1",
+		Runtime:        "nodejs22.x",
+		HasPackageJSON: true,
+	}
+
+	content, err := RenderDockerfile(fn, DockerConfig{}, "", "latest")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.Contains(content, "FROM public.ecr.aws/example/node:v1") {
+		t.Fatalf("expected image source base")
+	}
+	if !strings.Contains(content, "COPY functions/lambda-image-node/esb-preload.js /opt/esb/nodejs/esb-preload.js") {
+		t.Fatalf("expected preload hook copy for image wrapper")
+	}
+	if strings.Contains(content, "npm ") || strings.Contains(content, "CMD [") {
+		t.Fatalf("did not expect dependency install or CMD for image wrapper")
+	}
+}
+
 func TestRenderDockerfileImageWrapperPython(t *testing.T) {
 	fn := FunctionSpec{
 		Name:        "lambda-image",
@@ -280,6 +351,122 @@ func TestRenderRoutingYml(t *testing.T) {
 	}
 }
 
+func TestRenderFunctionsYmlAsyncEvents(t *testing.T) {
+	functions := []FunctionSpec{
+		{
+			Name:      "lambda-worker",
+			ImageName: "lambda-worker",
+			Events: []EventSpec{
+				{Type: EventTypeAPI, Path: "/api/worker", Method: "post"},
+				{Type: EventTypeSQS, Queue: "orders", BatchSize: intPtr(10), FilterPatterns: []string{`{"body":{"kind":["order"]}}`}},
+				{Type: EventTypeSNS, Topic: "alerts", FilterPolicy: `{"kind":["alert"]}`},
+				{Type: EventTypeS3, Bucket: "uploads", BucketEvents: []string{"s3:ObjectCreated:*"}, KeyPrefix: "incoming/"},
+				{Type: EventTypeDynamoDB, Stream: "orders-table", StartingPosition: "LATEST"},
+			},
+		},
+	}
+
+	content, err := RenderFunctionsYml(functions, "", "latest")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	var parsed struct {
+		Functions map[string]struct {
+			Events []map[string]map[string]any `yaml:"events"`
+		} `yaml:"functions"`
+	}
+	if err := yaml.Unmarshal([]byte(content), &parsed); err != nil {
+		t.Fatalf("yaml unmarshal failed: %v\n%s", err, content)
+	}
+	events := parsed.Functions["lambda-worker"].Events
+	if len(events) != 4 {
+		t.Fatalf("expected 4 trigger events, got %+v", events)
+	}
+	if events[0]["sqs"]["queue"] != "orders" || events[0]["sqs"]["batch_size"] != 10 {
+		t.Fatalf("unexpected sqs event: %+v", events[0])
+	}
+	patterns, ok := events[0]["sqs"]["filter_patterns"].([]any)
+	if !ok || len(patterns) != 1 || patterns[0] != `{"body":{"kind":["order"]}}` {
+		t.Fatalf("unexpected sqs filter patterns: %+v", events[0])
+	}
+	if events[1]["sns"]["topic"] != "alerts" || events[1]["sns"]["filter_policy"] != `{"kind":["alert"]}` {
+		t.Fatalf("unexpected sns event: %+v", events[1])
+	}
+	if events[2]["s3"]["bucket"] != "uploads" || events[2]["s3"]["prefix"] != "incoming/" {
+		t.Fatalf("unexpected s3 event: %+v", events[2])
+	}
+	if events[3]["dynamodb"]["table"] != "orders-table" || events[3]["dynamodb"]["starting_position"] != "LATEST" {
+		t.Fatalf("unexpected dynamodb event: %+v", events[3])
+	}
+}
+
+func TestRenderRoutingYmlSkipsTriggerEvents(t *testing.T) {
+	functions := []FunctionSpec{
+		{
+			Name: "lambda-worker",
+			Events: []EventSpec{
+				{Type: EventTypeAPI, Path: "/api/worker", Method: "post"},
+				{Type: EventTypeSchedule, ScheduleExpression: "rate(1 minute)"},
+				{Type: EventTypeSQS, Queue: "orders"},
+			},
+		},
+	}
+
+	content, err := RenderRoutingYml(functions)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	var parsed map[string]any
+	if err := yaml.Unmarshal([]byte(content), &parsed); err != nil {
+		t.Fatalf("yaml unmarshal failed: %v", err)
+	}
+	routes, ok := parsed["routes"].([]any)
+	if !ok || len(routes) != 1 {
+		t.Fatalf("expected only the api route, got: %s", content)
+	}
+}
+
+func TestRenderRoutingYmlExplicitAPI(t *testing.T) {
+	functions := []FunctionSpec{
+		{
+			Name: "lambda-proxy",
+			Events: []EventSpec{
+				{
+					Type:       EventTypeAPI,
+					Path:       "/{proxy+}",
+					Method:     "any",
+					API:        "PublicApi",
+					StageName:  "v1",
+					BasePath:   "orders",
+					Authorizer: "TokenAuth",
+				},
+			},
+		},
+	}
+
+	content, err := RenderRoutingYml(functions)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	var parsed struct {
+		Routes []map[string]string `yaml:"routes"`
+	}
+	if err := yaml.Unmarshal([]byte(content), &parsed); err != nil {
+		t.Fatalf("yaml unmarshal failed: %v", err)
+	}
+	if len(parsed.Routes) != 1 {
+		t.Fatalf("expected 1 route, got: %s", content)
+	}
+	route := parsed.Routes[0]
+	if route["path"] != "/orders/{proxy+}" || route["method"] != "ANY" {
+		t.Fatalf("unexpected route path/method: %+v", route)
+	}
+	if route["api"] != "PublicApi" || route["stage"] != "v1" || route["authorizer"] != "TokenAuth" {
+		t.Fatalf("unexpected route metadata: %+v", route)
+	}
+}
+
 func TestRenderFunctionsYmlRequiresImageName(t *testing.T) {
 	functions := []FunctionSpec{
 		{
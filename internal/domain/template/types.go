@@ -26,12 +26,35 @@ type FunctionSpec struct {
 	Timeout                 int
 	MemorySize              int
 	HasRequirements         bool
+	HasPackageJSON          bool
 	Environment             map[string]string
 	Events                  []EventSpec
 	Scaling                 ScalingSpec
 	Layers                  []manifest.LayerSpec
 	Architectures           []string
 	RuntimeManagementConfig RuntimeManagementConfig
+	// DockerfileTemplate selects a repository Dockerfile template from
+	// resource Metadata (a name under the override directory or a path).
+	DockerfileTemplate string
+	// ImageBuild is set for image functions built from source through SAM
+	// Metadata (Dockerfile/DockerContext) instead of a pre-built ImageUri.
+	ImageBuild *ImageBuildSpec
+	// BuildMethod is the SAM Metadata.BuildMethod for zip functions.
+	BuildMethod string
+}
+
+// BuildMethodMakefile runs `make build-<LogicalID>` with ARTIFACTS_DIR set,
+// matching `sam build` custom builds.
+const BuildMethodMakefile = "makefile"
+
+// ImageBuildSpec captures the SAM image build Metadata of a function.
+type ImageBuildSpec struct {
+	// Dockerfile is relative to Context.
+	Dockerfile string
+	// Context is relative to the SAM template until generation resolves it.
+	Context string
+	Args    map[string]string
+	Tag     string
 }
 
 // EventSpec captures supported event configurations.
@@ -41,6 +64,47 @@ type EventSpec struct {
 	Method             string
 	ScheduleExpression string
 	Input              string
+	// API, StageName, BasePath and Authorizer describe the explicit
+	// AWS::Serverless::Api/HttpApi resource an Api/HttpApi event belongs to.
+	API        string
+	StageName  string
+	BasePath   string
+	Authorizer string
+	// Queue, Topic, Bucket and Stream name the local resource driving
+	// SQS, SNS, S3 and DynamoDB events respectively.
+	Queue            string
+	Topic            string
+	Bucket           string
+	Stream           string
+	BucketEvents     []string
+	KeyPrefix        string
+	KeySuffix        string
+	BatchSize        *int
+	StartingPosition string
+	FilterPatterns   []string
+	FilterPolicy     string
+}
+
+// Supported event types.
+const (
+	EventTypeAPI      = "Api"
+	EventTypeHTTPAPI  = "HttpApi"
+	EventTypeSchedule = "Schedule"
+	EventTypeSQS      = "SQS"
+	EventTypeSNS      = "SNS"
+	EventTypeS3       = "S3"
+	EventTypeDynamoDB = "DynamoDB"
+)
+
+// IsTrigger reports whether the event is driven by the gateway scheduler or
+// an event source rather than an HTTP route.
+func (e EventSpec) IsTrigger() bool {
+	switch e.Type {
+	case EventTypeSchedule, EventTypeSQS, EventTypeSNS, EventTypeS3, EventTypeDynamoDB:
+		return true
+	default:
+		return false
+	}
 }
 
 // ScalingSpec captures scaling configuration.
@@ -57,4 +121,6 @@ type RuntimeManagementConfig struct {
 // DockerConfig captures Dockerfile rendering settings.
 type DockerConfig struct {
 	SitecustomizeSource string
+	// Template overrides the embedded runtime Dockerfile template when set.
+	Template DockerfileTemplate
 }
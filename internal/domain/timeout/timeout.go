@@ -0,0 +1,206 @@
+// Where: cli/internal/domain/timeout/timeout.go
+// What: Deploy timeout budget, phase names and budget-exceeded errors.
+// Why: Let build and deploy phases share one budget without hanging forever.
+package timeout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+var errUnknownPhase = errors.New("unknown phase")
+
+// Phase names a deploy step that can carry its own timeout budget.
+type Phase string
+
+const (
+	// PhaseTotal is the whole deploy command (--timeout).
+	PhaseTotal         Phase = "total"
+	PhaseRegistryWait  Phase = "registry_wait"
+	PhaseGenerate      Phase = "generate"
+	PhaseBaseBuild     Phase = "base_build"
+	PhaseFunctionBuild Phase = "function_build"
+	PhaseProvision     Phase = "provision"
+	PhaseSync          Phase = "sync"
+)
+
+// DefaultRegistryWait bounds registry readiness waits when registry_wait has
+// no budget; the registry wait is never unbounded.
+const DefaultRegistryWait = 60 * time.Second
+
+// Phases lists the phases accepted by ParsePhases, in deploy order.
+var Phases = []Phase{
+	PhaseRegistryWait,
+	PhaseGenerate,
+	PhaseBaseBuild,
+	PhaseFunctionBuild,
+	PhaseProvision,
+	PhaseSync,
+}
+
+// Budget holds the whole-command timeout and per-phase budgets. Zero means
+// no limit.
+type Budget struct {
+	Total  time.Duration
+	Phases map[Phase]time.Duration
+}
+
+// For returns the budget of phase, or zero when it has none.
+func (b Budget) For(phase Phase) time.Duration {
+	if phase == PhaseTotal {
+		return b.Total
+	}
+	return b.Phases[phase]
+}
+
+// With returns a copy of b with phases overridden; a zero duration clears
+// the budget of that phase.
+func (b Budget) With(phases map[Phase]time.Duration) Budget {
+	merged := make(map[Phase]time.Duration, len(b.Phases)+len(phases))
+	for phase, limit := range b.Phases {
+		merged[phase] = limit
+	}
+	for phase, limit := range phases {
+		merged[phase] = limit
+	}
+	return Budget{Total: b.Total, Phases: merged}
+}
+
+// ExceededError reports the phase whose budget ran out.
+type ExceededError struct {
+	Phase  Phase
+	Budget time.Duration
+}
+
+func (e *ExceededError) Error() string {
+	if e.Phase == PhaseTotal {
+		return fmt.Sprintf("deploy exceeded its %s timeout", e.Budget)
+	}
+	return fmt.Sprintf("%s phase exceeded its %s budget", e.Phase, e.Budget)
+}
+
+// Unwrap keeps errors.Is(err, context.DeadlineExceeded) working.
+func (e *ExceededError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+// Exceeded wraps err, the failure seen when the budget of phase ran out.
+func Exceeded(phase Phase, budget time.Duration, err error) error {
+	exceeded := &ExceededError{Phase: phase, Budget: budget}
+	if err == nil {
+		return exceeded
+	}
+	return fmt.Errorf("%w: %w", exceeded, err)
+}
+
+// WithTotal bounds ctx by the whole-command budget. Once it runs out,
+// context.Cause(ctx) is an *ExceededError for PhaseTotal.
+func WithTotal(ctx context.Context, total time.Duration) (context.Context, context.CancelFunc) {
+	if total <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeoutCause(ctx, total, &ExceededError{Phase: PhaseTotal, Budget: total})
+}
+
+// Run calls fn with ctx bounded by the budget of phase. When that budget
+// runs out before ctx itself is done, the error is an *ExceededError.
+func Run(ctx context.Context, budget Budget, phase Phase, fn func(context.Context) error) error {
+	limit := budget.For(phase)
+	if limit <= 0 {
+		return fn(ctx)
+	}
+	phaseCtx, cancel := context.WithTimeout(ctx, limit)
+	defer cancel()
+	err := fn(phaseCtx)
+	if err != nil && ctx.Err() == nil && errors.Is(phaseCtx.Err(), context.DeadlineExceeded) {
+		return Exceeded(phase, limit, err)
+	}
+	return err
+}
+
+// Cause returns the *ExceededError that ended ctx, if any.
+func Cause(ctx context.Context) (*ExceededError, bool) {
+	var exceeded *ExceededError
+	if errors.As(context.Cause(ctx), &exceeded) {
+		return exceeded, true
+	}
+	return nil, false
+}
+
+// ParsePhase accepts snake_case or kebab-case phase names.
+func ParsePhase(name string) (Phase, error) {
+	normalized := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), "-", "_")
+	for _, phase := range Phases {
+		if string(phase) == normalized {
+			return phase, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %q (want %s)", errUnknownPhase, name, phaseList())
+}
+
+// ParseDuration parses a Go duration ("90s", "10m"); empty and "0" mean no
+// limit.
+func ParseDuration(value string) (time.Duration, error) {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" || trimmed == "0" {
+		return 0, nil
+	}
+	limit, err := time.ParseDuration(trimmed)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q: %w", value, err)
+	}
+	if limit < 0 {
+		return 0, fmt.Errorf("invalid duration %q: must not be negative", value)
+	}
+	return limit, nil
+}
+
+// ParsePhases parses "<phase>=<duration>" entries.
+func ParsePhases(entries []string) (map[Phase]time.Duration, error) {
+	phases := map[Phase]time.Duration{}
+	for _, entry := range entries {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid phase timeout %q: want <phase>=<duration>", entry)
+		}
+		phase, err := ParsePhase(name)
+		if err != nil {
+			return nil, err
+		}
+		limit, err := ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("phase %s: %w", phase, err)
+		}
+		phases[phase] = limit
+	}
+	return phases, nil
+}
+
+// ParsePhaseMap parses a phase-keyed duration map, as read from config.
+func ParsePhaseMap(values map[string]string) (map[Phase]time.Duration, error) {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	entries := make([]string, 0, len(keys))
+	for _, key := range keys {
+		entries = append(entries, key+"="+values[key])
+	}
+	return ParsePhases(entries)
+}
+
+func phaseList() string {
+	names := make([]string, 0, len(Phases))
+	for _, phase := range Phases {
+		names = append(names, string(phase))
+	}
+	return strings.Join(names, ", ")
+}
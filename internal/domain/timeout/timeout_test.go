@@ -0,0 +1,93 @@
+// Where: cli/internal/domain/timeout/timeout_test.go
+// What: Unit tests for deploy timeout budgets.
+// Why: Keep phase parsing and budget-exceeded reporting stable.
+package timeout
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParsePhases(t *testing.T) {
+	phases, err := ParsePhases([]string{"registry-wait=2m", "provision=90s", "sync=0"})
+	if err != nil {
+		t.Fatalf("parse phases: %v", err)
+	}
+	want := map[Phase]time.Duration{
+		PhaseRegistryWait: 2 * time.Minute,
+		PhaseProvision:    90 * time.Second,
+		PhaseSync:         0,
+	}
+	if len(phases) != len(want) {
+		t.Fatalf("phases = %v, want %v", phases, want)
+	}
+	for phase, limit := range want {
+		if got, ok := phases[phase]; !ok || got != limit {
+			t.Fatalf("phase %s = %v, want %v", phase, got, limit)
+		}
+	}
+}
+
+func TestParsePhasesRejectsInvalidEntries(t *testing.T) {
+	for _, entry := range []string{"deploy=1m", "generate", "generate=soon", "sync=-1s"} {
+		if _, err := ParsePhases([]string{entry}); err == nil {
+			t.Fatalf("expected error for %q", entry)
+		}
+	}
+}
+
+func TestBudgetWithOverridesPhases(t *testing.T) {
+	base := Budget{Total: time.Hour, Phases: map[Phase]time.Duration{PhaseRegistryWait: time.Minute}}
+	merged := base.With(map[Phase]time.Duration{PhaseRegistryWait: 0, PhaseGenerate: time.Second})
+	if merged.For(PhaseRegistryWait) != 0 || merged.For(PhaseGenerate) != time.Second {
+		t.Fatalf("unexpected merged budget: %+v", merged)
+	}
+	if merged.For(PhaseTotal) != time.Hour {
+		t.Fatalf("total = %v, want 1h", merged.For(PhaseTotal))
+	}
+	if base.For(PhaseRegistryWait) != time.Minute {
+		t.Fatal("With must not modify the receiver")
+	}
+}
+
+func TestRunReportsExceededPhase(t *testing.T) {
+	budget := Budget{Phases: map[Phase]time.Duration{PhaseProvision: 10 * time.Millisecond}}
+	errProvision := errors.New("provisioner interrupted")
+	err := Run(context.Background(), budget, PhaseProvision, func(ctx context.Context) error {
+		<-ctx.Done()
+		return errProvision
+	})
+	var exceeded *ExceededError
+	if !errors.As(err, &exceeded) || exceeded.Phase != PhaseProvision {
+		t.Fatalf("expected provision budget error, got %v", err)
+	}
+	if !errors.Is(err, errProvision) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected wrapped errors, got %v", err)
+	}
+}
+
+func TestRunLeavesParentCancellationAlone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	budget := Budget{Phases: map[Phase]time.Duration{PhaseSync: time.Minute}}
+	err := Run(ctx, budget, PhaseSync, func(ctx context.Context) error { return ctx.Err() })
+	var exceeded *ExceededError
+	if errors.As(err, &exceeded) {
+		t.Fatalf("cancelled parent must not be reported as a budget error: %v", err)
+	}
+}
+
+func TestWithTotalSetsCause(t *testing.T) {
+	ctx, cancel := WithTotal(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	<-ctx.Done()
+	exceeded, ok := Cause(ctx)
+	if !ok || exceeded.Phase != PhaseTotal {
+		t.Fatalf("expected total budget cause, got %v", context.Cause(ctx))
+	}
+	if got := exceeded.Error(); got != "deploy exceeded its 10ms timeout" {
+		t.Fatalf("message = %q", got)
+	}
+}
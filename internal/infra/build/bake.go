@@ -16,12 +16,17 @@ type bakeTarget struct {
 	Context    string
 	Dockerfile string
 	Tags       []string
+	Platforms  []string
 	Outputs    []string
 	Labels     map[string]string
 	Args       map[string]string
 	Contexts   map[string]string
 	Secrets    []string
-	NoCache    bool
+	CacheFrom  []string
+	CacheTo    []string
+	// Attest requests SBOM/provenance attestations (bake `attest`).
+	Attest  []string
+	NoCache bool
 }
 
 func buildxBuilderName() string {
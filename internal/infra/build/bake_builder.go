@@ -34,7 +34,7 @@ func ensureBuildxBuilder(
 	builder := buildxBuilderName()
 	needsRecreate := false
 	desiredProxyEnv := buildxProxyDriverEnvMap()
-	return withBuildLock(lockRoot, "buildx", func() error {
+	return withBuildLock(ctx, lockRoot, "buildx", func() error {
 		output, err := runner.RunOutput(
 			ctx,
 			root,
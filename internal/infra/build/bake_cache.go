@@ -0,0 +1,152 @@
+// Where: cli/internal/infra/build/bake_cache.go
+// What: BuildKit cache import/export settings and cache hit accounting.
+// Why: Let CI reuse layer caches across runners instead of starting cold.
+package build
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Supported build cache kinds for --build-cache.
+const (
+	BuildCacheRegistry = "registry"
+	BuildCacheLocal    = "local"
+	BuildCacheInline   = "inline"
+)
+
+// BuildCache configures cache-from/cache-to for every bake target.
+// Kind is empty when only the builder-local cache is used.
+type BuildCache struct {
+	Kind string
+	// Ref is the registry repository (registry) or directory (local).
+	Ref string
+	// Scope namespaces cache entries per compose project/env so parallel
+	// environments do not overwrite each other.
+	Scope string
+}
+
+// ParseBuildCache parses "registry=<ref>", "local=<dir>" or "inline".
+// An empty value disables remote caching.
+func ParseBuildCache(value string) (BuildCache, error) {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return BuildCache{}, nil
+	}
+	if trimmed == BuildCacheInline {
+		return BuildCache{Kind: BuildCacheInline}, nil
+	}
+	kind, ref, ok := strings.Cut(trimmed, "=")
+	kind = strings.TrimSpace(kind)
+	ref = strings.TrimSpace(ref)
+	if !ok || ref == "" || (kind != BuildCacheRegistry && kind != BuildCacheLocal) {
+		return BuildCache{}, fmt.Errorf(
+			"invalid build cache %q (expected registry=<ref>, local=<dir> or inline)",
+			value,
+		)
+	}
+	if kind == BuildCacheLocal {
+		abs, err := filepath.Abs(ref)
+		if err != nil {
+			return BuildCache{}, fmt.Errorf("resolve build cache dir: %w", err)
+		}
+		ref = abs
+	}
+	return BuildCache{Kind: kind, Ref: ref}, nil
+}
+
+// Enabled reports whether a remote cache is configured.
+func (c BuildCache) Enabled() bool {
+	return c.Kind != ""
+}
+
+// applyBakeCache sets cache-from/cache-to on each target.
+func applyBakeCache(targets []bakeTarget, cache BuildCache) {
+	if !cache.Enabled() {
+		return
+	}
+	for i := range targets {
+		target := &targets[i]
+		switch cache.Kind {
+		case BuildCacheRegistry:
+			ref := fmt.Sprintf("%s:%s", cache.Ref, cacheEntryName(cache.Scope, target.Name))
+			target.CacheFrom = []string{"type=registry,ref=" + ref}
+			target.CacheTo = []string{"type=registry,ref=" + ref + ",mode=max"}
+		case BuildCacheLocal:
+			dir := filepath.Join(cache.Ref, cacheEntryName(cache.Scope, target.Name))
+			// BuildKit fails on a missing local cache, so only import once exported.
+			if _, err := os.Stat(filepath.Join(dir, "index.json")); err == nil {
+				target.CacheFrom = []string{"type=local,src=" + dir}
+			}
+			target.CacheTo = []string{"type=local,dest=" + dir + ",mode=max"}
+		case BuildCacheInline:
+			// Inline cache rides on the exported image; untagged targets
+			// (source builds) have nowhere to store it.
+			if len(target.Tags) == 0 {
+				continue
+			}
+			target.CacheFrom = []string{"type=registry,ref=" + target.Tags[0]}
+			target.CacheTo = []string{"type=inline"}
+		}
+	}
+}
+
+func cacheEntryName(scope, target string) string {
+	if scope = strings.TrimSpace(scope); scope == "" {
+		return target
+	}
+	return scope + "-" + target
+}
+
+// bakeCacheStats counts build steps and how many were served from cache.
+type bakeCacheStats struct {
+	Steps  int
+	Cached int
+}
+
+func (s bakeCacheStats) add(other bakeCacheStats) bakeCacheStats {
+	return bakeCacheStats{Steps: s.Steps + other.Steps, Cached: s.Cached + other.Cached}
+}
+
+// String renders "cache 7/9 (78%)", or "" when no steps were observed.
+func (s bakeCacheStats) String() string {
+	if s.Steps == 0 {
+		return ""
+	}
+	return fmt.Sprintf("cache %d/%d (%d%%)", s.Cached, s.Steps, s.Cached*100/s.Steps)
+}
+
+var (
+	bakeStepPattern   = regexp.MustCompile(`^#(\d+) \[[^\]]*\d+/\d+\]`)
+	bakeCachedPattern = regexp.MustCompile(`^#(\d+) CACHED\s*$`)
+)
+
+// parseBakeCacheStats reads plain buildx progress output. Only Dockerfile
+// steps ("[target 2/5] RUN ...") count; internal vertices are ignored.
+func parseBakeCacheStats(output string) bakeCacheStats {
+	steps := map[string]struct{}{}
+	cached := map[string]struct{}{}
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if match := bakeStepPattern.FindStringSubmatch(line); match != nil {
+			steps[match[1]] = struct{}{}
+			continue
+		}
+		if match := bakeCachedPattern.FindStringSubmatch(line); match != nil {
+			cached[match[1]] = struct{}{}
+		}
+	}
+	stats := bakeCacheStats{Steps: len(steps)}
+	for id := range cached {
+		if _, ok := steps[id]; ok {
+			stats.Cached++
+		}
+	}
+	return stats
+}
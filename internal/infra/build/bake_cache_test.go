@@ -0,0 +1,95 @@
+// Where: cli/internal/infra/build/bake_cache_test.go
+// What: Tests for build cache parsing, per-target cache refs and hit stats.
+// Why: Keep CI cache refs collision-free and hit ratios accurate.
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseBuildCache(t *testing.T) {
+	cache, err := ParseBuildCache(" registry=ghcr.io/acme/esb-cache ")
+	if err != nil || cache != (BuildCache{Kind: BuildCacheRegistry, Ref: "ghcr.io/acme/esb-cache"}) {
+		t.Fatalf("registry cache = %#v (%v)", cache, err)
+	}
+	if cache, err := ParseBuildCache("inline"); err != nil || cache.Kind != BuildCacheInline {
+		t.Fatalf("inline cache = %#v (%v)", cache, err)
+	}
+	if cache, err := ParseBuildCache(""); err != nil || cache.Enabled() {
+		t.Fatalf("empty cache = %#v (%v)", cache, err)
+	}
+	for _, value := range []string{"gha", "registry=", "s3=bucket"} {
+		if _, err := ParseBuildCache(value); err == nil {
+			t.Fatalf("expected error for %q", value)
+		}
+	}
+}
+
+func TestApplyBakeCacheScopesRefsPerTarget(t *testing.T) {
+	targets := []bakeTarget{{Name: "lambda-base", Tags: []string{"esb-lambda-base:latest"}}, {Name: "fn-hello"}}
+	applyBakeCache(targets, BuildCache{Kind: BuildCacheRegistry, Ref: "registry:5010/cache", Scope: "esb-dev-1234"})
+	if !reflect.DeepEqual(targets[1].CacheFrom, []string{"type=registry,ref=registry:5010/cache:esb-dev-1234-fn-hello"}) {
+		t.Fatalf("unexpected cache-from: %v", targets[1].CacheFrom)
+	}
+	if !reflect.DeepEqual(targets[1].CacheTo, []string{"type=registry,ref=registry:5010/cache:esb-dev-1234-fn-hello,mode=max"}) {
+		t.Fatalf("unexpected cache-to: %v", targets[1].CacheTo)
+	}
+
+	inline := []bakeTarget{{Name: "lambda-base", Tags: []string{"esb-lambda-base:latest"}}}
+	applyBakeCache(inline, BuildCache{Kind: BuildCacheInline})
+	if !reflect.DeepEqual(inline[0].CacheFrom, []string{"type=registry,ref=esb-lambda-base:latest"}) ||
+		!reflect.DeepEqual(inline[0].CacheTo, []string{"type=inline"}) {
+		t.Fatalf("unexpected inline cache: %#v", inline[0])
+	}
+}
+
+func TestApplyBakeCacheLocalImportsOnlyExistingCache(t *testing.T) {
+	root := t.TempDir()
+	cache := BuildCache{Kind: BuildCacheLocal, Ref: root, Scope: "esb-dev"}
+	targets := []bakeTarget{{Name: "fn-hello"}}
+	applyBakeCache(targets, cache)
+	dir := filepath.Join(root, "esb-dev-fn-hello")
+	if targets[0].CacheFrom != nil {
+		t.Fatalf("cold local cache must not be imported: %v", targets[0].CacheFrom)
+	}
+	if !reflect.DeepEqual(targets[0].CacheTo, []string{"type=local,dest=" + dir + ",mode=max"}) {
+		t.Fatalf("unexpected cache-to: %v", targets[0].CacheTo)
+	}
+
+	writeTestFile(t, filepath.Join(dir, "index.json"), "{}")
+	targets = []bakeTarget{{Name: "fn-hello"}}
+	applyBakeCache(targets, cache)
+	if !reflect.DeepEqual(targets[0].CacheFrom, []string{"type=local,src=" + dir}) {
+		t.Fatalf("unexpected cache-from: %v", targets[0].CacheFrom)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "index.json")); err != nil {
+		t.Fatalf("cache dir should be untouched: %v", err)
+	}
+}
+
+func TestParseBakeCacheStatsCountsDockerfileSteps(t *testing.T) {
+	output := `#1 [internal] load build definition from Dockerfile
+#1 DONE 0.0s
+#4 [fn-hello 1/3] FROM docker.io/library/python:3.12
+#4 CACHED
+#5 [fn-hello 2/3] COPY functions/hello /var/task
+#5 CACHED
+#6 [fn-hello 3/3] RUN pip install -r requirements.txt
+#6 DONE 3.1s
+#7 exporting to image
+#7 CACHED
+`
+	stats := parseBakeCacheStats(output)
+	if stats != (bakeCacheStats{Steps: 3, Cached: 2}) {
+		t.Fatalf("unexpected stats: %#v", stats)
+	}
+	if got := stats.String(); got != "cache 2/3 (66%)" {
+		t.Fatalf("unexpected summary: %q", got)
+	}
+	if got := (bakeCacheStats{}).String(); got != "" {
+		t.Fatalf("expected empty summary without steps, got %q", got)
+	}
+}
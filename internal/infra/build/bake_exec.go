@@ -21,36 +21,40 @@ func runBakeGroup(
 	groupName string,
 	targets []bakeTarget,
 	verbose bool,
-) error {
+) (bakeCacheStats, error) {
 	if runner == nil {
-		return fmt.Errorf("command runner is nil")
+		return bakeCacheStats{}, fmt.Errorf("command runner is nil")
 	}
 	if strings.TrimSpace(repoRoot) == "" {
-		return fmt.Errorf("repo root is required")
+		return bakeCacheStats{}, fmt.Errorf("repo root is required")
 	}
 	if strings.TrimSpace(groupName) == "" {
-		return fmt.Errorf("group name is required")
+		return bakeCacheStats{}, fmt.Errorf("group name is required")
 	}
 	if len(targets) == 0 {
-		return nil
+		return bakeCacheStats{}, nil
 	}
 
 	bakeFile := filepath.Join(repoRoot, "docker-bake.hcl")
 	if _, err := os.Stat(bakeFile); err != nil {
-		return fmt.Errorf("bake file not found: %w", err)
+		return bakeCacheStats{}, fmt.Errorf("bake file not found: %w", err)
 	}
 
 	tmpFile, err := writeBakeFile(groupName, targets)
 	if err != nil {
-		return err
+		return bakeCacheStats{}, err
 	}
 	defer func() { _ = os.Remove(tmpFile) }()
 
 	builder := buildxBuilderName()
-	args := buildBakeArgs(builder, bakeFile, tmpFile, targets, groupName, verbose)
-	return withBuildLock(lockRoot, "bake", func() error {
-		return runBakeCommand(ctx, runner, repoRoot, args, verbose)
+	args := buildBakeArgs(builder, bakeFile, tmpFile, targets, groupName)
+	var stats bakeCacheStats
+	err = withBuildLock(ctx, lockRoot, "bake", func() error {
+		result, runErr := runBakeCommand(ctx, runner, repoRoot, args, verbose)
+		stats = result
+		return runErr
 	})
+	return stats, err
 }
 
 func buildxHint(output string) string {
@@ -73,39 +77,39 @@ func buildBakeArgs(
 	tmpFile string,
 	targets []bakeTarget,
 	groupName string,
-	verbose bool,
 ) []string {
 	args := []string{"buildx", "bake", "--builder", builder}
 	args = append(args, bakeAllowArgs(targets)...)
 	args = append(args, bakeProvenanceArgs()...)
 	args = append(args, "-f", bakeFile, "-f", tmpFile)
-	if verbose {
-		args = append(args, "--progress", "plain")
-	}
+	// Plain progress keeps captured output parseable for cache hit stats.
+	args = append(args, "--progress", "plain")
 	args = append(args, groupName)
 	return args
 }
 
+// runBakeCommand runs bake and returns cache hit stats parsed from the
+// captured output. Verbose runs stream output and report no stats.
 func runBakeCommand(
 	ctx context.Context,
 	runner compose.CommandRunner,
 	repoRoot string,
 	args []string,
 	verbose bool,
-) error {
+) (bakeCacheStats, error) {
 	if verbose {
-		return runner.Run(ctx, repoRoot, "docker", args...)
+		return bakeCacheStats{}, runner.Run(ctx, repoRoot, "docker", args...)
 	}
 	output, err := runner.RunOutput(ctx, repoRoot, "docker", args...)
 	if err == nil {
-		return nil
+		return parseBakeCacheStats(string(output)), nil
 	}
 	trimmed := strings.TrimSpace(string(output))
 	if trimmed == "" {
-		return err
+		return bakeCacheStats{}, err
 	}
 	if hint := buildxHint(trimmed); hint != "" {
-		return fmt.Errorf("buildx bake failed: %w\n%s\n%s", err, trimmed, hint)
+		return bakeCacheStats{}, fmt.Errorf("buildx bake failed: %w\n%s\n%s", err, trimmed, hint)
 	}
-	return fmt.Errorf("buildx bake failed: %w\n%s", err, trimmed)
+	return bakeCacheStats{}, fmt.Errorf("buildx bake failed: %w\n%s", err, trimmed)
 }
@@ -42,7 +42,7 @@ func TestBuildxHint(t *testing.T) {
 func TestRunBakeCommandVerboseUsesRun(t *testing.T) {
 	runner := &bakeExecRunner{}
 
-	err := runBakeCommand(context.Background(), runner, "/repo", []string{"buildx", "bake"}, true)
+	_, err := runBakeCommand(context.Background(), runner, "/repo", []string{"buildx", "bake"}, true)
 	if err != nil {
 		t.Fatalf("run bake command: %v", err)
 	}
@@ -58,7 +58,7 @@ func TestRunBakeCommandVerbosePropagatesRunError(t *testing.T) {
 	runErr := errors.New("run failed")
 	runner := &bakeExecRunner{runErr: runErr}
 
-	err := runBakeCommand(context.Background(), runner, "/repo", []string{"buildx", "bake"}, true)
+	_, err := runBakeCommand(context.Background(), runner, "/repo", []string{"buildx", "bake"}, true)
 	if !errors.Is(err, runErr) {
 		t.Fatalf("expected run error to propagate, got %v", err)
 	}
@@ -67,7 +67,7 @@ func TestRunBakeCommandVerbosePropagatesRunError(t *testing.T) {
 func TestRunBakeCommandNonVerboseSuccess(t *testing.T) {
 	runner := &bakeExecRunner{output: []byte("ok")}
 
-	err := runBakeCommand(context.Background(), runner, "/repo", []string{"buildx", "bake"}, false)
+	_, err := runBakeCommand(context.Background(), runner, "/repo", []string{"buildx", "bake"}, false)
 	if err != nil {
 		t.Fatalf("run bake command: %v", err)
 	}
@@ -86,7 +86,7 @@ func TestRunBakeCommandNonVerboseReturnsRawErrorOnEmptyOutput(t *testing.T) {
 		outputErr: runErr,
 	}
 
-	err := runBakeCommand(context.Background(), runner, "/repo", []string{"buildx", "bake"}, false)
+	_, err := runBakeCommand(context.Background(), runner, "/repo", []string{"buildx", "bake"}, false)
 	if !errors.Is(err, runErr) {
 		t.Fatalf("expected raw error for empty output, got %v", err)
 	}
@@ -99,7 +99,7 @@ func TestRunBakeCommandNonVerboseWrapsErrorWithOutput(t *testing.T) {
 		outputErr: runErr,
 	}
 
-	err := runBakeCommand(context.Background(), runner, "/repo", []string{"buildx", "bake"}, false)
+	_, err := runBakeCommand(context.Background(), runner, "/repo", []string{"buildx", "bake"}, false)
 	if err == nil {
 		t.Fatalf("expected wrapped error")
 	}
@@ -124,7 +124,7 @@ func TestRunBakeCommandNonVerboseAddsECRHint(t *testing.T) {
 		outputErr: runErr,
 	}
 
-	err := runBakeCommand(context.Background(), runner, "/repo", []string{"buildx", "bake"}, false)
+	_, err := runBakeCommand(context.Background(), runner, "/repo", []string{"buildx", "bake"}, false)
 	if err == nil {
 		t.Fatalf("expected wrapped error")
 	}
@@ -63,6 +63,9 @@ func renderBakeFile(groupName string, targets []bakeTarget) (string, error) {
 		if len(target.Tags) > 0 {
 			b.WriteString(fmt.Sprintf("  tags = %s\n", hclList(target.Tags)))
 		}
+		if len(target.Platforms) > 0 {
+			b.WriteString(fmt.Sprintf("  platforms = %s\n", hclList(target.Platforms)))
+		}
 		outputs := target.Outputs
 		if len(outputs) == 0 {
 			outputs = []string{"type=docker"}
@@ -74,6 +77,15 @@ func renderBakeFile(groupName string, targets []bakeTarget) (string, error) {
 		if len(target.Secrets) > 0 {
 			b.WriteString(fmt.Sprintf("  secret = %s\n", hclList(target.Secrets)))
 		}
+		if len(target.CacheFrom) > 0 {
+			b.WriteString(fmt.Sprintf("  cache-from = %s\n", hclList(target.CacheFrom)))
+		}
+		if len(target.CacheTo) > 0 {
+			b.WriteString(fmt.Sprintf("  cache-to = %s\n", hclList(target.CacheTo)))
+		}
+		if len(target.Attest) > 0 {
+			b.WriteString(fmt.Sprintf("  attest = %s\n", hclList(target.Attest)))
+		}
 		if target.NoCache {
 			b.WriteString("  no-cache = true\n")
 		}
@@ -0,0 +1,87 @@
+// Where: cli/internal/infra/build/bake_hcl_test.go
+// What: Tests for bake HCL rendering, platform-aware outputs and attestations.
+// Why: Keep per-target platforms and multi-platform exporters correct.
+package build
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRenderBakeFileWritesPlatforms(t *testing.T) {
+	content, err := renderBakeFile("esb-functions", []bakeTarget{
+		{Name: "fn-arm", Tags: []string{"arm:latest"}, Platforms: []string{"linux/arm64"}},
+		{Name: "fn-host", Tags: []string{"host:latest"}},
+	})
+	if err != nil {
+		t.Fatalf("render bake file: %v", err)
+	}
+	if !strings.Contains(content, "target \"fn-arm\" {\n  tags = [\"arm:latest\"]\n  platforms = [\"linux/arm64\"]\n") {
+		t.Fatalf("expected platforms for fn-arm, got:\n%s", content)
+	}
+	if strings.Count(content, "platforms = ") != 1 {
+		t.Fatalf("expected host target without platforms, got:\n%s", content)
+	}
+}
+
+func TestRenderBakeFileWritesCacheSettings(t *testing.T) {
+	content, err := renderBakeFile("esb-base", []bakeTarget{{
+		Name:      "lambda-base",
+		CacheFrom: []string{"type=registry,ref=cache:lambda-base"},
+		CacheTo:   []string{"type=registry,ref=cache:lambda-base,mode=max"},
+	}})
+	if err != nil {
+		t.Fatalf("render bake file: %v", err)
+	}
+	for _, want := range []string{
+		"  cache-from = [\"type=registry,ref=cache:lambda-base\"]\n",
+		"  cache-to = [\"type=registry,ref=cache:lambda-base,mode=max\"]\n",
+	} {
+		if !strings.Contains(content, want) {
+			t.Fatalf("expected %q in:\n%s", want, content)
+		}
+	}
+}
+
+func TestResolvePlatformOutputsDropsDockerExporterForMultiPlatform(t *testing.T) {
+	outputs := []string{"type=docker", "type=registry"}
+	single, err := resolvePlatformOutputs(outputs, []string{"linux/arm64"})
+	if err != nil || !reflect.DeepEqual(single, outputs) {
+		t.Fatalf("single platform outputs = %v (%v)", single, err)
+	}
+	multi, err := resolvePlatformOutputs(outputs, []string{"linux/amd64", "linux/arm64"})
+	if err != nil || !reflect.DeepEqual(multi, []string{"type=registry"}) {
+		t.Fatalf("multi platform outputs = %v (%v)", multi, err)
+	}
+	if _, err := resolvePlatformOutputs([]string{"type=docker"}, []string{"linux/amd64", "linux/arm64"}); err == nil {
+		t.Fatalf("expected error without a registry output")
+	}
+}
+
+func TestResolveBakeAttestationsRequiresRegistryOutput(t *testing.T) {
+	t.Setenv("SBOM", "")
+	t.Setenv("PROVENANCE", "")
+	if got := resolveBakeAttestations([]string{"type=docker"}); got != nil {
+		t.Fatalf("expected no attestations for local-only output, got %v", got)
+	}
+	attest := resolveBakeAttestations([]string{"type=docker", "type=registry,registry.insecure=true"})
+	if !reflect.DeepEqual(attest, []string{"type=sbom", "type=provenance,mode=max"}) {
+		t.Fatalf("unexpected attestations: %v", attest)
+	}
+	content, err := renderBakeFile("esb-functions", []bakeTarget{{Name: "fn-hello", Attest: attest}})
+	if err != nil {
+		t.Fatalf("render bake file: %v", err)
+	}
+	if !strings.Contains(content, "  attest = [\"type=sbom\", \"type=provenance,mode=max\"]\n") {
+		t.Fatalf("expected attest in:\n%s", content)
+	}
+
+	t.Setenv("SBOM", "off")
+	if got := resolveBakeAttestations([]string{"type=registry"}); !reflect.DeepEqual(got, []string{"type=provenance,mode=max"}) {
+		t.Fatalf("expected provenance only, got %v", got)
+	}
+	if got := attestationTypes(); !reflect.DeepEqual(got, []string{attestationProvenance}) {
+		t.Fatalf("unexpected attestation types: %v", got)
+	}
+}
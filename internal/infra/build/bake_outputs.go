@@ -32,6 +32,66 @@ func provenanceMode() (string, bool) {
 	}
 }
 
+// Attestation types recorded in the bundle manifest.
+const (
+	attestationSBOM       = "sbom"
+	attestationProvenance = "provenance"
+)
+
+func sbomMode() (string, bool) {
+	value := strings.TrimSpace(os.Getenv("SBOM"))
+	switch strings.ToLower(value) {
+	case "", "1", "true", "on", "yes":
+		return "", true
+	case "0", "false", "off", "no":
+		return "", false
+	default:
+		return value, true
+	}
+}
+
+// attestationTypes lists the attestation types requested for pushed images
+// (SBOM and PROVENANCE env control each one).
+func attestationTypes() []string {
+	var types []string
+	if _, ok := sbomMode(); ok {
+		types = append(types, attestationSBOM)
+	}
+	if _, ok := provenanceMode(); ok {
+		types = append(types, attestationProvenance)
+	}
+	return types
+}
+
+// resolveBakeAttestations returns the attest entries for a target. Only the
+// registry exporter keeps attestations, so local-only targets get none.
+func resolveBakeAttestations(outputs []string) []string {
+	if !hasRegistryOutput(outputs) {
+		return nil
+	}
+	var attest []string
+	if mode, ok := sbomMode(); ok {
+		entry := "type=sbom"
+		if mode != "" {
+			entry += "," + mode
+		}
+		attest = append(attest, entry)
+	}
+	if mode, ok := provenanceMode(); ok {
+		attest = append(attest, "type=provenance,"+mode)
+	}
+	return attest
+}
+
+func hasRegistryOutput(outputs []string) bool {
+	for _, output := range outputs {
+		if output == "type=registry" || strings.HasPrefix(output, "type=registry,") {
+			return true
+		}
+	}
+	return false
+}
+
 func resolveBakeOutputs(registry string, pushToRegistry, includeDocker bool) []string {
 	var outputs []string
 	if includeDocker || !pushToRegistry {
@@ -47,6 +107,28 @@ func resolveBakeOutputs(registry string, pushToRegistry, includeDocker bool) []s
 	return outputs
 }
 
+// resolvePlatformOutputs drops the docker exporter for multi-platform targets
+// because the classic image store cannot load manifest lists.
+func resolvePlatformOutputs(outputs, platforms []string) ([]string, error) {
+	if len(platforms) <= 1 {
+		return outputs, nil
+	}
+	filtered := make([]string, 0, len(outputs))
+	for _, output := range outputs {
+		if output == "type=docker" {
+			continue
+		}
+		filtered = append(filtered, output)
+	}
+	if len(filtered) == 0 {
+		return nil, fmt.Errorf(
+			"multi-platform build (%s) requires a registry to push to",
+			strings.Join(platforms, ", "),
+		)
+	}
+	return filtered, nil
+}
+
 func isInsecureRegistry(registry string) bool {
 	if strings.TrimSpace(registry) == "" {
 		return false
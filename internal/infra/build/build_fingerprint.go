@@ -1,6 +1,6 @@
 // Where: cli/internal/infra/build/build_fingerprint.go
-// What: Build fingerprint helpers for image change detection.
-// Why: Tie cache keys to project/env and generated outputs.
+// What: Per-function build fingerprint helpers for image change detection.
+// Why: Rebuild only the function images whose inputs actually changed.
 package build
 
 import (
@@ -14,105 +14,161 @@ import (
 	"strings"
 
 	"github.com/poruru-code/esb-cli/internal/domain/template"
-	"github.com/poruru-code/esb-cli/internal/infra/staging"
 )
 
-func buildImageFingerprint(
-	outputDir,
-	composeProject,
-	env,
+// Fingerprint components, in the order they are reported by explain mode.
+const (
+	fingerprintCode        = "code"
+	fingerprintLayers      = "layers"
+	fingerprintDockerfile  = "dockerfile"
+	fingerprintBaseImage   = "base_image"
+	fingerprintImageSource = "image_source"
+	fingerprintPlatform    = "platform"
+)
+
+var fingerprintComponentOrder = []string{
+	fingerprintCode,
+	fingerprintLayers,
+	fingerprintDockerfile,
+	fingerprintBaseImage,
+	fingerprintImageSource,
+	fingerprintPlatform,
+}
+
+// functionFingerprint captures the inputs that determine a function image.
+type functionFingerprint struct {
+	Fingerprint string            `json:"fingerprint"`
+	Components  map[string]string `json:"components"`
+	Image       string            `json:"image,omitempty"`
+	Digest      string            `json:"digest,omitempty"`
+}
+
+// functionFingerprints computes a fingerprint per function from its staged
+// code tree, staged layers, rendered Dockerfile (runtime template), base
+// image id, image source digest and target platform.
+func functionFingerprints(
+	outputDir string,
 	baseImageID string,
 	functions []template.FunctionSpec,
 	imageSourceDigests map[string]string,
-) (string, error) {
-	outputHash, err := outputFingerprint(outputDir, functions)
-	if err != nil {
-		return "", err
-	}
-	stageKey := staging.CacheKey(composeProject, env)
-	imageSourceHash := imageSourceFingerprint(functions, imageSourceDigests)
-	seed := fmt.Sprintf("%s:%s:%s:%s", stageKey, strings.TrimSpace(baseImageID), outputHash, imageSourceHash)
-	sum := sha256.Sum256([]byte(seed))
-	return hex.EncodeToString(sum[:4]), nil
-}
-
-func imageSourceFingerprint(functions []template.FunctionSpec, imageSourceDigests map[string]string) string {
-	if len(functions) == 0 {
-		return ""
+	platforms map[string]string,
+) (map[string]functionFingerprint, error) {
+	if strings.TrimSpace(outputDir) == "" {
+		return nil, fmt.Errorf("output dir is required")
 	}
-	entries := make([]string, 0, len(functions))
+	result := make(map[string]functionFingerprint, len(functions))
 	for _, fn := range functions {
-		source := strings.TrimSpace(fn.ImageSource)
-		if source == "" {
+		name := strings.TrimSpace(fn.Name)
+		if name == "" {
 			continue
 		}
-		entries = append(entries, fmt.Sprintf(
-			"%s:%s:%s",
-			strings.TrimSpace(fn.Name),
-			source,
-			strings.TrimSpace(imageSourceDigests[source]),
-		))
-	}
-	if len(entries) == 0 {
-		return ""
+		functionDir := filepath.Join(outputDir, "functions", name)
+		code, err := hashTree(functionDir, func(rel string) bool {
+			return rel == "Dockerfile" || rel == "layers" || strings.HasPrefix(rel, "layers/")
+		})
+		if err != nil {
+			return nil, fmt.Errorf("fingerprint %s code: %w", name, err)
+		}
+		layers, err := hashTree(filepath.Join(functionDir, "layers"), nil)
+		if err != nil {
+			return nil, fmt.Errorf("fingerprint %s layers: %w", name, err)
+		}
+		dockerfile, err := hashTree(filepath.Join(functionDir, "Dockerfile"), nil)
+		if err != nil {
+			return nil, fmt.Errorf("fingerprint %s dockerfile: %w", name, err)
+		}
+		imageSource := ""
+		if source := strings.TrimSpace(fn.ImageSource); source != "" {
+			imageSource = hashString(source + "@" + strings.TrimSpace(imageSourceDigests[source]))
+		}
+		components := map[string]string{
+			fingerprintCode:        code,
+			fingerprintLayers:      layers,
+			fingerprintDockerfile:  dockerfile,
+			fingerprintBaseImage:   hashString(strings.TrimSpace(baseImageID)),
+			fingerprintImageSource: imageSource,
+			fingerprintPlatform:    strings.TrimSpace(platforms[name]),
+		}
+		result[name] = functionFingerprint{
+			Fingerprint: combineFingerprint(components),
+			Components:  components,
+		}
 	}
-	sort.Strings(entries)
-	sum := sha256.Sum256([]byte(strings.Join(entries, "\n")))
-	return hex.EncodeToString(sum[:4])
+	return result, nil
 }
 
-func outputFingerprint(outputDir string, functions []template.FunctionSpec) (string, error) {
-	if strings.TrimSpace(outputDir) == "" {
-		return "", fmt.Errorf("output dir is required")
-	}
-	configDir := filepath.Join(outputDir, "config")
-	paths := []string{
-		filepath.Join(configDir, "functions.yml"),
-		filepath.Join(configDir, "routing.yml"),
-	}
-	for _, path := range paths {
-		if !fileExists(path) {
-			return "", fmt.Errorf("config not found: %s", path)
+// changedFingerprintComponents lists components that differ between two
+// fingerprints, in report order.
+func changedFingerprintComponents(previous, current functionFingerprint) []string {
+	var changed []string
+	for _, key := range fingerprintComponentOrder {
+		if previous.Components[key] != current.Components[key] {
+			changed = append(changed, key)
 		}
 	}
-	for _, fn := range functions {
-		if strings.TrimSpace(fn.Name) == "" {
-			continue
-		}
-		paths = append(paths, filepath.Join(outputDir, "functions", fn.Name))
+	return changed
+}
+
+func combineFingerprint(components map[string]string) string {
+	parts := make([]string, 0, len(fingerprintComponentOrder))
+	for _, key := range fingerprintComponentOrder {
+		parts = append(parts, key+"="+components[key])
 	}
-	return hashPaths(outputDir, paths)
+	return hashString(strings.Join(parts, "\n"))
 }
 
-func hashPaths(baseDir string, paths []string) (string, error) {
-	hasher := sha256.New()
+func hashString(value string) string {
+	if value == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:8])
+}
+
+// hashTree hashes the file or directory at root. Missing paths hash to "".
+// skip receives slash-separated paths relative to root.
+func hashTree(root string, skip func(rel string) bool) (string, error) {
+	info, err := os.Stat(root)
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
 	files := make([]string, 0)
-	for _, path := range paths {
-		info, err := os.Stat(path)
-		if err != nil {
-			return "", err
-		}
-		if info.IsDir() {
-			err = filepath.WalkDir(path, func(entryPath string, entry os.DirEntry, walkErr error) error {
-				if walkErr != nil {
-					return walkErr
-				}
+	if info.IsDir() {
+		err = filepath.WalkDir(root, func(entryPath string, entry os.DirEntry, walkErr error) error {
+			if walkErr != nil {
+				return walkErr
+			}
+			rel, relErr := filepath.Rel(root, entryPath)
+			if relErr != nil {
+				return relErr
+			}
+			rel = filepath.ToSlash(rel)
+			if rel != "." && skip != nil && skip(rel) {
 				if entry.IsDir() {
-					return nil
+					return filepath.SkipDir
 				}
-				files = append(files, entryPath)
 				return nil
-			})
-			if err != nil {
-				return "", err
 			}
-			continue
+			if entry.IsDir() {
+				return nil
+			}
+			files = append(files, entryPath)
+			return nil
+		})
+		if err != nil {
+			return "", err
 		}
-		files = append(files, path)
+	} else {
+		files = append(files, root)
 	}
 	sort.Strings(files)
+
+	hasher := sha256.New()
 	for _, path := range files {
-		rel, err := filepath.Rel(baseDir, path)
+		rel, err := filepath.Rel(root, path)
 		if err != nil {
 			rel = path
 		}
@@ -131,5 +187,5 @@ func hashPaths(baseDir string, paths []string) (string, error) {
 		}
 		_, _ = hasher.Write([]byte{0})
 	}
-	return hex.EncodeToString(hasher.Sum(nil)[:4]), nil
+	return hex.EncodeToString(hasher.Sum(nil)[:8]), nil
 }
@@ -0,0 +1,123 @@
+// Where: cli/internal/infra/build/build_fingerprint_store.go
+// What: Persistent per-function fingerprint store and rebuild decisions.
+// Why: Skip unchanged function images across runs and explain rebuilds.
+package build
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const fingerprintStoreFileName = "build-fingerprints.json"
+
+// fingerprintStore persists the last built fingerprint and digest per function.
+type fingerprintStore struct {
+	path      string
+	Functions map[string]functionFingerprint `json:"functions"`
+}
+
+// loadFingerprintStore reads the store at path. A missing or unreadable
+// store starts empty so a corrupt file only costs a rebuild.
+func loadFingerprintStore(path string) *fingerprintStore {
+	store := &fingerprintStore{path: path, Functions: map[string]functionFingerprint{}}
+	if strings.TrimSpace(path) == "" {
+		return store
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return store
+	}
+	var decoded fingerprintStore
+	if err := json.Unmarshal(data, &decoded); err != nil || decoded.Functions == nil {
+		return store
+	}
+	store.Functions = decoded.Functions
+	return store
+}
+
+func (s *fingerprintStore) lookup(name string) (functionFingerprint, bool) {
+	if s == nil {
+		return functionFingerprint{}, false
+	}
+	entry, ok := s.Functions[name]
+	return entry, ok
+}
+
+func (s *fingerprintStore) record(name string, entry functionFingerprint) {
+	if s == nil {
+		return
+	}
+	s.Functions[name] = entry
+}
+
+func (s *fingerprintStore) save() error {
+	if s == nil || strings.TrimSpace(s.path) == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return fmt.Errorf("create fingerprint store dir: %w", err)
+	}
+	payload, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal fingerprint store: %w", err)
+	}
+	if err := os.WriteFile(s.path, append(payload, '\n'), 0o600); err != nil {
+		return fmt.Errorf("write fingerprint store: %w", err)
+	}
+	return nil
+}
+
+// functionBuildDecision explains whether a function image is rebuilt.
+type functionBuildDecision struct {
+	Function string
+	Rebuild  bool
+	Reason   string
+}
+
+// decideFunctionBuild compares the current fingerprint and image digest with
+// the stored entry. labelMatch covers images built before the store existed.
+func decideFunctionBuild(
+	name string,
+	current functionFingerprint,
+	stored functionFingerprint,
+	hasStored bool,
+	digest string,
+	labelMatch bool,
+	noCache bool,
+) functionBuildDecision {
+	rebuild := func(reason string) functionBuildDecision {
+		return functionBuildDecision{Function: name, Rebuild: true, Reason: reason}
+	}
+	switch {
+	case noCache:
+		return rebuild("--no-cache")
+	case digest == "":
+		return rebuild("image not found")
+	case !hasStored:
+		if labelMatch {
+			return functionBuildDecision{Function: name, Reason: "up-to-date (image label)"}
+		}
+		return rebuild("no previous fingerprint")
+	case stored.Fingerprint != current.Fingerprint:
+		changed := changedFingerprintComponents(stored, current)
+		if len(changed) == 0 {
+			return rebuild("fingerprint changed")
+		}
+		return rebuild("changed: " + strings.Join(changed, ", "))
+	case stored.Digest != "" && stored.Digest != digest:
+		return rebuild(fmt.Sprintf("image digest changed (%s -> %s)", shortDigest(stored.Digest), shortDigest(digest)))
+	default:
+		return functionBuildDecision{Function: name, Reason: "up-to-date"}
+	}
+}
+
+func shortDigest(digest string) string {
+	trimmed := strings.TrimPrefix(strings.TrimSpace(digest), "sha256:")
+	if len(trimmed) > 12 {
+		return trimmed[:12]
+	}
+	return trimmed
+}
@@ -0,0 +1,72 @@
+// Where: cli/internal/infra/build/build_fingerprint_store_test.go
+// What: Tests for the fingerprint store and rebuild decisions.
+// Why: Keep skip/rebuild reasons stable for explain mode.
+package build
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFingerprintStoreRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stage", fingerprintStoreFileName)
+	store := loadFingerprintStore(path)
+	store.record("lambda-a", functionFingerprint{Fingerprint: "abc", Digest: "sha256:1"})
+	if err := store.save(); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	loaded := loadFingerprintStore(path)
+	entry, ok := loaded.lookup("lambda-a")
+	if !ok || entry.Fingerprint != "abc" || entry.Digest != "sha256:1" {
+		t.Fatalf("unexpected entry: %#v (%v)", entry, ok)
+	}
+
+	if err := os.WriteFile(path, []byte("{not json"), 0o600); err != nil {
+		t.Fatalf("write corrupt store: %v", err)
+	}
+	if _, ok := loadFingerprintStore(path).lookup("lambda-a"); ok {
+		t.Fatalf("corrupt store must load empty")
+	}
+}
+
+func TestDecideFunctionBuild(t *testing.T) {
+	stored := functionFingerprint{
+		Fingerprint: "old",
+		Components:  map[string]string{fingerprintCode: "1", fingerprintDockerfile: "a"},
+		Digest:      "sha256:aaaaaaaaaaaaaaaa",
+	}
+	current := functionFingerprint{
+		Fingerprint: "new",
+		Components:  map[string]string{fingerprintCode: "2", fingerprintDockerfile: "a"},
+	}
+	same := stored
+	same.Digest = ""
+
+	tests := []struct {
+		name       string
+		current    functionFingerprint
+		hasStored  bool
+		digest     string
+		labelMatch bool
+		noCache    bool
+		rebuild    bool
+		reason     string
+	}{
+		{name: "no cache", current: same, hasStored: true, digest: "sha256:aaaaaaaaaaaaaaaa", noCache: true, rebuild: true, reason: "--no-cache"},
+		{name: "missing image", current: same, hasStored: true, rebuild: true, reason: "image not found"},
+		{name: "first build", current: current, digest: "sha256:x", rebuild: true, reason: "no previous fingerprint"},
+		{name: "label fallback", current: current, digest: "sha256:x", labelMatch: true, reason: "up-to-date (image label)"},
+		{name: "code changed", current: current, hasStored: true, digest: "sha256:aaaaaaaaaaaaaaaa", rebuild: true, reason: "changed: code"},
+		{name: "digest drift", current: same, hasStored: true, digest: "sha256:bbbbbbbbbbbbbbbb", rebuild: true, reason: "image digest changed (aaaaaaaaaaaa -> bbbbbbbbbbbb)"},
+		{name: "up to date", current: same, hasStored: true, digest: "sha256:aaaaaaaaaaaaaaaa", reason: "up-to-date"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := decideFunctionBuild("lambda-a", tc.current, stored, tc.hasStored, tc.digest, tc.labelMatch, tc.noCache)
+			if got.Rebuild != tc.rebuild || got.Reason != tc.reason {
+				t.Fatalf("decision = %#v, want rebuild=%v reason=%q", got, tc.rebuild, tc.reason)
+			}
+		})
+	}
+}
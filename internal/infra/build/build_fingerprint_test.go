@@ -1,19 +1,18 @@
 // Where: cli/internal/infra/build/build_fingerprint_test.go
-// What: Tests for image build fingerprint calculation.
-// Why: Ensure mutable image-source bases trigger rebuild when upstream digest changes.
+// What: Tests for per-function image build fingerprints.
+// Why: Ensure each function rebuilds only when its own inputs change.
 package build
 
 import (
 	"path/filepath"
+	"reflect"
 	"testing"
 
 	"github.com/poruru-code/esb-cli/internal/domain/template"
 )
 
-func TestBuildImageFingerprintChangesWhenImageSourceDigestChanges(t *testing.T) {
+func TestFunctionFingerprintsChangeWhenImageSourceDigestChanges(t *testing.T) {
 	outputDir := t.TempDir()
-	writeTestFile(t, filepath.Join(outputDir, "config", "functions.yml"), "functions: {}\n")
-	writeTestFile(t, filepath.Join(outputDir, "config", "routing.yml"), "routes: []\n")
 	writeTestFile(t, filepath.Join(outputDir, "functions", "lambda-image", "Dockerfile"), "FROM scratch\n")
 
 	functions := []template.FunctionSpec{
@@ -23,33 +22,51 @@ func TestBuildImageFingerprintChangesWhenImageSourceDigestChanges(t *testing.T)
 		},
 	}
 
-	first, err := buildImageFingerprint(
-		outputDir,
-		"esb-test",
-		"dev",
-		"sha256:base",
-		functions,
-		map[string]string{
-			"public.ecr.aws/example/repo:latest": "public.ecr.aws/example/repo@sha256:111",
-		},
-	)
+	first, err := functionFingerprints(outputDir, "sha256:base", functions, map[string]string{
+		"public.ecr.aws/example/repo:latest": "public.ecr.aws/example/repo@sha256:111",
+	}, nil)
 	if err != nil {
 		t.Fatalf("first fingerprint: %v", err)
 	}
-	second, err := buildImageFingerprint(
-		outputDir,
-		"esb-test",
-		"dev",
-		"sha256:base",
-		functions,
-		map[string]string{
-			"public.ecr.aws/example/repo:latest": "public.ecr.aws/example/repo@sha256:222",
-		},
-	)
+	second, err := functionFingerprints(outputDir, "sha256:base", functions, map[string]string{
+		"public.ecr.aws/example/repo:latest": "public.ecr.aws/example/repo@sha256:222",
+	}, nil)
 	if err != nil {
 		t.Fatalf("second fingerprint: %v", err)
 	}
-	if first == second {
-		t.Fatalf("expected fingerprint change when image-source digest changes, got %q", first)
+	if first["lambda-image"].Fingerprint == second["lambda-image"].Fingerprint {
+		t.Fatalf("expected fingerprint change when image-source digest changes, got %q", first["lambda-image"].Fingerprint)
+	}
+	changed := changedFingerprintComponents(first["lambda-image"], second["lambda-image"])
+	if !reflect.DeepEqual(changed, []string{fingerprintImageSource}) {
+		t.Fatalf("unexpected changed components: %v", changed)
+	}
+}
+
+func TestFunctionFingerprintsIsolateFunctions(t *testing.T) {
+	outputDir := t.TempDir()
+	for _, name := range []string{"lambda-a", "lambda-b"} {
+		writeTestFile(t, filepath.Join(outputDir, "functions", name, "Dockerfile"), "FROM scratch\n")
+		writeTestFile(t, filepath.Join(outputDir, "functions", name, "src", "app.py"), "print('v1')\n")
+		writeTestFile(t, filepath.Join(outputDir, "functions", name, "layers", "common", "lib.py"), "x = 1\n")
+	}
+	functions := []template.FunctionSpec{{Name: "lambda-a"}, {Name: "lambda-b"}}
+
+	before, err := functionFingerprints(outputDir, "sha256:base", functions, nil, nil)
+	if err != nil {
+		t.Fatalf("fingerprint: %v", err)
+	}
+	writeTestFile(t, filepath.Join(outputDir, "functions", "lambda-a", "layers", "common", "lib.py"), "x = 2\n")
+	after, err := functionFingerprints(outputDir, "sha256:base", functions, nil, nil)
+	if err != nil {
+		t.Fatalf("fingerprint: %v", err)
+	}
+
+	if before["lambda-b"].Fingerprint != after["lambda-b"].Fingerprint {
+		t.Fatalf("unchanged function must keep its fingerprint")
+	}
+	changed := changedFingerprintComponents(before["lambda-a"], after["lambda-a"])
+	if !reflect.DeepEqual(changed, []string{fingerprintLayers}) {
+		t.Fatalf("expected only layers to change, got %v", changed)
 	}
 }
@@ -3,8 +3,17 @@
 // Why: Keep generator inputs colocated with generator implementation.
 package build
 
+import (
+	"context"
+
+	"github.com/poruru-code/esb-cli/internal/domain/timeout"
+	"github.com/poruru-code/esb-cli/internal/infra/ui"
+)
+
 // BuildRequest contains parameters for a build operation.
 type BuildRequest struct {
+	// Ctx cancels the build (Ctrl-C). Nil means context.Background.
+	Ctx           context.Context
 	ProjectDir    string
 	ProjectName   string
 	TemplatePath  string
@@ -20,4 +29,28 @@ type BuildRequest struct {
 	BuildImages   bool
 	Bundle        bool
 	Emoji         bool
+	// Functions limits staging and image builds to functions matching these
+	// names or globs. Empty rebuilds every function.
+	Functions []string
+	// BuildCache configures remote BuildKit cache import/export:
+	// "registry=<ref>", "local=<dir>" or "inline". Empty uses only the
+	// builder-local cache.
+	BuildCache string
+	// BundleSignKey signs bundle image registry digests with a key file or
+	// "keyless"; empty leaves the bundle unsigned.
+	BundleSignKey string
+	// Explain prints why each function image is rebuilt or skipped.
+	Explain bool
+	// Timeouts bounds the registry wait, generate, base build and function
+	// build phases.
+	Timeouts timeout.Budget
+	// Events receives phase and image events in JSON output mode.
+	Events ui.EventSink
+}
+
+func (r BuildRequest) requestContext() context.Context {
+	if r.Ctx == nil {
+		return context.Background()
+	}
+	return r.Ctx
 }
@@ -93,6 +93,38 @@ func dockerImageRepoDigests(
 	return digests
 }
 
+// functionImageDigest identifies the current image for a tag: the local
+// image id when loaded, otherwise the registry manifest digest.
+func functionImageDigest(
+	ctx context.Context,
+	runner compose.CommandRunner,
+	contextDir string,
+	imageTag string,
+	registry string,
+) string {
+	if id := dockerImageID(ctx, runner, contextDir, imageTag); id != "" {
+		return id
+	}
+	if runner == nil || strings.TrimSpace(registry) == "" {
+		return ""
+	}
+	out, err := runner.RunOutput(
+		ctx,
+		contextDir,
+		"docker",
+		"buildx",
+		"imagetools",
+		"inspect",
+		"--format",
+		"{{.Manifest.Digest}}",
+		imageTag,
+	)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
 func dockerImageExists(
 	ctx context.Context,
 	runner compose.CommandRunner,
@@ -14,6 +14,7 @@ import (
 
 	"github.com/poruru-code/esb-cli/internal/constants"
 	"github.com/poruru-code/esb-cli/internal/domain/template"
+	"github.com/poruru-code/esb-cli/internal/domain/timeout"
 	"github.com/poruru-code/esb-cli/internal/infra/compose"
 	"github.com/poruru-code/esb-cli/internal/infra/config"
 	"github.com/poruru-code/esb-cli/internal/infra/staging"
@@ -81,6 +82,7 @@ func (b *GoBuilder) Build(request BuildRequest) error {
 		return fmt.Errorf("repo root finder is not configured")
 	}
 	out := resolveBuildOutput(b.Out)
+	ctx := request.requestContext()
 
 	templatePath, err := templategen.ResolveTemplatePath(request.TemplatePath, request.ProjectDir)
 	if err != nil {
@@ -124,12 +126,18 @@ func (b *GoBuilder) Build(request BuildRequest) error {
 		return err
 	}
 	imageLabels := brandingImageLabels(composeProject, request.Env)
+	buildCache, err := ParseBuildCache(request.BuildCache)
+	if err != nil {
+		return err
+	}
+	buildCache.Scope = staging.CacheKey(composeProject, request.Env)
 
 	cfg.Parameters = toAnyMap(defaultGeneratorParameters())
 	for key, value := range request.Parameters {
 		cfg.Parameters[key] = value
 	}
-	phase := newPhaseReporter(request.Verbose, request.Emoji, out)
+	phase := newPhaseReporter(request.Verbose, request.Emoji, out, request.Events).
+		withBudget(ctx, request.Timeouts)
 	if request.Verbose {
 		_, _ = fmt.Fprintln(out, "Generating files...")
 		_, _ = fmt.Fprintf(out, "Using Template: %s\n", templatePath)
@@ -146,7 +154,7 @@ func (b *GoBuilder) Build(request BuildRequest) error {
 	}
 	if request.BuildImages {
 		registryInfo, err = b.resolveBuildRegistryInfo(
-			context.Background(),
+			ctx,
 			repoRoot,
 			composeProject,
 			request,
@@ -155,7 +163,7 @@ func (b *GoBuilder) Build(request BuildRequest) error {
 			return err
 		}
 		if err := ensureBuildxBuilder(
-			context.Background(),
+			ctx,
 			b.Runner,
 			repoRoot,
 			lockRoot,
@@ -169,10 +177,11 @@ func (b *GoBuilder) Build(request BuildRequest) error {
 	}
 
 	var functions []template.FunctionSpec
-	if err := phase.Run("Generate config", func() error {
+	if err := phase.RunBounded("Generate config", timeout.PhaseGenerate, func(ctx context.Context) (bakeCacheStats, error) {
 		generated, err := b.generateAndStageConfig(
 			cfg,
 			templategen.GenerateOptions{
+				Context:         ctx,
 				ProjectRoot:     repoRoot,
 				Out:             out,
 				Registry:        registryInfo.RuntimeRegistry,
@@ -183,13 +192,15 @@ func (b *GoBuilder) Build(request BuildRequest) error {
 				ImageSources:    request.ImageSources,
 				ImageRuntimes:   request.ImageRuntimes,
 				Verbose:         request.Verbose,
+				Functions:       request.Functions,
+				Events:          request.Events,
 			},
 		)
 		if err != nil {
-			return err
+			return bakeCacheStats{}, err
 		}
 		functions = generated
-		return nil
+		return bakeCacheStats{}, nil
 	}); err != nil {
 		return err
 	}
@@ -212,9 +223,13 @@ func (b *GoBuilder) Build(request BuildRequest) error {
 		_ = os.Setenv(constants.BuildArgCAFingerprint, rootFingerprint)
 	}
 
+	platforms, err := template.FunctionPlatforms(functions)
+	if err != nil {
+		return err
+	}
 	lambdaBaseTag := lambdaBaseImageTag(registryInfo.PushRegistry, imageTag)
-	if err := phase.Run("Build base images", func() error {
-		return b.buildBaseImages(baseImageBuildInput{
+	if err := phase.RunBounded("Build base images", timeout.PhaseBaseBuild, func(ctx context.Context) (bakeCacheStats, error) {
+		return b.buildBaseImages(ctx, baseImageBuildInput{
 			RepoRoot:            repoRoot,
 			LockRoot:            lockRoot,
 			RegistryForPush:     registryInfo.PushRegistry,
@@ -225,17 +240,19 @@ func (b *GoBuilder) Build(request BuildRequest) error {
 			Verbose:             request.Verbose,
 			IncludeDockerOutput: includeDockerOutput,
 			LambdaBaseTag:       lambdaBaseTag,
+			Platforms:           template.PlatformList(platforms),
+			Cache:               buildCache,
 			Out:                 out,
 		})
 	}); err != nil {
 		return err
 	}
 
-	baseImageID := dockerImageID(context.Background(), b.Runner, repoRoot, lambdaBaseTag)
+	baseImageID := dockerImageID(ctx, b.Runner, repoRoot, lambdaBaseTag)
 	imageSourceDigests := map[string]string{}
 	if !request.NoCache {
 		imageSourceDigests, err = resolveImageSourceDigests(
-			context.Background(),
+			ctx,
 			b.Runner,
 			repoRoot,
 			functions,
@@ -246,50 +263,65 @@ func (b *GoBuilder) Build(request BuildRequest) error {
 			return err
 		}
 	}
-	imageFingerprint, err := buildImageFingerprint(
+	targets, err := selectFunctions(functions, request.Functions)
+	if err != nil {
+		return err
+	}
+	fingerprints, err := functionFingerprints(
 		cfg.Paths.OutputDir,
-		composeProject,
-		request.Env,
 		baseImageID,
-		functions,
+		targets,
 		imageSourceDigests,
+		platforms,
 	)
 	if err != nil {
 		return err
 	}
-	functionLabels := make(map[string]string, len(imageLabels)+2)
-	for key, value := range imageLabels {
-		functionLabels[key] = value
-	}
-	if imageFingerprint != "" {
-		functionLabels[compose.ESBImageFingerprintLabel] = imageFingerprint
+	stageDir, err := staging.BaseDir(templatePath, composeProject, request.Env)
+	if err != nil {
+		return err
 	}
-	functionLabels[compose.ESBKindLabel] = "function"
+	store := loadFingerprintStore(filepath.Join(stageDir, fingerprintStoreFileName))
+	functionLabels := mergeStringMap(imageLabels, map[string]string{
+		compose.ESBKindLabel: "function",
+	})
 
-	buildCount := 0
-	for range functions {
-		buildCount++
-	}
-	label := fmt.Sprintf("Build function images (%d)", buildCount)
-	if err := phase.Run(label, func() error {
+	label := fmt.Sprintf("Build function images (%d)", len(targets))
+	if err := phase.RunBounded(label, timeout.PhaseFunctionBuild, func(ctx context.Context) (bakeCacheStats, error) {
 		return buildFunctionImages(
-			context.Background(),
+			ctx,
 			b.Runner,
-			repoRoot,
-			lockRoot,
-			cfg.Paths.OutputDir,
-			functions,
-			registryInfo.PushRegistry,
-			imageTag,
-			request.NoCache,
-			request.Verbose,
-			functionLabels,
-			includeDockerOutput,
-			out,
+			functionImageBuildInput{
+				RepoRoot:      repoRoot,
+				LockRoot:      lockRoot,
+				OutputDir:     cfg.Paths.OutputDir,
+				Functions:     targets,
+				Platforms:     platforms,
+				Registry:      registryInfo.PushRegistry,
+				Tag:           imageTag,
+				NoCache:       request.NoCache,
+				Verbose:       request.Verbose,
+				Labels:        functionLabels,
+				IncludeDocker: includeDockerOutput,
+				Cache:         buildCache,
+				Fingerprints:  fingerprints,
+				Store:         store,
+				Explain:       request.Explain,
+				Out:           out,
+			},
 		)
 	}); err != nil {
 		return err
 	}
+	emitFunctionImageEvents(
+		ctx,
+		b.Runner,
+		cfg.Paths.OutputDir,
+		targets,
+		registryInfo.PushRegistry,
+		imageTag,
+		request.Events,
+	)
 
 	// Control plane images are now built separately via `esb build-infra` or docker compose.
 	// Only function images are built during deploy.
@@ -298,7 +330,7 @@ func (b *GoBuilder) Build(request BuildRequest) error {
 			return fmt.Errorf("bundle manifest writer is not configured")
 		}
 		manifestPath, err := b.WriteBundleManifest(
-			context.Background(),
+			ctx,
 			templategen.BundleManifestInput{
 				RepoRoot:        repoRoot,
 				OutputDir:       cfg.Paths.OutputDir,
@@ -311,6 +343,9 @@ func (b *GoBuilder) Build(request BuildRequest) error {
 				Registry:        registryInfo.PushRegistry,
 				ServiceRegistry: registryInfo.ServiceRegistry,
 				Functions:       functions,
+				Platforms:       platforms,
+				Attestations:    attestationTypes(),
+				SignKey:         request.BundleSignKey,
 				Runner:          b.Runner,
 			},
 		)
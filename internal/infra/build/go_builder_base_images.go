@@ -25,12 +25,17 @@ type baseImageBuildInput struct {
 	Verbose             bool
 	IncludeDockerOutput bool
 	LambdaBaseTag       string
-	Out                 io.Writer
+	// Platforms lists every function platform; the lambda base is built
+	// for all of them so each function can build FROM it.
+	Platforms []string
+	Cache     BuildCache
+	Out       io.Writer
 }
 
-func (b *GoBuilder) buildBaseImages(input baseImageBuildInput) error {
+func (b *GoBuilder) buildBaseImages(ctx context.Context, input baseImageBuildInput) (bakeCacheStats, error) {
 	out := resolveBuildOutput(input.Out)
-	return withBuildLock(input.LockRoot, "base-images", func() error {
+	var stats bakeCacheStats
+	err := withBuildLock(ctx, input.LockRoot, "base-images", func() error {
 		proxyArgs := dockerBuildArgMap()
 		commonDir := filepath.Join(input.RepoRoot, "services", "common")
 
@@ -39,7 +44,7 @@ func (b *GoBuilder) buildBaseImages(input baseImageBuildInput) error {
 
 		osBaseTag := fmt.Sprintf("%s-os-base:latest", meta.ImagePrefix)
 		if !input.NoCache && dockerImageHasLabelValue(
-			context.Background(),
+			ctx,
 			b.Runner,
 			input.RepoRoot,
 			osBaseTag,
@@ -54,7 +59,7 @@ func (b *GoBuilder) buildBaseImages(input baseImageBuildInput) error {
 
 		pythonBaseTag := fmt.Sprintf("%s-python-base:latest", meta.ImagePrefix)
 		if !input.NoCache && dockerImageHasLabelValue(
-			context.Background(),
+			ctx,
 			b.Runner,
 			input.RepoRoot,
 			pythonBaseTag,
@@ -67,13 +72,22 @@ func (b *GoBuilder) buildBaseImages(input baseImageBuildInput) error {
 			}
 		}
 
+		lambdaOutputs, err := resolvePlatformOutputs(
+			resolveBakeOutputs(input.RegistryForPush, true, input.IncludeDockerOutput),
+			input.Platforms,
+		)
+		if err != nil {
+			return err
+		}
 		lambdaTarget := bakeTarget{
-			Name:    "lambda-base",
-			Tags:    []string{input.LambdaBaseTag},
-			Outputs: resolveBakeOutputs(input.RegistryForPush, true, input.IncludeDockerOutput),
-			Labels:  input.ImageLabels,
-			Args:    proxyArgs,
-			NoCache: input.NoCache,
+			Name:      "lambda-base",
+			Tags:      []string{input.LambdaBaseTag},
+			Platforms: input.Platforms,
+			Outputs:   lambdaOutputs,
+			Labels:    input.ImageLabels,
+			Args:      proxyArgs,
+			Attest:    resolveBakeAttestations(lambdaOutputs),
+			NoCache:   input.NoCache,
 		}
 
 		baseImageLabels := map[string]string{
@@ -105,8 +119,9 @@ func (b *GoBuilder) buildBaseImages(input baseImageBuildInput) error {
 			)
 		}
 
-		return runBakeGroup(
-			context.Background(),
+		applyBakeCache(baseTargets, input.Cache)
+		result, err := runBakeGroup(
+			ctx,
 			b.Runner,
 			input.RepoRoot,
 			input.LockRoot,
@@ -114,7 +129,10 @@ func (b *GoBuilder) buildBaseImages(input baseImageBuildInput) error {
 			baseTargets,
 			input.Verbose,
 		)
+		stats = result
+		return err
 	})
+	return stats, err
 }
 
 func newRootCABaseTarget(
@@ -127,13 +145,16 @@ func newRootCABaseTarget(
 	proxyArgs map[string]string,
 	input baseImageBuildInput,
 ) bakeTarget {
+	outputs := resolveBakeOutputs(input.RegistryForPush, false, input.IncludeDockerOutput)
 	return bakeTarget{
 		Name:       name,
 		Context:    commonDir,
 		Dockerfile: filepath.Join(commonDir, dockerfileName),
 		Tags:       []string{tag},
-		Outputs:    resolveBakeOutputs(input.RegistryForPush, false, input.IncludeDockerOutput),
+		Outputs:    outputs,
 		Labels:     labels,
+		// Local-only outputs get none; the bundle manifest records why.
+		Attest: resolveBakeAttestations(outputs),
 		Args: mergeStringMap(proxyArgs, map[string]string{
 			constants.BuildArgCAFingerprint: input.RootFingerprint,
 			"ROOT_CA_MOUNT_ID":              meta.RootCAMountID,
@@ -17,6 +17,11 @@ import (
 	"github.com/poruru-code/esb-cli/internal/meta"
 )
 
+// ResolveRootCAPath returns the ESB root CA used to sign local TLS endpoints.
+func ResolveRootCAPath() (string, error) {
+	return resolveRootCAPath()
+}
+
 func resolveRootCAPath() (string, error) {
 	value, err := envutil.GetHostEnv(constants.HostSuffixCACertPath)
 	if err != nil {
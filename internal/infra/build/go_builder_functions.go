@@ -14,6 +14,7 @@ import (
 
 	"github.com/poruru-code/esb-cli/internal/domain/template"
 	"github.com/poruru-code/esb-cli/internal/infra/compose"
+	"github.com/poruru-code/esb-cli/internal/infra/ui"
 	"github.com/poruru-code/esb-cli/internal/meta"
 )
 
@@ -26,15 +27,25 @@ func resolveImageSourceDigests(
 	out io.Writer,
 ) (map[string]string, error) {
 	uniqueSources := make(map[string]struct{})
+	resolved := make(map[string]string)
 	for _, fn := range functions {
 		source := strings.TrimSpace(fn.ImageSource)
 		if source == "" {
 			continue
 		}
+		if fn.ImageBuild != nil {
+			// Source builds are fingerprinted from their context, not pulled.
+			digest, err := sourceBuildDigest(*fn.ImageBuild)
+			if err != nil {
+				return nil, fmt.Errorf("fingerprint %s image build: %w", fn.Name, err)
+			}
+			resolved[source] = digest
+			continue
+		}
 		uniqueSources[source] = struct{}{}
 	}
 	if len(uniqueSources) == 0 {
-		return map[string]string{}, nil
+		return resolved, nil
 	}
 	sources := make([]string, 0, len(uniqueSources))
 	for source := range uniqueSources {
@@ -42,7 +53,6 @@ func resolveImageSourceDigests(
 	}
 	sort.Strings(sources)
 
-	resolved := make(map[string]string, len(sources))
 	for _, source := range sources {
 		digest, err := resolveImageSourceDigest(ctx, runner, contextDir, source, verbose, out)
 		if err != nil {
@@ -124,88 +134,251 @@ func joinRegistry(registry, image string) string {
 	return registry + "/" + image
 }
 
+type functionImageBuildInput struct {
+	RepoRoot      string
+	LockRoot      string
+	OutputDir     string
+	Functions     []template.FunctionSpec
+	Platforms     map[string]string
+	Registry      string
+	Tag           string
+	NoCache       bool
+	Verbose       bool
+	Labels        map[string]string
+	IncludeDocker bool
+	Cache         BuildCache
+	// Fingerprints holds the current per-function fingerprints; Store holds
+	// the fingerprints and digests recorded by the previous build.
+	Fingerprints map[string]functionFingerprint
+	Store        *fingerprintStore
+	// Explain prints why each function image is rebuilt or skipped.
+	Explain bool
+	Out     io.Writer
+}
+
 func buildFunctionImages(
 	ctx context.Context,
 	runner compose.CommandRunner,
-	repoRoot string,
-	lockRoot string,
-	outputDir string,
-	functions []template.FunctionSpec,
-	registry string,
-	tag string,
-	noCache bool,
-	verbose bool,
-	labels map[string]string,
-	includeDocker bool,
-	out io.Writer,
-) error {
-	out = resolveBuildOutput(out)
-	if verbose {
+	input functionImageBuildInput,
+) (bakeCacheStats, error) {
+	out := resolveBuildOutput(input.Out)
+	if input.Verbose {
 		_, _ = fmt.Fprintln(out, "Building function images...")
 	}
 	proxyArgs := dockerBuildArgMap()
-	expectedFingerprint := strings.TrimSpace(labels[compose.ESBImageFingerprintLabel])
-	bakeTargets := make([]bakeTarget, 0, len(functions))
-	for _, fn := range functions {
-		if verbose {
+	bakeTargets := make([]bakeTarget, 0, len(input.Functions))
+	built := make([]template.FunctionSpec, 0, len(input.Functions))
+	decisions := make([]functionBuildDecision, 0, len(input.Functions))
+	for _, fn := range input.Functions {
+		if input.Verbose {
 			_, _ = fmt.Fprintf(out, "  Building image for %s...\n", fn.Name)
 		}
 		if strings.TrimSpace(fn.Name) == "" {
-			return fmt.Errorf("function name is required")
+			return bakeCacheStats{}, fmt.Errorf("function name is required")
 		}
 		if strings.TrimSpace(fn.ImageName) == "" {
-			return fmt.Errorf("function image name is required for %s", fn.Name)
+			return bakeCacheStats{}, fmt.Errorf("function image name is required for %s", fn.Name)
 		}
-		functionDir := filepath.Join(outputDir, "functions", fn.Name)
+		functionDir := filepath.Join(input.OutputDir, "functions", fn.Name)
 		dockerfile := filepath.Join(functionDir, "Dockerfile")
 		if _, err := os.Stat(dockerfile); err != nil {
-			return fmt.Errorf("dockerfile not found: %w", err)
+			return bakeCacheStats{}, fmt.Errorf("dockerfile not found: %w", err)
 		}
-		if err := writeFunctionDockerignore(outputDir, functionDir); err != nil {
-			return err
+		if err := writeFunctionDockerignore(input.OutputDir, functionDir); err != nil {
+			return bakeCacheStats{}, err
 		}
 
-		imageTag := fmt.Sprintf("%s-%s:%s", meta.ImagePrefix, fn.ImageName, tag)
-		imageTag = joinRegistry(registry, imageTag)
+		imageTag := functionImageTag(input.Registry, fn.ImageName, input.Tag)
+		current := input.Fingerprints[fn.Name]
+		current.Image = imageTag
+		labels := mergeStringMap(input.Labels, nil)
+		if current.Fingerprint != "" {
+			labels[compose.ESBImageFingerprintLabel] = current.Fingerprint
+		}
 
-		skipBuild := false
-		if !noCache && expectedFingerprint != "" {
-			if dockerImageHasLabelValue(ctx, runner, outputDir, imageTag, compose.ESBImageFingerprintLabel, expectedFingerprint) {
-				skipBuild = true
-				if verbose {
-					_, _ = fmt.Fprintf(out, "  Skipping %s (up-to-date)\n", fn.Name)
-				}
+		decision := functionBuildDecision{Function: fn.Name, Rebuild: true, Reason: "no fingerprint"}
+		if current.Fingerprint != "" {
+			digest := ""
+			labelMatch := false
+			if !input.NoCache {
+				digest = functionImageDigest(ctx, runner, input.OutputDir, imageTag, input.Registry)
+				labelMatch = dockerImageHasLabelValue(
+					ctx, runner, input.OutputDir, imageTag, compose.ESBImageFingerprintLabel, current.Fingerprint,
+				)
+			}
+			stored, hasStored := input.Store.lookup(fn.Name)
+			decision = decideFunctionBuild(fn.Name, current, stored, hasStored, digest, labelMatch, input.NoCache)
+			if !decision.Rebuild {
+				current.Digest = digest
+				input.Store.record(fn.Name, current)
 			}
 		}
-		if !skipBuild {
-			target := bakeTarget{
-				Name:       "fn-" + fn.ImageName,
-				Context:    outputDir,
-				Dockerfile: dockerfile,
-				Tags:       []string{imageTag},
-				Outputs:    resolveBakeOutputs(registry, true, includeDocker),
-				Labels:     labels,
-				Args:       proxyArgs,
-				NoCache:    noCache,
+		decisions = append(decisions, decision)
+		if !decision.Rebuild {
+			if input.Verbose {
+				_, _ = fmt.Fprintf(out, "  Skipping %s (up-to-date)\n", fn.Name)
 			}
-			bakeTargets = append(bakeTargets, target)
+			continue
+		}
+		var contexts map[string]string
+		if fn.ImageBuild != nil {
+			source := sourceBuildTarget(fn, proxyArgs, functionTargetPlatforms(input.Platforms, fn.Name), input.NoCache)
+			bakeTargets = append(bakeTargets, source)
+			contexts = map[string]string{template.SourceImageRef(fn): "target:" + source.Name}
 		}
+		outputs := resolveBakeOutputs(input.Registry, true, input.IncludeDocker)
+		bakeTargets = append(bakeTargets, bakeTarget{
+			Name:       "fn-" + fn.ImageName,
+			Context:    input.OutputDir,
+			Dockerfile: dockerfile,
+			Tags:       []string{imageTag},
+			Platforms:  functionTargetPlatforms(input.Platforms, fn.Name),
+			Outputs:    outputs,
+			Labels:     labels,
+			Args:       proxyArgs,
+			Contexts:   contexts,
+			Attest:     resolveBakeAttestations(outputs),
+			NoCache:    input.NoCache,
+		})
+		built = append(built, fn)
+	}
+	if input.Explain {
+		writeBuildExplain(out, decisions)
 	}
 
-	if len(bakeTargets) > 0 {
-		if err := runBakeGroup(
-			ctx,
-			runner,
-			repoRoot,
-			lockRoot,
-			"esb-functions",
-			bakeTargets,
-			verbose,
-		); err != nil {
-			return err
+	if len(bakeTargets) == 0 {
+		return bakeCacheStats{}, input.Store.save()
+	}
+	applyBakeCache(bakeTargets, input.Cache)
+	stats, err := runBakeGroup(
+		ctx,
+		runner,
+		input.RepoRoot,
+		input.LockRoot,
+		"esb-functions",
+		bakeTargets,
+		input.Verbose,
+	)
+	if err != nil {
+		return stats, err
+	}
+	for _, fn := range built {
+		current, ok := input.Fingerprints[fn.Name]
+		if !ok || current.Fingerprint == "" {
+			continue
 		}
+		current.Image = functionImageTag(input.Registry, fn.ImageName, input.Tag)
+		current.Digest = functionImageDigest(ctx, runner, input.OutputDir, current.Image, input.Registry)
+		input.Store.record(fn.Name, current)
+	}
+	return stats, input.Store.save()
+}
+
+// sourceBuildTarget builds the SAM Metadata image of fn. It is only consumed
+// through the function target's named context, so nothing is exported.
+func sourceBuildTarget(fn template.FunctionSpec, proxyArgs map[string]string, platforms []string, noCache bool) bakeTarget {
+	return bakeTarget{
+		Name:       "src-" + fn.ImageName,
+		Context:    fn.ImageBuild.Context,
+		Dockerfile: fn.ImageBuild.Dockerfile,
+		Platforms:  platforms,
+		Outputs:    []string{"type=cacheonly"},
+		Args:       mergeStringMap(proxyArgs, fn.ImageBuild.Args),
+		NoCache:    noCache,
+	}
+}
+
+// sourceBuildDigest fingerprints a Metadata image build from its context
+// tree, Dockerfile, build args and tag.
+func sourceBuildDigest(build template.ImageBuildSpec) (string, error) {
+	contextHash, err := hashTree(build.Context, nil)
+	if err != nil {
+		return "", err
+	}
+	dockerfileHash, err := hashTree(build.Dockerfile, nil)
+	if err != nil {
+		return "", err
+	}
+	parts := []string{"context=" + contextHash, "dockerfile=" + dockerfileHash, "tag=" + build.Tag}
+	keys := make([]string, 0, len(build.Args))
+	for key := range build.Args {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		parts = append(parts, "arg:"+key+"="+build.Args[key])
+	}
+	return hashString(strings.Join(parts, "\n")), nil
+}
+
+// writeBuildExplain prints the rebuild decision for each function image.
+func writeBuildExplain(out io.Writer, decisions []functionBuildDecision) {
+	_, _ = fmt.Fprintln(out, "Function image build plan:")
+	for _, decision := range decisions {
+		action := "skip"
+		if decision.Rebuild {
+			action = "rebuild"
+		}
+		_, _ = fmt.Fprintf(out, "  %s: %s (%s)\n", decision.Function, action, decision.Reason)
+	}
+}
+
+// functionTargetPlatforms returns the bake platforms for a function, or nil
+// to build for the host platform.
+func functionTargetPlatforms(platforms map[string]string, name string) []string {
+	platform := strings.TrimSpace(platforms[name])
+	if platform == "" {
+		return nil
+	}
+	return []string{platform}
+}
+
+// selectFunctions keeps the functions matching names (exact or glob),
+// preserving order. Empty names selects every function.
+func selectFunctions(functions []template.FunctionSpec, names []string) ([]template.FunctionSpec, error) {
+	selector, err := template.NewFunctionSelector(names)
+	if err != nil {
+		return nil, err
+	}
+	if selector.Empty() {
+		return functions, nil
+	}
+	selected := make([]template.FunctionSpec, 0, len(functions))
+	for _, fn := range functions {
+		if selector.Match(fn.Name) {
+			selected = append(selected, fn)
+		}
+	}
+	return selected, nil
+}
+
+func functionImageTag(registry, imageName, tag string) string {
+	return joinRegistry(registry, fmt.Sprintf("%s-%s:%s", meta.ImagePrefix, imageName, tag))
+}
+
+// emitFunctionImageEvents reports built function images with their local
+// image IDs and repo digests when an event sink is configured.
+func emitFunctionImageEvents(
+	ctx context.Context,
+	runner compose.CommandRunner,
+	contextDir string,
+	functions []template.FunctionSpec,
+	registry string,
+	tag string,
+	events ui.EventSink,
+) {
+	if events == nil {
+		return
+	}
+	for _, fn := range functions {
+		imageTag := functionImageTag(registry, fn.ImageName, tag)
+		events.Emit(ui.EventImage, ui.ImageEvent{
+			Function: fn.Name,
+			Image:    imageTag,
+			ID:       dockerImageID(ctx, runner, contextDir, imageTag),
+			Digests:  dockerImageRepoDigests(ctx, runner, contextDir, imageTag),
+		})
 	}
-	return nil
 }
 
 func writeFunctionDockerignore(contextDir, functionDir string) error {
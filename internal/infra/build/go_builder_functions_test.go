@@ -1,15 +1,17 @@
 // Where: cli/internal/infra/build/go_builder_functions_test.go
-// What: Tests for image-source digest resolution used in build skip decisions.
+// What: Tests for image-source digest resolution and function image bake targets.
 // Why: Ensure mutable image tags are refreshed and digest-pinned refs avoid unnecessary pulls.
 package build
 
 import (
 	"context"
 	"io"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/poruru-code/esb-cli/internal/domain/template"
+	"github.com/poruru-code/esb-cli/internal/meta"
 )
 
 func TestResolveImageSourceDigestsPullsMutableSourceAndUsesRepoDigest(t *testing.T) {
@@ -83,3 +85,70 @@ func containsDockerPullCall(calls []commandCall, source string) bool {
 	}
 	return false
 }
+
+func TestSelectFunctionsKeepsNamedFunctions(t *testing.T) {
+	functions := []template.FunctionSpec{{Name: "a"}, {Name: "b"}, {Name: "c"}}
+	if got, err := selectFunctions(functions, nil); err != nil || len(got) != 3 {
+		t.Fatalf("expected all functions, got %d (%v)", len(got), err)
+	}
+	got, err := selectFunctions(functions, []string{"c", "a", "missing"})
+	if err != nil || len(got) != 2 || got[0].Name != "a" || got[1].Name != "c" {
+		t.Fatalf("unexpected selection: %#v (%v)", got, err)
+	}
+	functions = append(functions, template.FunctionSpec{Name: "api-users"})
+	got, err = selectFunctions(functions, []string{"api-*"})
+	if err != nil || len(got) != 1 || got[0].Name != "api-users" {
+		t.Fatalf("unexpected glob selection: %#v (%v)", got, err)
+	}
+}
+
+func TestBuildFunctionImagesBuildsMetadataSourceImage(t *testing.T) {
+	repoRoot := t.TempDir()
+	writeTestFile(t, filepath.Join(repoRoot, "docker-bake.hcl"), "# bake stub\n")
+	outputDir := filepath.Join(repoRoot, "out")
+	writeTestFile(t, filepath.Join(outputDir, "functions", "lambda-image", "Dockerfile"), "FROM x\n")
+	sourceDir := filepath.Join(repoRoot, "src")
+	writeTestFile(t, filepath.Join(sourceDir, "Dockerfile"), "FROM public.ecr.aws/lambda/python:3.12\n")
+
+	fn := template.FunctionSpec{
+		Name:      "lambda-image",
+		ImageName: "lambda-image",
+		ImageBuild: &template.ImageBuildSpec{
+			Context:    sourceDir,
+			Dockerfile: filepath.Join(sourceDir, "Dockerfile"),
+			Args:       map[string]string{"APP_VERSION": "1"},
+			Tag:        "v1",
+		},
+	}
+	fn.ImageSource = template.SourceImageRef(fn)
+
+	runner := &recordRunner{}
+	digests, err := resolveImageSourceDigests(context.Background(), runner, outputDir, []template.FunctionSpec{fn}, false, io.Discard)
+	if err != nil {
+		t.Fatalf("resolve image source digests: %v", err)
+	}
+	if digests[fn.ImageSource] == "" || containsDockerPullCall(runner.calls, fn.ImageSource) {
+		t.Fatalf("expected context digest without pull, got %v", digests)
+	}
+
+	if _, err := buildFunctionImages(context.Background(), runner, functionImageBuildInput{
+		RepoRoot:  repoRoot,
+		LockRoot:  t.TempDir(),
+		OutputDir: outputDir,
+		Functions: []template.FunctionSpec{fn},
+		Tag:       "latest",
+		Out:       io.Discard,
+	}); err != nil {
+		t.Fatalf("build function images: %v", err)
+	}
+	for _, want := range []string{
+		`target "src-lambda-image"`,
+		`output = ["type=cacheonly"]`,
+		`"APP_VERSION" = "1"`,
+		`"` + meta.ImagePrefix + `-source-lambda-image:v1" = "target:src-lambda-image"`,
+	} {
+		if !hasBakeFileContaining(runner.bakeFiles, want) {
+			t.Fatalf("expected %q in bake file: %v", want, runner.bakeFiles)
+		}
+	}
+}
@@ -4,14 +4,22 @@
 package build
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"syscall"
+	"time"
 )
 
-func withBuildLock(lockRoot, name string, fn func() error) error {
+const buildLockPollInterval = 200 * time.Millisecond
+
+// withBuildLock runs fn while holding an exclusive flock on
+// <lockRoot>/.lock-<name>. Waiting for the lock stops when ctx is cancelled,
+// and the lock is released whenever fn returns.
+func withBuildLock(ctx context.Context, lockRoot, name string, fn func() error) error {
 	key := strings.TrimSpace(name)
 	if key == "" {
 		return fn()
@@ -27,12 +35,29 @@ func withBuildLock(lockRoot, name string, fn func() error) error {
 	if err != nil {
 		return err
 	}
-	defer func() {
-		_ = syscall.Flock(int(lockFile.Fd()), syscall.LOCK_UN)
-		_ = lockFile.Close()
-	}()
-	if err := syscall.Flock(int(lockFile.Fd()), syscall.LOCK_EX); err != nil {
-		return err
+	defer func() { _ = lockFile.Close() }()
+	if err := acquireFileLock(ctx, lockFile); err != nil {
+		return fmt.Errorf("acquire %s lock: %w", key, err)
 	}
+	defer func() { _ = syscall.Flock(int(lockFile.Fd()), syscall.LOCK_UN) }()
 	return fn()
 }
+
+// acquireFileLock polls a non-blocking flock so a held lock does not block
+// cancellation.
+func acquireFileLock(ctx context.Context, lockFile *os.File) error {
+	for {
+		err := syscall.Flock(int(lockFile.Fd()), syscall.LOCK_EX|syscall.LOCK_NB)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, syscall.EWOULDBLOCK) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(buildLockPollInterval):
+		}
+	}
+}
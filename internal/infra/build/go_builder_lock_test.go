@@ -0,0 +1,45 @@
+package build
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestWithBuildLockStopsWaitingWhenCancelled(t *testing.T) {
+	lockRoot := t.TempDir()
+	held := make(chan struct{})
+	release := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		done <- withBuildLock(context.Background(), lockRoot, "bake", func() error {
+			close(held)
+			<-release
+			return nil
+		})
+	}()
+	<-held
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	called := false
+	err := withBuildLock(ctx, lockRoot, "bake", func() error {
+		called = true
+		return nil
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline error, got %v", err)
+	}
+	if called {
+		t.Fatal("fn must not run without the lock")
+	}
+
+	close(release)
+	if err := <-done; err != nil {
+		t.Fatalf("holder: %v", err)
+	}
+	if err := withBuildLock(context.Background(), lockRoot, "bake", func() error { return nil }); err != nil {
+		t.Fatalf("lock should be free after holder returns: %v", err)
+	}
+}
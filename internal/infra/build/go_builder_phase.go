@@ -4,16 +4,25 @@
 package build
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"time"
+
+	"github.com/poruru-code/esb-cli/internal/domain/timeout"
+	"github.com/poruru-code/esb-cli/internal/infra/ui"
 )
 
 type phaseReporter struct {
 	verbose bool
 	emoji   bool
 	out     io.Writer
+	events  ui.EventSink
+	// ctx and timeouts bound phases started with RunBounded.
+	ctx      context.Context
+	timeouts timeout.Budget
 }
 
 func resolveBuildOutput(out io.Writer) io.Writer {
@@ -23,24 +32,87 @@ func resolveBuildOutput(out io.Writer) io.Writer {
 	return os.Stdout
 }
 
-func newPhaseReporter(verbose, emoji bool, out io.Writer) phaseReporter {
-	return phaseReporter{verbose: verbose, emoji: emoji, out: resolveBuildOutput(out)}
+func newPhaseReporter(verbose, emoji bool, out io.Writer, events ui.EventSink) phaseReporter {
+	return phaseReporter{
+		verbose: verbose,
+		emoji:   emoji,
+		out:     resolveBuildOutput(out),
+		events:  events,
+		ctx:     context.Background(),
+	}
+}
+
+// withBudget returns a reporter whose bounded phases run under ctx and the
+// per-phase budgets.
+func (p phaseReporter) withBudget(ctx context.Context, timeouts timeout.Budget) phaseReporter {
+	p.ctx = ctx
+	p.timeouts = timeouts
+	return p
 }
 
 func (p phaseReporter) Run(label string, fn func() error) error {
+	return p.RunWithCache(label, func() (bakeCacheStats, error) {
+		return bakeCacheStats{}, fn()
+	})
+}
+
+// RunBounded runs fn with its context bounded by the budget of budgetPhase.
+// A phase that runs out of budget is reported as timed out.
+func (p phaseReporter) RunBounded(
+	label string,
+	budgetPhase timeout.Phase,
+	fn func(context.Context) (bakeCacheStats, error),
+) error {
+	return p.RunWithCache(label, func() (bakeCacheStats, error) {
+		var stats bakeCacheStats
+		err := timeout.Run(p.ctx, p.timeouts, budgetPhase, func(ctx context.Context) error {
+			var err error
+			stats, err = fn(ctx)
+			return err
+		})
+		return stats, err
+	})
+}
+
+// RunWithCache runs a bake phase and appends its cache hit ratio to the
+// summary line when steps were observed.
+func (p phaseReporter) RunWithCache(label string, fn func() (bakeCacheStats, error)) error {
 	start := time.Now()
-	err := fn()
-	if p.verbose {
-		return err
-	}
+	stats, err := fn()
 	duration := time.Since(start)
 	ok := err == nil
 	status := "ok"
-	if !ok {
+	eventStatus := status
+	var exceeded *timeout.ExceededError
+	switch {
+	case errors.As(err, &exceeded):
+		status = "timed out"
+		eventStatus = "timeout"
+	case !ok:
 		status = "failed"
+		eventStatus = status
+	}
+	if p.events != nil {
+		p.events.Emit(ui.EventPhase, ui.PhaseEvent{
+			Label:       label,
+			Status:      eventStatus,
+			DurationMs:  duration.Milliseconds(),
+			CacheSteps:  stats.Steps,
+			CacheCached: stats.Cached,
+		})
+	}
+	if p.verbose {
+		return err
 	}
 	prefix := p.prefix(ok)
-	_, _ = fmt.Fprintf(p.out, "%s%s ... %s (%s)\n", prefix, label, status, formatDuration(duration))
+	detail := formatDuration(duration)
+	if cache := stats.String(); cache != "" {
+		detail += ", " + cache
+	}
+	if exceeded != nil {
+		detail += fmt.Sprintf(", %s budget %s exceeded", exceeded.Phase, exceeded.Budget)
+	}
+	_, _ = fmt.Fprintf(p.out, "%s%s ... %s (%s)\n", prefix, label, status, detail)
 	return err
 }
 
@@ -0,0 +1,94 @@
+// Where: cli/internal/infra/build/go_builder_phase_test.go
+// What: Tests for build phase reporting.
+// Why: Keep phase timing events available in both verbose and summary modes.
+package build
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/poruru-code/esb-cli/internal/domain/timeout"
+	"github.com/poruru-code/esb-cli/internal/infra/ui"
+)
+
+type recordingEventSink struct {
+	names  []string
+	events []any
+}
+
+func (r *recordingEventSink) Emit(name string, data any) {
+	r.names = append(r.names, name)
+	r.events = append(r.events, data)
+}
+
+func TestPhaseReporterEmitsPhaseEvents(t *testing.T) {
+	for _, verbose := range []bool{false, true} {
+		sink := &recordingEventSink{}
+		var out bytes.Buffer
+		phase := newPhaseReporter(verbose, false, &out, sink)
+
+		_ = phase.Run("Generate config", func() error { return nil })
+		_ = phase.Run("Build base images", func() error { return errors.New("boom") })
+
+		if len(sink.events) != 2 {
+			t.Fatalf("verbose=%v: expected 2 events, got %d", verbose, len(sink.events))
+		}
+		first, ok := sink.events[0].(ui.PhaseEvent)
+		if !ok || sink.names[0] != ui.EventPhase || first.Label != "Generate config" || first.Status != "ok" {
+			t.Fatalf("verbose=%v: unexpected first event %#v", verbose, sink.events[0])
+		}
+		second := sink.events[1].(ui.PhaseEvent)
+		if second.Status != "failed" {
+			t.Fatalf("verbose=%v: expected failed status, got %#v", verbose, second)
+		}
+		if verbose && out.Len() != 0 {
+			t.Fatalf("verbose mode should not print phase summaries, got %q", out.String())
+		}
+	}
+}
+
+func TestPhaseReporterRunWithCacheReportsHitRatio(t *testing.T) {
+	sink := &recordingEventSink{}
+	var out bytes.Buffer
+	phase := newPhaseReporter(false, false, &out, sink)
+
+	_ = phase.RunWithCache("Build function images (2)", func() (bakeCacheStats, error) {
+		return bakeCacheStats{Steps: 4, Cached: 3}, nil
+	})
+
+	if !bytes.Contains(out.Bytes(), []byte(", cache 3/4 (75%))")) {
+		t.Fatalf("expected cache ratio in summary, got %q", out.String())
+	}
+	event := sink.events[0].(ui.PhaseEvent)
+	if event.CacheSteps != 4 || event.CacheCached != 3 {
+		t.Fatalf("unexpected phase event: %#v", event)
+	}
+}
+
+func TestPhaseReporterRunBoundedReportsExceededBudget(t *testing.T) {
+	sink := &recordingEventSink{}
+	var out bytes.Buffer
+	budget := timeout.Budget{Phases: map[timeout.Phase]time.Duration{timeout.PhaseBaseBuild: 10 * time.Millisecond}}
+	phase := newPhaseReporter(false, false, &out, sink).withBudget(context.Background(), budget)
+
+	err := phase.RunBounded("Build base images", timeout.PhaseBaseBuild, func(ctx context.Context) (bakeCacheStats, error) {
+		<-ctx.Done()
+		return bakeCacheStats{}, ctx.Err()
+	})
+
+	var exceeded *timeout.ExceededError
+	if !errors.As(err, &exceeded) || exceeded.Phase != timeout.PhaseBaseBuild {
+		t.Fatalf("expected base_build budget error, got %v", err)
+	}
+	if !strings.Contains(out.String(), "[fail] Build base images ... timed out (") ||
+		!strings.Contains(out.String(), "base_build budget 10ms exceeded") {
+		t.Fatalf("unexpected summary: %q", out.String())
+	}
+	if event := sink.events[0].(ui.PhaseEvent); event.Status != "timeout" {
+		t.Fatalf("expected timeout status, got %#v", event)
+	}
+}
@@ -5,6 +5,7 @@ package build
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
@@ -15,6 +16,8 @@ import (
 	"github.com/poruru-code/esb-cli/internal/constants"
 )
 
+var errRegistryNotResponding = errors.New("registry not responding")
+
 func isLocalRegistryHost(host string) bool {
 	switch strings.ToLower(strings.TrimSpace(host)) {
 	case "registry", "localhost", "127.0.0.1":
@@ -63,7 +66,7 @@ func resolveHostRegistryAddress() (string, bool) {
 	return fmt.Sprintf("127.0.0.1:%s", port), false
 }
 
-func waitForRegistry(registry string, timeout time.Duration) error {
+func waitForRegistry(ctx context.Context, registry string, timeout time.Duration) error {
 	if strings.TrimSpace(os.Getenv("ESB_REGISTRY_WAIT")) == "0" {
 		return nil
 	}
@@ -75,7 +78,7 @@ func waitForRegistry(registry string, timeout time.Duration) error {
 	client := registryWaitHTTPClient(trimmed)
 	deadline := time.Now().Add(timeout)
 	for time.Now().Before(deadline) {
-		req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 		if err != nil {
 			return fmt.Errorf("create registry request: %w", err)
 		}
@@ -86,9 +89,13 @@ func waitForRegistry(registry string, timeout time.Duration) error {
 				return nil
 			}
 		}
-		time.Sleep(500 * time.Millisecond)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(500 * time.Millisecond):
+		}
 	}
-	return fmt.Errorf("registry not responding at %s", url)
+	return fmt.Errorf("%w at %s", errRegistryNotResponding, url)
 }
 
 func registryWaitHTTPClient(registry string) *http.Client {
@@ -5,12 +5,13 @@ package build
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
-	"time"
 
 	"github.com/poruru-code/esb-cli/internal/constants"
+	"github.com/poruru-code/esb-cli/internal/domain/timeout"
 	"github.com/poruru-code/esb-cli/internal/infra/envutil"
 )
 
@@ -101,7 +102,14 @@ func (b *GoBuilder) resolveBuildRegistryInfo(
 				}
 			}
 
-			if err := waitForRegistry(hostRegistryAddr, 30*time.Second); err != nil {
+			wait := request.Timeouts.For(timeout.PhaseRegistryWait)
+			if wait <= 0 {
+				wait = timeout.DefaultRegistryWait
+			}
+			if err := waitForRegistry(ctx, hostRegistryAddr, wait); err != nil {
+				if errors.Is(err, errRegistryNotResponding) {
+					return buildRegistryInfo{}, timeout.Exceeded(timeout.PhaseRegistryWait, wait, err)
+				}
 				return buildRegistryInfo{}, err
 			}
 		}
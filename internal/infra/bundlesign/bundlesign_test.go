@@ -0,0 +1,274 @@
+// Where: cli/internal/infra/bundlesign/bundlesign_test.go
+// What: Tests for bundle image signing and verification.
+// Why: Verification must fail closed on missing, tampered or foreign signatures.
+package bundlesign
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeKeyPair(t *testing.T, dir, name string) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshal key: %v", err)
+	}
+	pubDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("marshal public key: %v", err)
+	}
+	keyPath := filepath.Join(dir, name+".key")
+	pubPath := filepath.Join(dir, name+".pub")
+	if err := os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("write key: %v", err)
+	}
+	if err := os.WriteFile(pubPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}), 0o600); err != nil {
+		t.Fatalf("write public key: %v", err)
+	}
+	return keyPath, pubPath
+}
+
+func writeSignedBundle(t *testing.T, keyPath string) string {
+	t.Helper()
+	bundleDir := filepath.Join(t.TempDir(), "bundle")
+	if err := os.MkdirAll(bundleDir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	manifest := `{"images": [
+  {"name": "registry:5010/esb-lambda-hello:latest", "digest": "sha256:111", "manifest_digest": "sha256:aaa"},
+  {"name": "alpine:latest", "digest": "sha256:222", "manifest_digest": "sha256:bbb"},
+  {"name": "esb-os-base:latest", "digest": "sha256:333"}
+]}`
+	manifestPath := filepath.Join(bundleDir, "manifest.json")
+	if err := os.WriteFile(manifestPath, []byte(manifest), 0o600); err != nil {
+		t.Fatalf("write manifest: %v", err)
+	}
+	signer, err := NewSigner(keyPath)
+	if err != nil {
+		t.Fatalf("NewSigner: %v", err)
+	}
+	if _, err := WriteSignatures(t.Context(), bundleDir, signer, []Image{
+		{Name: "registry:5010/esb-lambda-hello:latest", Digest: "sha256:aaa"},
+		{Name: "alpine:latest", Digest: "sha256:bbb"},
+	}, ""); err != nil {
+		t.Fatalf("WriteSignatures: %v", err)
+	}
+	return manifestPath
+}
+
+func TestVerifyBundleAcceptsSignedBundle(t *testing.T) {
+	keyPath, pubPath := writeKeyPair(t, t.TempDir(), "cosign")
+	manifestPath := writeSignedBundle(t, keyPath)
+	verified, err := VerifyBundle(manifestPath, pubPath)
+	if err != nil {
+		t.Fatalf("VerifyBundle: %v", err)
+	}
+	if len(verified) != 2 || verified[0] != (Image{Name: "registry:5010/esb-lambda-hello:latest", Digest: "sha256:aaa"}) {
+		t.Fatalf("unexpected verified images: %+v", verified)
+	}
+
+	payload, err := os.ReadFile(filepath.Join(filepath.Dir(manifestPath), SignaturesDirName, "000-sha256-aaa.payload"))
+	if err != nil {
+		t.Fatalf("read payload: %v", err)
+	}
+	var claims simpleSigning
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		t.Fatalf("unmarshal payload: %v", err)
+	}
+	if claims.Critical.Identity.DockerReference != "registry:5010/esb-lambda-hello" ||
+		claims.Critical.Image.DockerManifestDigest != "sha256:aaa" ||
+		claims.Critical.Type != "cosign container image signature" {
+		t.Fatalf("unexpected payload: %s", payload)
+	}
+}
+
+func TestVerifyBundleFailsClosed(t *testing.T) {
+	keyDir := t.TempDir()
+	keyPath, pubPath := writeKeyPair(t, keyDir, "cosign")
+	_, otherPub := writeKeyPair(t, keyDir, "other")
+
+	manifestPath := writeSignedBundle(t, keyPath)
+	if _, err := VerifyBundle(manifestPath, otherPub); err == nil || !strings.Contains(err.Error(), "invalid signature") {
+		t.Fatalf("expected foreign key to fail, got %v", err)
+	}
+
+	manifestPath = writeSignedBundle(t, keyPath)
+	tampered := `{"images": [{"name": "registry:5010/esb-lambda-hello:latest", "digest": "sha256:111", "manifest_digest": "sha256:ccc"}]}`
+	if err := os.WriteFile(manifestPath, []byte(tampered), 0o600); err != nil {
+		t.Fatalf("write manifest: %v", err)
+	}
+	if _, err := VerifyBundle(manifestPath, pubPath); err == nil || !strings.Contains(err.Error(), "no signature for") {
+		t.Fatalf("expected tampered digest to fail, got %v", err)
+	}
+
+	if err := os.RemoveAll(filepath.Join(filepath.Dir(manifestPath), SignaturesDirName)); err != nil {
+		t.Fatalf("remove signatures: %v", err)
+	}
+	if _, err := VerifyBundle(manifestPath, pubPath); err == nil || !strings.Contains(err.Error(), "read bundle signatures") {
+		t.Fatalf("expected missing signatures to fail, got %v", err)
+	}
+}
+
+func TestVerifyBundleRejectsOldIndexSchema(t *testing.T) {
+	keyPath, pubPath := writeKeyPair(t, t.TempDir(), "cosign")
+	manifestPath := writeSignedBundle(t, keyPath)
+	indexPath := filepath.Join(filepath.Dir(manifestPath), SignaturesDirName, IndexFileName)
+	if err := os.WriteFile(indexPath, []byte(`{"schema_version": "2", "signatures": []}`), 0o600); err != nil {
+		t.Fatalf("write index: %v", err)
+	}
+	if _, err := VerifyBundle(manifestPath, pubPath); err == nil || !strings.Contains(err.Error(), "re-sign the bundle") {
+		t.Fatalf("expected schema mismatch error, got %v", err)
+	}
+}
+
+func TestNewSignerRejectsUnsupportedKeys(t *testing.T) {
+	dir := t.TempDir()
+	encrypted := filepath.Join(dir, "cosign.key")
+	block := pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED SIGSTORE PRIVATE KEY", Bytes: []byte("x")})
+	if err := os.WriteFile(encrypted, block, 0o600); err != nil {
+		t.Fatalf("write key: %v", err)
+	}
+	if _, err := NewSigner(encrypted); err == nil || !strings.Contains(err.Error(), "password protected") {
+		t.Fatalf("expected encrypted key error, got %v", err)
+	}
+	if _, err := NewSigner(filepath.Join(dir, "missing.key")); err == nil {
+		t.Fatalf("expected missing key error")
+	}
+}
+
+// fakeRegistry records blobs and manifests pushed through the OCI
+// distribution API.
+type fakeRegistry struct {
+	blobs     map[string][]byte
+	manifests map[string][]byte
+}
+
+func (r *fakeRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	switch {
+	case req.Method == http.MethodPost && strings.HasSuffix(req.URL.Path, "/blobs/uploads/"):
+		w.Header().Set("Location", req.URL.Path+"upload-1")
+		w.WriteHeader(http.StatusAccepted)
+	case req.Method == http.MethodPut && strings.Contains(req.URL.Path, "/blobs/uploads/"):
+		sum := sha256.Sum256(body)
+		digest := fmt.Sprintf("sha256:%x", sum)
+		if req.URL.Query().Get("digest") != digest {
+			http.Error(w, "digest mismatch", http.StatusBadRequest)
+			return
+		}
+		r.blobs[digest] = body
+		w.WriteHeader(http.StatusCreated)
+	case req.Method == http.MethodPut && strings.Contains(req.URL.Path, "/manifests/"):
+		r.manifests[req.URL.Path] = body
+		w.WriteHeader(http.StatusCreated)
+	default:
+		http.NotFound(w, req)
+	}
+}
+
+func TestWriteSignaturesKeylessAttachesToLocalRegistry(t *testing.T) {
+	registry := &fakeRegistry{blobs: map[string][]byte{}, manifests: map[string][]byte{}}
+	server := httptest.NewServer(registry)
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+	image := Image{Name: host + "/esb-lambda-hello:latest", Digest: "sha256:aaa"}
+
+	signer, err := NewSigner(KeylessKey)
+	if err != nil {
+		t.Fatalf("NewSigner: %v", err)
+	}
+	bundleDir := t.TempDir()
+	if _, err := WriteSignatures(t.Context(), bundleDir, signer, []Image{
+		image,
+		{Name: "alpine:latest", Digest: "sha256:bbb"},
+	}, host+"/"); err != nil {
+		t.Fatalf("WriteSignatures: %v", err)
+	}
+
+	index, err := readIndex(filepath.Join(bundleDir, SignaturesDirName))
+	if err != nil {
+		t.Fatalf("readIndex: %v", err)
+	}
+	if index.Key != "keyless" || index.PublicKey != KeylessPublicKeyFileName {
+		t.Fatalf("unexpected index key fields: %+v", index)
+	}
+	if got := index.Signatures[0].Attached; got != host+"/esb-lambda-hello:sha256-aaa.sig" {
+		t.Fatalf("unexpected attached ref %q", got)
+	}
+	if got := index.Signatures[1].Attached; got != "" {
+		t.Fatalf("external image signature must not be attached, got %q", got)
+	}
+
+	raw, ok := registry.manifests["/v2/esb-lambda-hello/manifests/sha256-aaa.sig"]
+	if !ok {
+		t.Fatalf("signature manifest not pushed: %v", registry.manifests)
+	}
+	var manifest ociManifest
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		t.Fatalf("unmarshal manifest: %v", err)
+	}
+	if len(manifest.Layers) != 1 {
+		t.Fatalf("unexpected layers: %s", raw)
+	}
+	layer := manifest.Layers[0]
+	payload := registry.blobs[layer.Digest]
+	sig, err := os.ReadFile(filepath.Join(bundleDir, SignaturesDirName, index.Signatures[0].Signature))
+	if err != nil {
+		t.Fatalf("read signature: %v", err)
+	}
+	if layer.Annotations[cosignSignatureAnnotation] != string(sig) {
+		t.Fatalf("layer annotation does not carry the signature: %s", raw)
+	}
+	want, err := Payload(image)
+	if err != nil {
+		t.Fatalf("Payload: %v", err)
+	}
+	if string(payload) != string(want) {
+		t.Fatalf("unexpected pushed payload: %s", payload)
+	}
+	if _, ok := registry.blobs[manifest.Config.Digest]; !ok {
+		t.Fatalf("config blob not pushed")
+	}
+
+	pubPath := filepath.Join(bundleDir, SignaturesDirName, KeylessPublicKeyFileName)
+	manifestPath := filepath.Join(bundleDir, "manifest.json")
+	bundleManifest := fmt.Sprintf(`{"images": [{"name": %q, "digest": "sha256:111", "manifest_digest": "sha256:aaa"}]}`, image.Name)
+	if err := os.WriteFile(manifestPath, []byte(bundleManifest), 0o600); err != nil {
+		t.Fatalf("write manifest: %v", err)
+	}
+	if _, err := VerifyBundle(manifestPath, pubPath); err != nil {
+		t.Fatalf("VerifyBundle: %v", err)
+	}
+}
+
+func TestWriteSignaturesKeylessRequiresLocalRegistry(t *testing.T) {
+	signer, err := NewSigner(KeylessKey)
+	if err != nil {
+		t.Fatalf("NewSigner: %v", err)
+	}
+	images := []Image{{Name: "ghcr.io/acme/esb-lambda-hello:latest", Digest: "sha256:aaa"}}
+	for _, registry := range []string{"", "ghcr.io/acme/"} {
+		if _, err := WriteSignatures(t.Context(), t.TempDir(), signer, images, registry); err == nil ||
+			!strings.Contains(err.Error(), "keyless signing requires a local registry") {
+			t.Fatalf("registry %q: expected local registry error, got %v", registry, err)
+		}
+	}
+}
@@ -0,0 +1,295 @@
+// Where: cli/internal/infra/bundlesign/sign.go
+// What: Cosign-compatible signing of bundle image digests.
+// Why: Prove that the digests listed in a bundle manifest came from our pipeline.
+package bundlesign
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// KeylessKey selects an ephemeral signing key instead of a key file.
+	KeylessKey = "keyless"
+
+	// SignaturesDirName is the directory next to bundle/manifest.json that
+	// holds the signatures.
+	SignaturesDirName = "signatures"
+	// IndexFileName lists every signed image in SignaturesDirName.
+	IndexFileName = "index.json"
+	// KeylessPublicKeyFileName holds the ephemeral public key in keyless mode.
+	KeylessPublicKeyFileName = "keyless.pub"
+
+	indexSchemaVersion = "3"
+	signatureType      = "cosign container image signature"
+	keyModeFile        = "file"
+	keyModeKeyless     = "keyless"
+)
+
+// Image is a bundle image reference to sign or verify. Digest is the
+// registry manifest digest, the digest `cosign sign` would sign.
+type Image struct {
+	Name   string
+	Digest string
+}
+
+// Signer signs simple-signing payloads with an ECDSA P-256 key.
+type Signer struct {
+	key     *ecdsa.PrivateKey
+	keyless bool
+}
+
+// NewSigner loads the key file at keyPath, or creates an ephemeral key when
+// keyPath is KeylessKey. Keyless mode stands in for Fulcio/Rekor in local
+// development and attaches its signatures to the local registry.
+func NewSigner(keyPath string) (*Signer, error) {
+	trimmed := strings.TrimSpace(keyPath)
+	if trimmed == "" {
+		return nil, fmt.Errorf("signing key is required")
+	}
+	if trimmed == KeylessKey {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("generate keyless signing key: %w", err)
+		}
+		return &Signer{key: key, keyless: true}, nil
+	}
+	key, err := loadPrivateKey(trimmed)
+	if err != nil {
+		return nil, err
+	}
+	return &Signer{key: key}, nil
+}
+
+// Keyless reports whether the signer uses an ephemeral key.
+func (s *Signer) Keyless() bool {
+	return s.keyless
+}
+
+// Sign returns the base64 ASN.1 ECDSA signature over sha256(payload), the
+// format `cosign verify-blob --key` accepts.
+func (s *Signer) Sign(payload []byte) (string, error) {
+	sum := sha256.Sum256(payload)
+	sig, err := s.key.Sign(rand.Reader, sum[:], crypto.SHA256)
+	if err != nil {
+		return "", fmt.Errorf("sign payload: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(sig), nil
+}
+
+// PublicKeyPEM returns the PEM-encoded public key of the signer.
+func (s *Signer) PublicKeyPEM() ([]byte, error) {
+	der, err := x509.MarshalPKIXPublicKey(&s.key.PublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("marshal public key: %w", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), nil
+}
+
+// simpleSigning is the cosign "simple signing" payload format.
+type simpleSigning struct {
+	Critical simpleSigningCritical `json:"critical"`
+	Optional map[string]string     `json:"optional"`
+}
+
+type simpleSigningCritical struct {
+	Identity simpleSigningIdentity `json:"identity"`
+	Image    simpleSigningImage    `json:"image"`
+	Type     string                `json:"type"`
+}
+
+type simpleSigningIdentity struct {
+	DockerReference string `json:"docker-reference"`
+}
+
+type simpleSigningImage struct {
+	DockerManifestDigest string `json:"docker-manifest-digest"`
+}
+
+// Payload builds the cosign simple-signing payload for an image digest.
+func Payload(image Image) ([]byte, error) {
+	if strings.TrimSpace(image.Digest) == "" {
+		return nil, fmt.Errorf("digest is required to sign %s", image.Name)
+	}
+	return json.Marshal(simpleSigning{Critical: signedClaims(image)})
+}
+
+func signedClaims(image Image) simpleSigningCritical {
+	return simpleSigningCritical{
+		Identity: simpleSigningIdentity{DockerReference: Repository(image.Name)},
+		Image:    simpleSigningImage{DockerManifestDigest: image.Digest},
+		Type:     signatureType,
+	}
+}
+
+// signatureIndex is written to signatures/index.json.
+type signatureIndex struct {
+	SchemaVersion string           `json:"schema_version"`
+	Key           string           `json:"key"`
+	PublicKey     string           `json:"public_key,omitempty"`
+	Signatures    []signatureEntry `json:"signatures"`
+}
+
+type signatureEntry struct {
+	Name   string `json:"name"`
+	Digest string `json:"digest"`
+	// CosignTag is the tag cosign would use to attach the signature in a registry.
+	CosignTag string `json:"cosign_tag"`
+	// Attached is the registry ref the signature was pushed to (keyless).
+	Attached  string `json:"attached,omitempty"`
+	Payload   string `json:"payload"`
+	Signature string `json:"signature"`
+}
+
+// WriteSignatures signs every image and writes <payload>, <sig> and
+// index.json under bundleDir/signatures. In keyless mode the ephemeral public
+// key is written as keyless.pub and the signatures of images in registry,
+// which must be a local registry, are attached there under their cosign tag.
+func WriteSignatures(
+	ctx context.Context,
+	bundleDir string,
+	signer *Signer,
+	images []Image,
+	registry string,
+) (string, error) {
+	if signer == nil {
+		return "", fmt.Errorf("signer is required")
+	}
+	registryHost := ""
+	if signer.Keyless() {
+		host, err := localRegistryHost(registry)
+		if err != nil {
+			return "", err
+		}
+		registryHost = host
+	}
+	dir := filepath.Join(bundleDir, SignaturesDirName)
+	if err := os.RemoveAll(dir); err != nil {
+		return "", fmt.Errorf("reset signatures dir: %w", err)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("create signatures dir: %w", err)
+	}
+
+	index := signatureIndex{SchemaVersion: indexSchemaVersion, Key: keyModeFile}
+	if signer.Keyless() {
+		pub, err := signer.PublicKeyPEM()
+		if err != nil {
+			return "", err
+		}
+		if err := os.WriteFile(filepath.Join(dir, KeylessPublicKeyFileName), pub, 0o644); err != nil {
+			return "", fmt.Errorf("write keyless public key: %w", err)
+		}
+		index.Key = keyModeKeyless
+		index.PublicKey = KeylessPublicKeyFileName
+	}
+
+	for i, image := range images {
+		payload, err := Payload(image)
+		if err != nil {
+			return "", err
+		}
+		sig, err := signer.Sign(payload)
+		if err != nil {
+			return "", fmt.Errorf("sign %s: %w", image.Name, err)
+		}
+		base := signatureFileBase(image.Digest)
+		// Two names can share a manifest digest, so prefix the position.
+		payloadFile := fmt.Sprintf("%03d-%s.payload", i, base)
+		sigFile := fmt.Sprintf("%03d-%s.sig", i, base)
+		if err := os.WriteFile(filepath.Join(dir, payloadFile), payload, 0o644); err != nil {
+			return "", fmt.Errorf("write signature payload: %w", err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, sigFile), []byte(sig), 0o644); err != nil {
+			return "", fmt.Errorf("write signature: %w", err)
+		}
+		entry := signatureEntry{
+			Name:      image.Name,
+			Digest:    image.Digest,
+			CosignTag: base + ".sig",
+			Payload:   payloadFile,
+			Signature: sigFile,
+		}
+		if registryHost != "" && referenceHost(image.Name) == registryHost {
+			attached, err := attachSignature(ctx, image, entry.CosignTag, payload, sig)
+			if err != nil {
+				return "", err
+			}
+			entry.Attached = attached
+		}
+		index.Signatures = append(index.Signatures, entry)
+	}
+
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("marshal signature index: %w", err)
+	}
+	path := filepath.Join(dir, IndexFileName)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", fmt.Errorf("write signature index: %w", err)
+	}
+	return path, nil
+}
+
+// Repository strips the tag or digest from an image reference.
+func Repository(name string) string {
+	name = strings.TrimSpace(name)
+	if at := strings.Index(name, "@"); at != -1 {
+		name = name[:at]
+	}
+	slash := strings.LastIndex(name, "/")
+	if colon := strings.LastIndex(name, ":"); colon > slash {
+		name = name[:colon]
+	}
+	return name
+}
+
+// signatureFileBase follows cosign's sha256-<hex> tag convention.
+func signatureFileBase(digest string) string {
+	return strings.Replace(strings.TrimSpace(digest), ":", "-", 1)
+}
+
+func loadPrivateKey(path string) (*ecdsa.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read signing key: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("signing key %s is not PEM encoded", path)
+	}
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		key, err := x509.ParseECPrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parse signing key %s: %w", path, err)
+		}
+		return key, nil
+	case "PRIVATE KEY":
+		parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parse signing key %s: %w", path, err)
+		}
+		key, ok := parsed.(*ecdsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("signing key %s must be an ECDSA key", path)
+		}
+		return key, nil
+	case "ENCRYPTED SIGSTORE PRIVATE KEY", "ENCRYPTED COSIGN PRIVATE KEY":
+		return nil, fmt.Errorf("signing key %s is password protected; use an unencrypted PKCS#8 or EC private key", path)
+	default:
+		return nil, fmt.Errorf("signing key %s has unsupported PEM type %q", path, block.Type)
+	}
+}
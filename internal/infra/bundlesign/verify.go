@@ -0,0 +1,158 @@
+// Where: cli/internal/infra/bundlesign/verify.go
+// What: Signature verification for bundle manifests.
+// Why: Refuse bundles whose registry manifest digests were not signed by the trusted key.
+package bundlesign
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// bundleManifestImages is the subset of bundle/manifest.json needed to verify.
+type bundleManifestImages struct {
+	Images []struct {
+		Name           string `json:"name"`
+		ManifestDigest string `json:"manifest_digest"`
+	} `json:"images"`
+}
+
+// VerifyBundle checks that every image in the bundle manifest with a
+// registry manifest digest has a signature in the sibling signatures
+// directory made by the key at publicKeyPath, and returns those images.
+// Images without a manifest digest (local-only images) are not signed and
+// not returned. Any missing, mismatched or invalid signature is an error.
+func VerifyBundle(manifestPath, publicKeyPath string) ([]Image, error) {
+	pub, err := loadPublicKey(publicKeyPath)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("read bundle manifest: %w", err)
+	}
+	var manifest bundleManifestImages
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("parse bundle manifest %s: %w", manifestPath, err)
+	}
+
+	dir := filepath.Join(filepath.Dir(manifestPath), SignaturesDirName)
+	index, err := readIndex(dir)
+	if err != nil {
+		return nil, err
+	}
+	byImage := make(map[Image]signatureEntry, len(index.Signatures))
+	for _, entry := range index.Signatures {
+		byImage[Image{Name: entry.Name, Digest: entry.Digest}] = entry
+	}
+	verified := make([]Image, 0, len(manifest.Images))
+	for _, raw := range manifest.Images {
+		if strings.TrimSpace(raw.ManifestDigest) == "" {
+			continue
+		}
+		image := Image{Name: raw.Name, Digest: raw.ManifestDigest}
+		entry, ok := byImage[image]
+		if !ok {
+			return nil, fmt.Errorf("no signature for %s (%s)", image.Name, image.Digest)
+		}
+		if err := verifyEntry(dir, pub, image, entry); err != nil {
+			return nil, err
+		}
+		verified = append(verified, image)
+	}
+	if len(verified) == 0 {
+		return nil, fmt.Errorf("bundle manifest %s lists no signed images", manifestPath)
+	}
+	return verified, nil
+}
+
+func verifyEntry(dir string, pub *ecdsa.PublicKey, image Image, entry signatureEntry) error {
+	payload, err := readSignatureFile(dir, entry.Payload)
+	if err != nil {
+		return err
+	}
+	var got simpleSigning
+	if err := json.Unmarshal(payload, &got); err != nil {
+		return fmt.Errorf("parse signature payload for %s: %w", image.Name, err)
+	}
+	if got.Critical != signedClaims(image) {
+		return fmt.Errorf("signature payload for %s does not match digest %s", image.Name, image.Digest)
+	}
+
+	encoded, err := readSignatureFile(dir, entry.Signature)
+	if err != nil {
+		return err
+	}
+	sig, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(encoded)))
+	if err != nil {
+		return fmt.Errorf("decode signature for %s: %w", image.Name, err)
+	}
+	sum := sha256.Sum256(payload)
+	if !ecdsa.VerifyASN1(pub, sum[:], sig) {
+		return fmt.Errorf("invalid signature for %s (%s)", image.Name, image.Digest)
+	}
+	return nil
+}
+
+func readIndex(dir string) (signatureIndex, error) {
+	data, err := os.ReadFile(filepath.Join(dir, IndexFileName))
+	if err != nil {
+		return signatureIndex{}, fmt.Errorf("read bundle signatures: %w", err)
+	}
+	var index signatureIndex
+	if err := json.Unmarshal(data, &index); err != nil {
+		return signatureIndex{}, fmt.Errorf("parse bundle signatures: %w", err)
+	}
+	if index.SchemaVersion != indexSchemaVersion {
+		return signatureIndex{}, fmt.Errorf(
+			"unsupported bundle signatures schema %q (want %q); re-sign the bundle with --sign-key",
+			index.SchemaVersion, indexSchemaVersion)
+	}
+	return index, nil
+}
+
+// readSignatureFile reads a file named in index.json, refusing paths that
+// escape the signatures directory.
+func readSignatureFile(dir, name string) ([]byte, error) {
+	clean := filepath.Clean(strings.TrimSpace(name))
+	if clean == "." || filepath.IsAbs(clean) || strings.HasPrefix(clean, "..") || strings.ContainsAny(clean, `/\`) {
+		return nil, fmt.Errorf("invalid signature file name %q", name)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, clean))
+	if err != nil {
+		return nil, fmt.Errorf("read signature file: %w", err)
+	}
+	return data, nil
+}
+
+func loadPublicKey(path string) (*ecdsa.PublicKey, error) {
+	trimmed := strings.TrimSpace(path)
+	if trimmed == "" {
+		return nil, fmt.Errorf("verification public key is required")
+	}
+	data, err := os.ReadFile(trimmed)
+	if err != nil {
+		return nil, fmt.Errorf("read verification key: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, fmt.Errorf("verification key %s must be a PEM PUBLIC KEY", trimmed)
+	}
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse verification key %s: %w", trimmed, err)
+	}
+	key, ok := parsed.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("verification key %s must be an ECDSA key", trimmed)
+	}
+	return key, nil
+}
@@ -0,0 +1,92 @@
+// Where: cli/internal/infra/compose/gateway.go
+// What: Gateway container discovery helpers.
+// Why: Share gateway and service port discovery between deploy, invoke and logs.
+package compose
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+)
+
+// SelectGatewayContainer returns the preferred gateway container, optionally
+// scoped to composeProject. Running containers win, then the lowest name.
+// ok is false when no gateway container exists.
+func SelectGatewayContainer(
+	ctx context.Context,
+	client DockerClient,
+	composeProject string,
+) (container.Summary, bool, error) {
+	filterArgs := filters.NewArgs()
+	filterArgs.Add("label", fmt.Sprintf("%s=gateway", ComposeServiceLabel))
+	if strings.TrimSpace(composeProject) != "" {
+		filterArgs.Add("label", fmt.Sprintf("%s=%s", ComposeProjectLabel, composeProject))
+	}
+	containers, err := client.ContainerList(ctx, container.ListOptions{All: true, Filters: filterArgs})
+	if err != nil {
+		return container.Summary{}, false, fmt.Errorf("list containers: %w", err)
+	}
+	if len(containers) == 0 {
+		return container.Summary{}, false, nil
+	}
+	sort.SliceStable(containers, func(i, j int) bool {
+		iRunning := strings.EqualFold(containers[i].State, "running")
+		jRunning := strings.EqualFold(containers[j].State, "running")
+		if iRunning != jRunning {
+			return iRunning
+		}
+		return PrimaryContainerName(containers[i].Names) < PrimaryContainerName(containers[j].Names)
+	})
+	return containers[0], true, nil
+}
+
+// PublishedPort returns the host port bound to privatePort, or 0 when the
+// port is not published.
+func PublishedPort(summary container.Summary, privatePort int) int {
+	for _, port := range summary.Ports {
+		if int(port.PrivatePort) == privatePort && port.PublicPort != 0 {
+			return int(port.PublicPort)
+		}
+	}
+	return 0
+}
+
+// DiscoverServicePort finds the stack that owns the preferred gateway and
+// returns the host port published for the first DefaultPortMappings entry
+// keyed by envVar. It returns 0 when no gateway or published port exists.
+func DiscoverServicePort(
+	ctx context.Context,
+	client DockerClient,
+	composeProject string,
+	envVar string,
+) (int, error) {
+	gateway, ok, err := SelectGatewayContainer(ctx, client, composeProject)
+	if err != nil || !ok {
+		return 0, err
+	}
+	project := strings.TrimSpace(gateway.Labels[ComposeProjectLabel])
+	filterArgs := filters.NewArgs()
+	filterArgs.Add("label", fmt.Sprintf("%s=%s", ComposeProjectLabel, project))
+	containers, err := client.ContainerList(ctx, container.ListOptions{Filters: filterArgs})
+	if err != nil {
+		return 0, fmt.Errorf("list containers: %w", err)
+	}
+	for _, mapping := range DefaultPortMappings {
+		if mapping.EnvVar != envVar {
+			continue
+		}
+		for _, ctr := range containers {
+			if strings.TrimSpace(ctr.Labels[ComposeServiceLabel]) != mapping.Service {
+				continue
+			}
+			if port := PublishedPort(ctr, mapping.ContainerPort); port != 0 {
+				return port, nil
+			}
+		}
+	}
+	return 0, nil
+}
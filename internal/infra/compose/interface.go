@@ -6,8 +6,13 @@ import (
 	"io"
 	"os"
 	"os/exec"
+	"time"
 )
 
+// cancelWaitDelay bounds how long a cancelled command may take to exit after
+// SIGINT before it is killed.
+const cancelWaitDelay = 10 * time.Second
+
 // CommandRunner defines the interface for executing external commands.
 type CommandRunner interface {
 	Run(ctx context.Context, dir, name string, args ...string) error
@@ -35,9 +40,18 @@ func (r ExecRunner) stderr() io.Writer {
 	return os.Stderr
 }
 
-func (r ExecRunner) Run(ctx context.Context, dir, name string, args ...string) error {
+// newCommand interrupts the command on cancellation, like Ctrl-C would, so
+// docker and buildx can clean up before exiting.
+func newCommand(ctx context.Context, dir, name string, args ...string) *exec.Cmd {
 	cmd := exec.CommandContext(ctx, name, args...)
 	cmd.Dir = dir
+	cmd.Cancel = func() error { return cmd.Process.Signal(os.Interrupt) }
+	cmd.WaitDelay = cancelWaitDelay
+	return cmd
+}
+
+func (r ExecRunner) Run(ctx context.Context, dir, name string, args ...string) error {
+	cmd := newCommand(ctx, dir, name, args...)
 	cmd.Stdout = r.stdout()
 	cmd.Stderr = r.stderr()
 	if err := cmd.Run(); err != nil {
@@ -47,8 +61,7 @@ func (r ExecRunner) Run(ctx context.Context, dir, name string, args ...string) e
 }
 
 func (r ExecRunner) RunOutput(ctx context.Context, dir, name string, args ...string) ([]byte, error) {
-	cmd := exec.CommandContext(ctx, name, args...)
-	cmd.Dir = dir
+	cmd := newCommand(ctx, dir, name, args...)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return output, fmt.Errorf("run %s: %w", name, err)
@@ -57,8 +70,7 @@ func (r ExecRunner) RunOutput(ctx context.Context, dir, name string, args ...str
 }
 
 func (r ExecRunner) RunQuiet(ctx context.Context, dir, name string, args ...string) error {
-	cmd := exec.CommandContext(ctx, name, args...)
-	cmd.Dir = dir
+	cmd := newCommand(ctx, dir, name, args...)
 	if err := cmd.Run(); err != nil {
 		return fmt.Errorf("run %s: %w", name, err)
 	}
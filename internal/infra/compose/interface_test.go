@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"context"
 	"testing"
+	"time"
 )
 
 func TestExecRunnerRunUsesInjectedWriters(t *testing.T) {
@@ -27,3 +28,22 @@ func TestExecRunnerRunUsesInjectedWriters(t *testing.T) {
 		t.Fatalf("unexpected stderr: %q", errOut.String())
 	}
 }
+
+func TestExecRunnerRunInterruptsOnCancel(t *testing.T) {
+	var out bytes.Buffer
+	runner := ExecRunner{Out: &out, ErrOut: &out}
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	started := time.Now()
+	err := runner.Run(ctx, "", "sh", "-c", "trap 'printf interrupted; exit 130' INT; sleep 5 >/dev/null 2>&1 & wait")
+	if err == nil {
+		t.Fatal("expected error from cancelled command")
+	}
+	if elapsed := time.Since(started); elapsed > 3*time.Second {
+		t.Fatalf("command was not interrupted promptly: %s", elapsed)
+	}
+	if out.String() != "interrupted" {
+		t.Fatalf("expected SIGINT handler output, got %q", out.String())
+	}
+}
@@ -22,6 +22,7 @@ type GlobalConfig struct {
 	Projects        map[string]ProjectEntry  `yaml:"projects,omitempty"`
 	BuildDefaults   map[string]BuildDefaults `yaml:"build_defaults,omitempty"`
 	RecentTemplates []string                 `yaml:"recent_templates,omitempty"`
+	Timeouts        TimeoutDefaults          `yaml:"timeouts,omitempty"`
 }
 
 // ProjectEntry stores a project's directory path and last-used timestamp.
@@ -40,6 +41,14 @@ type BuildDefaults struct {
 	ImageRuntimes map[string]string `yaml:"image_runtimes,omitempty"`
 }
 
+// TimeoutDefaults stores deploy timeout budgets as Go durations ("90s", "10m").
+// Phases is keyed by phase name (registry_wait, generate, base_build,
+// function_build, provision, sync).
+type TimeoutDefaults struct {
+	Deploy string            `yaml:"deploy,omitempty"`
+	Phases map[string]string `yaml:"phases,omitempty"`
+}
+
 // DefaultGlobalConfig returns an initialized GlobalConfig with version set.
 func DefaultGlobalConfig() GlobalConfig {
 	return GlobalConfig{
@@ -58,6 +58,7 @@ func newComposeProvisioner(
 
 // RunProvisioner runs the deploy profile provisioner via docker compose.
 func (p composeProvisioner) RunProvisioner(
+	ctx context.Context,
 	composeProject string,
 	mode string,
 	noDeps bool,
@@ -92,7 +93,6 @@ func (p composeProvisioner) RunProvisioner(
 		repoRoot = resolvedRoot
 	}
 
-	ctx := context.Background()
 	var files []string
 	if len(request.ComposeFiles) > 0 {
 		files = make([]string, 0, len(request.ComposeFiles))
@@ -133,6 +133,6 @@ func (p composeProvisioner) RunProvisioner(
 		ComposeFiles:   files,
 		NoDeps:         request.NoDeps,
 		Verbose:        request.Verbose,
-		NoWarnOrphans:  p.composeSupportsNoWarnOrphans(repoRoot),
+		NoWarnOrphans:  p.composeSupportsNoWarnOrphans(ctx, repoRoot),
 	})
 }
@@ -53,11 +53,10 @@ func (p composeProvisioner) resolveComposeFilesForProject(
 	return result, nil
 }
 
-func (p composeProvisioner) composeSupportsNoWarnOrphans(repoRoot string) bool {
+func (p composeProvisioner) composeSupportsNoWarnOrphans(ctx context.Context, repoRoot string) bool {
 	if p.composeRunner == nil {
 		return false
 	}
-	ctx := context.Background()
 	out, err := p.composeRunner.RunOutput(ctx, repoRoot, "docker", "compose", "--help")
 	if err != nil {
 		return false
@@ -203,7 +203,7 @@ func TestRunProvisionerSkipsServicePrecheckWhenResolvedFilesExist(t *testing.T)
 	if err != nil {
 		t.Fatalf("getwd: %v", err)
 	}
-	if err := p.RunProvisioner("esb-dev", compose.ModeDocker, true, false, projectDir, nil); err != nil {
+	if err := p.RunProvisioner(context.Background(), "esb-dev", compose.ModeDocker, true, false, projectDir, nil); err != nil {
 		t.Fatalf("run provisioner: %v", err)
 	}
 	if runner.serviceChecks != 0 {
@@ -237,7 +237,7 @@ func TestRunProvisionerParityExpectsSharedRunErrorClass(t *testing.T) {
 	}
 	p := newComposeProvisioner(runner, nil, nil)
 
-	err := p.RunProvisioner("esb-dev", compose.ModeDocker, true, false, root, []string{composePath})
+	err := p.RunProvisioner(context.Background(), "esb-dev", compose.ModeDocker, true, false, root, []string{composePath})
 	if err == nil {
 		t.Fatal("expected error")
 	}
@@ -291,7 +291,7 @@ func TestComposeSupportsNoWarnOrphans(t *testing.T) {
 			},
 		},
 	}
-	if !p.composeSupportsNoWarnOrphans("/tmp") {
+	if !p.composeSupportsNoWarnOrphans(context.Background(), "/tmp") {
 		t.Fatal("expected --no-warn-orphans support")
 	}
 }
@@ -12,12 +12,12 @@ import (
 )
 
 // CheckServicesStatus checks if gateway and agent/runtime-node are running (warning only).
-func (p composeProvisioner) CheckServicesStatus(composeProject, mode string) {
+func (p composeProvisioner) CheckServicesStatus(ctx context.Context, composeProject, mode string) {
 	if p.userInterface == nil {
 		return
 	}
 
-	gatewayRunning := p.isServiceRunning(composeProject, "gateway")
+	gatewayRunning := p.isServiceRunning(ctx, composeProject, "gateway")
 	if !gatewayRunning {
 		p.userInterface.Warn("Warning: Gateway is not running. Deploy will continue but functions may not be immediately available.")
 	}
@@ -26,7 +26,7 @@ func (p composeProvisioner) CheckServicesStatus(composeProject, mode string) {
 	if mode == compose.ModeContainerd {
 		agentService = "runtime-node"
 	}
-	agentRunning := p.isServiceRunning(composeProject, agentService)
+	agentRunning := p.isServiceRunning(ctx, composeProject, agentService)
 	if !agentRunning {
 		p.userInterface.Warn(
 			fmt.Sprintf(
@@ -37,11 +37,10 @@ func (p composeProvisioner) CheckServicesStatus(composeProject, mode string) {
 	}
 }
 
-func (p composeProvisioner) isServiceRunning(composeProject, service string) bool {
+func (p composeProvisioner) isServiceRunning(ctx context.Context, composeProject, service string) bool {
 	if p.composeRunner == nil {
 		return true
 	}
-	ctx := context.Background()
 	args := []string{"compose"}
 	if result, err := p.resolveComposeFilesForProject(ctx, composeProject); err == nil {
 		for _, file := range result.Files {
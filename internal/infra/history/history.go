@@ -0,0 +1,277 @@
+// Where: cli/internal/infra/history/history.go
+// What: Deploy history ledger stored under the staging root.
+// Why: Record what was deployed when, and keep the exact applied config.
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	domaincfg "github.com/poruru-code/esb-cli/internal/domain/config"
+	"github.com/poruru-code/esb-cli/internal/infra/staging"
+)
+
+const (
+	entryFileName = "entry.json"
+	configDirName = "config"
+	idLayout      = "20060102T150405Z"
+	// MaxEntries is the number of entries kept per project/env; older
+	// entries are pruned when a new one is recorded.
+	MaxEntries = 100
+)
+
+// ErrEntryNotFound reports an unknown history entry id.
+var ErrEntryNotFound = errors.New("history entry not found")
+
+// Kinds of recorded operations.
+const (
+	KindDeploy   = "deploy"
+	KindApply    = "apply"
+	KindRollback = "rollback"
+)
+
+// Entry is one successful deploy, apply or rollback.
+type Entry struct {
+	ID         string            `json:"id"`
+	CreatedAt  time.Time         `json:"created_at"`
+	Kind       string            `json:"kind"`
+	Project    string            `json:"project"`
+	Env        string            `json:"env"`
+	Mode       string            `json:"mode"`
+	User       string            `json:"user,omitempty"`
+	Git        Git               `json:"git"`
+	Template   Template          `json:"template"`
+	Parameters map[string]string `json:"parameters"`
+	Images     []Image           `json:"images"`
+	// ConfigDiff counts changes against the previous entry of the same
+	// project/env.
+	ConfigDiff ConfigDiff `json:"config_diff"`
+	// Files lists the runtime config files saved with the entry.
+	Files []string `json:"files"`
+	// RolledBackTo is the id of the entry a rollback restored.
+	RolledBackTo string `json:"rolled_back_to,omitempty"`
+	// Dir is the entry directory; its config/ subdirectory holds Files.
+	Dir string `json:"-"`
+}
+
+// Git mirrors the git block of the bundle manifest.
+type Git struct {
+	Commit string `json:"commit"`
+	Dirty  bool   `json:"dirty"`
+}
+
+// Template identifies the deployed SAM template.
+type Template struct {
+	Path   string `json:"path"`
+	Sha256 string `json:"sha256"`
+}
+
+// Image is a function image referenced by the applied functions.yml.
+type Image struct {
+	Function string `json:"function"`
+	Image    string `json:"image"`
+	Digest   string `json:"digest,omitempty"`
+}
+
+// ConfigDiff holds config diff counts per section.
+type ConfigDiff struct {
+	Functions domaincfg.Counts            `json:"functions"`
+	Routes    domaincfg.Counts            `json:"routes"`
+	Resources map[string]domaincfg.Counts `json:"resources,omitempty"`
+}
+
+// ConfigDir returns the directory holding the applied config files.
+func (e Entry) ConfigDir() string {
+	if e.Dir == "" {
+		return ""
+	}
+	return filepath.Join(e.Dir, configDirName)
+}
+
+// Filter narrows List and Find to a project and/or env. Empty fields match
+// everything.
+type Filter struct {
+	Project string
+	Env     string
+}
+
+func (f Filter) match(entry Entry) bool {
+	if project := strings.TrimSpace(f.Project); project != "" && project != entry.Project {
+		return false
+	}
+	if env := strings.TrimSpace(f.Env); env != "" && !strings.EqualFold(env, entry.Env) {
+		return false
+	}
+	return true
+}
+
+// Record saves entry and lets writeConfig fill its config directory;
+// writeConfig returns the names of the files it wrote. path is any path
+// inside the repository (template path or repo root). The id is derived from
+// entry.CreatedAt.
+func Record(path string, entry Entry, writeConfig func(dir string) ([]string, error)) (Entry, error) {
+	historyDir, err := staging.HistoryDir(path, entry.Project, entry.Env)
+	if err != nil {
+		return Entry{}, err
+	}
+	if err := os.MkdirAll(historyDir, 0o755); err != nil {
+		return Entry{}, fmt.Errorf("create history dir: %w", err)
+	}
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+	entry.CreatedAt = entry.CreatedAt.UTC()
+	entry.ID, entry.Dir, err = allocateEntryDir(historyDir, entry.CreatedAt)
+	if err != nil {
+		return Entry{}, err
+	}
+	entry.Files = []string{}
+	if writeConfig != nil {
+		if err := os.MkdirAll(entry.ConfigDir(), 0o755); err != nil {
+			_ = os.RemoveAll(entry.Dir)
+			return Entry{}, fmt.Errorf("create history config dir: %w", err)
+		}
+		files, err := writeConfig(entry.ConfigDir())
+		if err != nil {
+			_ = os.RemoveAll(entry.Dir)
+			return Entry{}, fmt.Errorf("save history config: %w", err)
+		}
+		entry.Files = append(entry.Files, files...)
+	}
+	payload, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		_ = os.RemoveAll(entry.Dir)
+		return Entry{}, fmt.Errorf("encode history entry: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(entry.Dir, entryFileName), append(payload, '\n'), 0o600); err != nil {
+		_ = os.RemoveAll(entry.Dir)
+		return Entry{}, fmt.Errorf("write history entry: %w", err)
+	}
+	prune(historyDir)
+	return entry, nil
+}
+
+// List returns the entries matching filter, newest first.
+func List(path string, filter Filter) ([]Entry, error) {
+	root, err := staging.RootDir(path)
+	if err != nil {
+		return nil, err
+	}
+	matches, err := filepath.Glob(filepath.Join(root, "*", "history", "*", entryFileName))
+	if err != nil {
+		return nil, fmt.Errorf("list history: %w", err)
+	}
+	entries := make([]Entry, 0, len(matches))
+	for _, match := range matches {
+		entry, err := loadEntry(filepath.Dir(match))
+		if err != nil {
+			continue
+		}
+		if filter.match(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].CreatedAt.Equal(entries[j].CreatedAt) {
+			return entries[i].CreatedAt.After(entries[j].CreatedAt)
+		}
+		return entries[i].ID > entries[j].ID
+	})
+	return entries, nil
+}
+
+// Latest returns the newest entry of project/env.
+func Latest(path, project, env string) (Entry, bool, error) {
+	entries, err := List(path, Filter{Project: project, Env: env})
+	if err != nil || len(entries) == 0 {
+		return Entry{}, false, err
+	}
+	return entries[0], true, nil
+}
+
+// Find returns the entry with id. When several project/envs share the id,
+// filter must narrow it down to one.
+func Find(path, id string, filter Filter) (Entry, error) {
+	entries, err := List(path, filter)
+	if err != nil {
+		return Entry{}, err
+	}
+	var found []Entry
+	for _, entry := range entries {
+		if entry.ID == strings.TrimSpace(id) {
+			found = append(found, entry)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return Entry{}, fmt.Errorf("%w: %s", ErrEntryNotFound, id)
+	case 1:
+		return found[0], nil
+	}
+	targets := make([]string, 0, len(found))
+	for _, entry := range found {
+		targets = append(targets, fmt.Sprintf("%s (env: %s)", entry.Project, entry.Env))
+	}
+	return Entry{}, fmt.Errorf("history entry %s exists for %s; select one with --project/--env", id, strings.Join(targets, ", "))
+}
+
+func loadEntry(dir string) (Entry, error) {
+	payload, err := os.ReadFile(filepath.Join(dir, entryFileName))
+	if err != nil {
+		return Entry{}, fmt.Errorf("read history entry: %w", err)
+	}
+	var entry Entry
+	if err := json.Unmarshal(payload, &entry); err != nil {
+		return Entry{}, fmt.Errorf("decode history entry %s: %w", dir, err)
+	}
+	entry.Dir = dir
+	return entry, nil
+}
+
+// allocateEntryDir creates the entry directory, suffixing the id when
+// another entry was recorded in the same second.
+func allocateEntryDir(historyDir string, createdAt time.Time) (string, string, error) {
+	base := createdAt.Format(idLayout)
+	for attempt := 0; attempt < 100; attempt++ {
+		id := base
+		if attempt > 0 {
+			id = fmt.Sprintf("%s-%d", base, attempt)
+		}
+		dir := filepath.Join(historyDir, id)
+		err := os.Mkdir(dir, 0o755)
+		if err == nil {
+			return id, dir, nil
+		}
+		if !os.IsExist(err) {
+			return "", "", fmt.Errorf("create history entry: %w", err)
+		}
+	}
+	return "", "", fmt.Errorf("create history entry: too many entries at %s", base)
+}
+
+// prune removes the oldest entries beyond MaxEntries. Ids sort by time.
+func prune(historyDir string) {
+	items, err := os.ReadDir(historyDir)
+	if err != nil {
+		return
+	}
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		if item.IsDir() {
+			ids = append(ids, item.Name())
+		}
+	}
+	if len(ids) <= MaxEntries {
+		return
+	}
+	sort.Strings(ids)
+	for _, id := range ids[:len(ids)-MaxEntries] {
+		_ = os.RemoveAll(filepath.Join(historyDir, id))
+	}
+}
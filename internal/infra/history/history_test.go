@@ -0,0 +1,153 @@
+// Where: cli/internal/infra/history/history_test.go
+// What: Tests for the deploy history ledger.
+// Why: Keep entry ids, ordering, lookups and pruning stable.
+package history
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newHistoryRepo(t *testing.T) string {
+	t.Helper()
+	repoRoot := t.TempDir()
+	if err := os.WriteFile(filepath.Join(repoRoot, "docker-compose.docker.yml"), []byte("services: {}\n"), 0o600); err != nil {
+		t.Fatalf("write compose marker: %v", err)
+	}
+	return repoRoot
+}
+
+func writeConfig(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+		t.Fatalf("write %s: %v", name, err)
+	}
+}
+
+// copyConfig copies the named files that exist in srcDir, like the deploy
+// workflow does.
+func copyConfig(t *testing.T, srcDir string, names ...string) func(string) ([]string, error) {
+	t.Helper()
+	return func(dir string) ([]string, error) {
+		files := []string{}
+		for _, name := range names {
+			data, err := os.ReadFile(filepath.Join(srcDir, name))
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			if err := os.WriteFile(filepath.Join(dir, name), data, 0o600); err != nil {
+				return nil, err
+			}
+			files = append(files, name)
+		}
+		return files, nil
+	}
+}
+
+func TestRecordSavesEntryAndConfigFiles(t *testing.T) {
+	repoRoot := newHistoryRepo(t)
+	configDir := filepath.Join(t.TempDir(), "config")
+	writeConfig(t, configDir, "functions.yml", "functions: {a: {}}\n")
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	first, err := Record(repoRoot, Entry{CreatedAt: createdAt, Kind: KindDeploy, Project: "esb-dev", Env: "dev"},
+		copyConfig(t, configDir, "functions.yml", "routing.yml"))
+	if err != nil {
+		t.Fatalf("record: %v", err)
+	}
+	if first.ID != "20260102T030405Z" {
+		t.Fatalf("unexpected id %q", first.ID)
+	}
+	if !reflect.DeepEqual(first.Files, []string{"functions.yml"}) {
+		t.Fatalf("unexpected files %v", first.Files)
+	}
+	data, err := os.ReadFile(filepath.Join(first.ConfigDir(), "functions.yml"))
+	if err != nil || string(data) != "functions: {a: {}}\n" {
+		t.Fatalf("config not copied: %q (%v)", data, err)
+	}
+
+	second, err := Record(repoRoot, Entry{CreatedAt: createdAt, Kind: KindApply, Project: "esb-dev", Env: "dev"}, nil)
+	if err != nil {
+		t.Fatalf("record second: %v", err)
+	}
+	if second.ID != "20260102T030405Z-1" {
+		t.Fatalf("same-second entries need distinct ids, got %q", second.ID)
+	}
+	if _, err := Record(repoRoot, Entry{CreatedAt: createdAt, Project: "esb-dev", Env: "dev"}, func(string) ([]string, error) {
+		return nil, errors.New("disk full")
+	}); err == nil || !strings.Contains(err.Error(), "disk full") {
+		t.Fatalf("expected writeConfig error, got %v", err)
+	}
+	latest, ok, err := Latest(repoRoot, "esb-dev", "dev")
+	if err != nil || !ok || latest.ID != second.ID {
+		t.Fatalf("latest = %#v, %v, %v", latest, ok, err)
+	}
+}
+
+func TestListAndFindFilterByProjectAndEnv(t *testing.T) {
+	repoRoot := newHistoryRepo(t)
+	createdAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	for _, env := range []string{"dev", "stg"} {
+		if _, err := Record(repoRoot, Entry{CreatedAt: createdAt, Project: "esb-" + env, Env: env}, nil); err != nil {
+			t.Fatalf("record %s: %v", env, err)
+		}
+	}
+	if _, err := Record(repoRoot, Entry{CreatedAt: createdAt.Add(time.Hour), Project: "esb-dev", Env: "dev"}, nil); err != nil {
+		t.Fatalf("record: %v", err)
+	}
+
+	all, err := List(repoRoot, Filter{})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(all) != 3 || all[0].ID != "20260102T040405Z" {
+		t.Fatalf("expected newest first, got %#v", all)
+	}
+	dev, err := List(repoRoot, Filter{Env: "dev"})
+	if err != nil || len(dev) != 2 {
+		t.Fatalf("env filter: %d entries, %v", len(dev), err)
+	}
+
+	if _, err := Find(repoRoot, "20260102T030405Z", Filter{}); err == nil || !strings.Contains(err.Error(), "--project/--env") {
+		t.Fatalf("expected ambiguous id error, got %v", err)
+	}
+	entry, err := Find(repoRoot, "20260102T030405Z", Filter{Env: "stg"})
+	if err != nil || entry.Project != "esb-stg" {
+		t.Fatalf("find = %#v, %v", entry, err)
+	}
+	if _, err := Find(repoRoot, "missing", Filter{}); !errors.Is(err, ErrEntryNotFound) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
+
+func TestRecordPrunesOldestEntries(t *testing.T) {
+	repoRoot := newHistoryRepo(t)
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	for i := 0; i < MaxEntries+2; i++ {
+		entry := Entry{CreatedAt: start.Add(time.Duration(i) * time.Minute), Project: "esb-dev", Env: "dev"}
+		if _, err := Record(repoRoot, entry, nil); err != nil {
+			t.Fatalf("record %d: %v", i, err)
+		}
+	}
+	entries, err := List(repoRoot, Filter{})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(entries) != MaxEntries {
+		t.Fatalf("expected %d entries, got %d", MaxEntries, len(entries))
+	}
+	if oldest := entries[len(entries)-1]; !oldest.CreatedAt.Equal(start.Add(2 * time.Minute)) {
+		t.Fatalf("oldest entries must be pruned, oldest kept %s", oldest.CreatedAt)
+	}
+}
@@ -0,0 +1,136 @@
+// Where: cli/internal/infra/sam/samconfig.go
+// What: samconfig.toml / samconfig.yaml deploy settings lookup.
+// Why: Reuse the stack name, template and parameters SAM projects already keep.
+package sam
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/poruru-code/esb/pkg/yamlshape"
+	"gopkg.in/yaml.v3"
+)
+
+// SamconfigDefaultEnv is the samconfig environment used without --env.
+const SamconfigDefaultEnv = "default"
+
+// SamconfigFileNames lists the samconfig files looked up in each directory,
+// in SAM CLI priority order.
+var SamconfigFileNames = []string{"samconfig.toml", "samconfig.yaml", "samconfig.yml"}
+
+// SamconfigDeploy holds the deploy settings of one samconfig environment.
+type SamconfigDeploy struct {
+	// Path is the samconfig file the settings were read from.
+	Path string
+	// Env is the environment section that was used.
+	Env          string
+	StackName    string
+	TemplateFile string
+	// ParameterOverrides keeps the raw "Key=Value ..." entries.
+	ParameterOverrides []string
+}
+
+// Empty reports whether no deploy setting was found.
+func (d SamconfigDeploy) Empty() bool {
+	return d.StackName == "" && d.TemplateFile == "" && len(d.ParameterOverrides) == 0
+}
+
+// FindSamconfig returns the first samconfig file found in dirs.
+func FindSamconfig(dirs ...string) (string, bool) {
+	seen := map[string]struct{}{}
+	for _, dir := range dirs {
+		dir = strings.TrimSpace(dir)
+		if dir == "" {
+			continue
+		}
+		if _, ok := seen[dir]; ok {
+			continue
+		}
+		seen[dir] = struct{}{}
+		for _, name := range SamconfigFileNames {
+			path := filepath.Join(dir, name)
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return path, true
+			}
+		}
+	}
+	return "", false
+}
+
+// LoadSamconfigDeploy reads the deploy settings of env from a samconfig file.
+// The env section is used when it exists, otherwise the default section.
+// Within a section, deploy.parameters win over global.parameters. A relative
+// template_file is resolved against the samconfig directory.
+func LoadSamconfigDeploy(path, env string) (SamconfigDeploy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return SamconfigDeploy{}, fmt.Errorf("read samconfig: %w", err)
+	}
+	var doc map[string]any
+	if strings.EqualFold(filepath.Ext(path), ".toml") {
+		doc, err = parseSamconfigTOML(string(data))
+	} else {
+		err = yaml.Unmarshal(data, &doc)
+	}
+	if err != nil {
+		return SamconfigDeploy{}, fmt.Errorf("parse samconfig %s: %w", path, err)
+	}
+
+	section := strings.TrimSpace(env)
+	if section == "" || doc[section] == nil {
+		section = SamconfigDefaultEnv
+	}
+	envSection := yamlshape.AsMap(doc[section])
+	params := map[string]any{}
+	for _, command := range []string{"global", "deploy"} {
+		for key, value := range yamlshape.AsMap(yamlshape.AsMap(envSection[command])["parameters"]) {
+			params[key] = value
+		}
+	}
+
+	deploy := SamconfigDeploy{Path: path, Env: section}
+	if deploy.StackName, err = samconfigString(params, "stack_name"); err != nil {
+		return SamconfigDeploy{}, fmt.Errorf("samconfig %s: %w", path, err)
+	}
+	if deploy.TemplateFile, err = samconfigString(params, "template_file"); err != nil {
+		return SamconfigDeploy{}, fmt.Errorf("samconfig %s: %w", path, err)
+	}
+	if deploy.TemplateFile == "" {
+		if deploy.TemplateFile, err = samconfigString(params, "template"); err != nil {
+			return SamconfigDeploy{}, fmt.Errorf("samconfig %s: %w", path, err)
+		}
+	}
+	if deploy.TemplateFile != "" && !filepath.IsAbs(deploy.TemplateFile) {
+		deploy.TemplateFile = filepath.Join(filepath.Dir(path), deploy.TemplateFile)
+	}
+	switch raw := params["parameter_overrides"].(type) {
+	case nil:
+	case string:
+		deploy.ParameterOverrides = []string{raw}
+	case []any:
+		for _, item := range raw {
+			entry, ok := item.(string)
+			if !ok {
+				return SamconfigDeploy{}, fmt.Errorf("samconfig %s: parameter_overrides entries must be strings", path)
+			}
+			deploy.ParameterOverrides = append(deploy.ParameterOverrides, entry)
+		}
+	default:
+		return SamconfigDeploy{}, fmt.Errorf("samconfig %s: parameter_overrides must be a string or a list", path)
+	}
+	return deploy, nil
+}
+
+func samconfigString(params map[string]any, key string) (string, error) {
+	switch value := params[key].(type) {
+	case nil:
+		return "", nil
+	case string:
+		return strings.TrimSpace(value), nil
+	default:
+		return "", errors.New(key + " must be a string")
+	}
+}
@@ -0,0 +1,153 @@
+// Where: cli/internal/infra/sam/samconfig_test.go
+// What: Tests for samconfig deploy settings lookup.
+// Why: Ensure TOML/YAML samconfig environments map to deploy defaults.
+package sam
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const testSamconfigTOML = `version = 0.1
+
+# Shared settings
+[default.global.parameters]
+stack_name = "esb-dev"
+
+[default.deploy.parameters]
+template_file = "template.yaml" # relative to samconfig
+parameter_overrides = "Stage=\"dev\" Size=1"
+confirm_changeset = true
+
+[prod.deploy.parameters]
+stack_name = 'esb-prod'
+template_file = "/abs/template.yaml"
+parameter_overrides = [
+  "Stage=prod",
+  "Size=3", # trailing comment
+]
+`
+
+func writeSamconfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("write samconfig: %v", err)
+	}
+	return path
+}
+
+func TestLoadSamconfigDeployTOML(t *testing.T) {
+	path := writeSamconfig(t, "samconfig.toml", testSamconfigTOML)
+
+	got, err := LoadSamconfigDeploy(path, "prod")
+	if err != nil {
+		t.Fatalf("load prod: %v", err)
+	}
+	want := SamconfigDeploy{
+		Path:               path,
+		Env:                "prod",
+		StackName:          "esb-prod",
+		TemplateFile:       "/abs/template.yaml",
+		ParameterOverrides: []string{"Stage=prod", "Size=3"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected prod settings:\n got %#v\nwant %#v", got, want)
+	}
+
+	got, err = LoadSamconfigDeploy(path, "staging")
+	if err != nil {
+		t.Fatalf("load staging: %v", err)
+	}
+	want = SamconfigDeploy{
+		Path:               path,
+		Env:                SamconfigDefaultEnv,
+		StackName:          "esb-dev",
+		TemplateFile:       filepath.Join(filepath.Dir(path), "template.yaml"),
+		ParameterOverrides: []string{`Stage="dev" Size=1`},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("unexpected default settings:\n got %#v\nwant %#v", got, want)
+	}
+}
+
+func TestLoadSamconfigDeployYAML(t *testing.T) {
+	path := writeSamconfig(t, "samconfig.yaml", `
+version: 0.1
+dev:
+  deploy:
+    parameters:
+      stack_name: esb-dev
+      parameter_overrides:
+        - Stage=dev
+`)
+	got, err := LoadSamconfigDeploy(path, "dev")
+	if err != nil {
+		t.Fatalf("load yaml: %v", err)
+	}
+	if got.StackName != "esb-dev" || !reflect.DeepEqual(got.ParameterOverrides, []string{"Stage=dev"}) {
+		t.Fatalf("unexpected yaml settings: %#v", got)
+	}
+}
+
+func TestLoadSamconfigDeployAcceptsFullTOML(t *testing.T) {
+	content := `version = 0.1
+[default]
+deploy = { parameters = { stack_name = "esb-inline" } }
+
+[prod.deploy.parameters]
+parameter_overrides = """
+Stage=prod
+Size=3"""
+capabilities = [
+  "CAPABILITY_IAM",
+]
+`
+	path := writeSamconfig(t, "samconfig.toml", content)
+	got, err := LoadSamconfigDeploy(path, "prod")
+	if err != nil {
+		t.Fatalf("load prod: %v", err)
+	}
+	if !reflect.DeepEqual(got.ParameterOverrides, []string{"Stage=prod\nSize=3"}) {
+		t.Fatalf("unexpected multi-line overrides: %#v", got.ParameterOverrides)
+	}
+	got, err = LoadSamconfigDeploy(path, "")
+	if err != nil || got.StackName != "esb-inline" {
+		t.Fatalf("expected inline table stack name, got %#v err=%v", got, err)
+	}
+}
+
+func TestParseSamconfigTOMLRejectsInvalidSyntax(t *testing.T) {
+	for _, content := range []string{
+		"[default.deploy.parameters\n",
+		"key = \"unterminated\n",
+		"just a line\n",
+	} {
+		if _, err := parseSamconfigTOML(content); err == nil {
+			t.Fatalf("expected error for %q", content)
+		}
+	}
+}
+
+func TestFindSamconfigPrefersTOMLAndEarlierDirs(t *testing.T) {
+	first := t.TempDir()
+	second := t.TempDir()
+	for _, path := range []string{
+		filepath.Join(second, "samconfig.toml"),
+		filepath.Join(first, "samconfig.yaml"),
+		filepath.Join(first, "samconfig.toml"),
+	} {
+		if err := os.WriteFile(path, []byte("version = 0.1\n"), 0o600); err != nil {
+			t.Fatalf("write: %v", err)
+		}
+	}
+	got, ok := FindSamconfig("", first, second)
+	if !ok || got != filepath.Join(first, "samconfig.toml") {
+		t.Fatalf("unexpected samconfig: %q %v", got, ok)
+	}
+	if _, ok := FindSamconfig(t.TempDir()); ok {
+		t.Fatal("expected no samconfig")
+	}
+}
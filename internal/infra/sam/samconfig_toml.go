@@ -0,0 +1,16 @@
+// Where: cli/internal/infra/sam/samconfig_toml.go
+// What: TOML decoding for samconfig.toml.
+// Why: Accept any valid samconfig.toml, not only what `sam init` writes.
+package sam
+
+import "github.com/BurntSushi/toml"
+
+// parseSamconfigTOML decodes a samconfig.toml document into generic maps so
+// it can be read the same way as samconfig.yaml.
+func parseSamconfigTOML(content string) (map[string]any, error) {
+	doc := map[string]any{}
+	if _, err := toml.Decode(content, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
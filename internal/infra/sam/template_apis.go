@@ -0,0 +1,108 @@
+// Where: cli/internal/infra/sam/template_apis.go
+// What: AWS::Serverless::Api / HttpApi extraction and event binding.
+// Why: Carry stage, base path and authorizer settings into routing output.
+package sam
+
+import (
+	"strings"
+
+	"github.com/poruru-code/esb-cli/internal/domain/template"
+	"github.com/poruru-code/esb-cli/internal/domain/value"
+)
+
+const (
+	httpAPIDefaultPath  = "/{proxy+}"
+	httpAPIDefaultStage = "$default"
+	authorizerNone      = "NONE"
+)
+
+type apiSpec struct {
+	Type              string
+	StageName         string
+	BasePath          string
+	DefaultAuthorizer string
+}
+
+func parseAPIResources(resources map[string]any) map[string]apiSpec {
+	apis := map[string]apiSpec{}
+	for _, logicalID := range sortedMapKeys(resources) {
+		m := value.AsMap(resources[logicalID])
+		if m == nil {
+			continue
+		}
+		var eventType string
+		switch value.AsString(m["Type"]) {
+		case "AWS::Serverless::Api":
+			eventType = template.EventTypeAPI
+		case "AWS::Serverless::HttpApi":
+			eventType = template.EventTypeHTTPAPI
+		default:
+			continue
+		}
+		props := value.AsMap(m["Properties"])
+		spec := apiSpec{
+			Type:      eventType,
+			StageName: value.AsString(props["StageName"]),
+			BasePath:  parseAPIBasePath(props),
+		}
+		if spec.StageName == "" && eventType == template.EventTypeHTTPAPI {
+			spec.StageName = httpAPIDefaultStage
+		}
+		if auth := value.AsMap(props["Auth"]); auth != nil {
+			spec.DefaultAuthorizer = value.AsString(auth["DefaultAuthorizer"])
+		}
+		apis[logicalID] = spec
+	}
+	return apis
+}
+
+func parseAPIBasePath(props map[string]any) string {
+	domain := value.AsMap(props["Domain"])
+	if domain == nil {
+		return ""
+	}
+	for _, raw := range value.AsSlice(domain["BasePath"]) {
+		basePath := strings.Trim(strings.TrimSpace(value.AsString(raw)), "/")
+		if basePath != "" && basePath != "(none)" {
+			return basePath
+		}
+	}
+	return ""
+}
+
+// applyAPIResources binds Api/HttpApi events to explicit API resources.
+func applyAPIResources(functions []template.FunctionSpec, apis map[string]apiSpec, warnf func(string, ...any)) {
+	for i := range functions {
+		events := functions[i].Events
+		for j := range events {
+			event := &events[j]
+			if event.Type != template.EventTypeAPI && event.Type != template.EventTypeHTTPAPI {
+				continue
+			}
+			authorizer := event.Authorizer
+			if event.API != "" {
+				api, ok := apis[event.API]
+				switch {
+				case !ok:
+					if warnf != nil {
+						warnf("function %s event references unknown API %s", functions[i].Name, event.API)
+					}
+				case api.Type != event.Type:
+					if warnf != nil {
+						warnf("function %s %s event references %s API %s", functions[i].Name, event.Type, api.Type, event.API)
+					}
+				default:
+					event.StageName = api.StageName
+					event.BasePath = api.BasePath
+					if authorizer == "" {
+						authorizer = api.DefaultAuthorizer
+					}
+				}
+			}
+			if strings.EqualFold(authorizer, authorizerNone) {
+				authorizer = ""
+			}
+			event.Authorizer = authorizer
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Where: cli/internal/infra/sam/template_event_sources.go
+// What: Event source reference resolution for parsed functions.
+// Why: Map Ref/GetAtt/ARN sources to the local resource names the gateway uses.
+package sam
+
+import (
+	"strings"
+
+	"github.com/poruru-code/esb-cli/internal/domain/template"
+	"github.com/poruru-code/esb-cli/internal/domain/value"
+)
+
+const localGetAttPrefix = "arn:aws:local:"
+
+// resolveEventSources rewrites queue/topic/bucket/stream references in-place.
+func resolveEventSources(functions []template.FunctionSpec, resources map[string]any) {
+	for i := range functions {
+		events := functions[i].Events
+		for j := range events {
+			event := &events[j]
+			switch event.Type {
+			case template.EventTypeSQS:
+				event.Queue = resolveEventSourceName(event.Queue, resources, "AWS::SQS::Queue")
+			case template.EventTypeSNS:
+				event.Topic = resolveEventSourceName(event.Topic, resources, "AWS::SNS::Topic")
+			case template.EventTypeS3:
+				event.Bucket = resolveEventSourceName(event.Bucket, resources, "AWS::S3::Bucket")
+			case template.EventTypeDynamoDB:
+				event.Stream = resolveEventSourceName(event.Stream, resources, "AWS::DynamoDB::Table")
+			}
+		}
+	}
+}
+
+func resolveEventSourceName(ref string, resources map[string]any, resourceType string) string {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return ""
+	}
+	candidate := eventSourceLogicalID(ref)
+	resource := value.AsMap(resources[candidate])
+	if resource == nil || value.AsString(resource["Type"]) != resourceType {
+		return candidate
+	}
+	props := value.AsMap(resource["Properties"])
+	switch resourceType {
+	case "AWS::SQS::Queue":
+		return value.AsStringDefault(props["QueueName"], candidate)
+	case "AWS::SNS::Topic":
+		return value.AsStringDefault(props["TopicName"], candidate)
+	case "AWS::S3::Bucket":
+		return ResolveS3BucketName(props, candidate)
+	case "AWS::DynamoDB::Table":
+		return ResolveTableName(props, candidate)
+	}
+	return candidate
+}
+
+// eventSourceLogicalID extracts a logical ID or resource name from a reference.
+// Supported forms:
+//   - Ref results (plain logical IDs)
+//   - resolver GetAtt output: arn:aws:local:<Attr>:global:<LogicalID>/<Attr>
+//   - real ARNs (arn:aws:sqs:...:name, arn:aws:dynamodb:...:table/name/stream/...)
+func eventSourceLogicalID(ref string) string {
+	parts := strings.SplitN(ref, ":", 6)
+	if len(parts) != 6 || parts[0] != "arn" {
+		return ref
+	}
+	resource := parts[5]
+	if strings.HasPrefix(ref, localGetAttPrefix) {
+		name, _, _ := strings.Cut(resource, "/")
+		return name
+	}
+	if rest, ok := strings.CutPrefix(resource, "table/"); ok {
+		name, _, _ := strings.Cut(rest, "/")
+		return name
+	}
+	return resource
+}
@@ -4,6 +4,7 @@
 package sam
 
 import (
+	"encoding/json"
 	"strings"
 
 	"github.com/poruru-code/esb-cli/internal/domain/template"
@@ -28,33 +29,195 @@ func parseEvents(events map[string]any) []template.EventSpec {
 		}
 
 		switch eventType {
-		case "Api":
+		case template.EventTypeAPI:
 			path := value.AsString(props["Path"])
 			method := value.AsString(props["Method"])
 			if path == "" || method == "" {
 				continue
 			}
 			result = append(result, template.EventSpec{
-				Type:   "Api",
-				Path:   path,
-				Method: strings.ToLower(method),
+				Type:       template.EventTypeAPI,
+				Path:       path,
+				Method:     strings.ToLower(method),
+				API:        eventSourceLogicalID(value.AsString(props["RestApiId"])),
+				Authorizer: parseEventAuthorizer(props),
 			})
-		case "Schedule":
+		case template.EventTypeHTTPAPI:
+			// HttpApi events without Path/Method bind the $default catch-all route.
+			path := value.AsStringDefault(props["Path"], httpAPIDefaultPath)
+			method := value.AsStringDefault(props["Method"], "any")
+			if method == "*" {
+				method = "any"
+			}
+			result = append(result, template.EventSpec{
+				Type:       template.EventTypeHTTPAPI,
+				Path:       path,
+				Method:     strings.ToLower(method),
+				API:        eventSourceLogicalID(value.AsString(props["ApiId"])),
+				Authorizer: parseEventAuthorizer(props),
+			})
+		case template.EventTypeSchedule:
 			schedule := value.AsString(props["Schedule"])
 			if schedule == "" {
 				continue
 			}
 			input := value.AsString(props["Input"])
 			result = append(result, template.EventSpec{
-				Type:               "Schedule",
+				Type:               template.EventTypeSchedule,
 				ScheduleExpression: schedule,
 				Input:              input,
 			})
+		case template.EventTypeSQS:
+			queue := value.AsString(props["Queue"])
+			if queue == "" || isDisabledEvent(props) {
+				continue
+			}
+			result = append(result, template.EventSpec{
+				Type:           template.EventTypeSQS,
+				Queue:          queue,
+				BatchSize:      parseBatchSize(props),
+				FilterPatterns: parseFilterPatterns(props),
+			})
+		case template.EventTypeSNS:
+			topic := value.AsString(props["Topic"])
+			if topic == "" {
+				continue
+			}
+			result = append(result, template.EventSpec{
+				Type:         template.EventTypeSNS,
+				Topic:        topic,
+				FilterPolicy: encodeFilterPolicy(props["FilterPolicy"]),
+			})
+		case template.EventTypeS3:
+			bucket := value.AsString(props["Bucket"])
+			if bucket == "" {
+				continue
+			}
+			prefix, suffix := parseS3KeyFilter(props)
+			result = append(result, template.EventSpec{
+				Type:         template.EventTypeS3,
+				Bucket:       bucket,
+				BucketEvents: asStringSlice(props["Events"]),
+				KeyPrefix:    prefix,
+				KeySuffix:    suffix,
+			})
+		case template.EventTypeDynamoDB:
+			stream := value.AsString(props["Stream"])
+			if stream == "" || isDisabledEvent(props) {
+				continue
+			}
+			result = append(result, template.EventSpec{
+				Type:             template.EventTypeDynamoDB,
+				Stream:           stream,
+				BatchSize:        parseBatchSize(props),
+				StartingPosition: strings.ToUpper(value.AsString(props["StartingPosition"])),
+				FilterPatterns:   parseFilterPatterns(props),
+			})
 		}
 	}
 	return result
 }
 
+func parseEventAuthorizer(props map[string]any) string {
+	auth := value.AsMap(props["Auth"])
+	if auth == nil {
+		return ""
+	}
+	return value.AsString(auth["Authorizer"])
+}
+
+func isDisabledEvent(props map[string]any) bool {
+	switch typed := props["Enabled"].(type) {
+	case bool:
+		return !typed
+	case string:
+		return strings.EqualFold(strings.TrimSpace(typed), "false")
+	}
+	return false
+}
+
+func parseBatchSize(props map[string]any) *int {
+	if size, ok := value.AsIntPointer(props["BatchSize"]); ok {
+		return size
+	}
+	return nil
+}
+
+// parseFilterPatterns extracts FilterCriteria.Filters[].Pattern as JSON strings.
+func parseFilterPatterns(props map[string]any) []string {
+	criteria := value.AsMap(props["FilterCriteria"])
+	if criteria == nil {
+		return nil
+	}
+	var patterns []string
+	for _, raw := range value.AsSlice(criteria["Filters"]) {
+		filter := value.AsMap(raw)
+		if filter == nil {
+			continue
+		}
+		if pattern := encodeFilterPolicy(filter["Pattern"]); pattern != "" {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns
+}
+
+// encodeFilterPolicy normalizes string or map filter definitions to compact JSON.
+func encodeFilterPolicy(raw any) string {
+	switch typed := raw.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(typed)
+	default:
+		data, err := json.Marshal(typed)
+		if err != nil {
+			return ""
+		}
+		return string(data)
+	}
+}
+
+func parseS3KeyFilter(props map[string]any) (string, string) {
+	filter := value.AsMap(props["Filter"])
+	if filter == nil {
+		return "", ""
+	}
+	key := value.AsMap(filter["S3Key"])
+	if key == nil {
+		return "", ""
+	}
+	prefix := ""
+	suffix := ""
+	for _, raw := range value.AsSlice(key["Rules"]) {
+		rule := value.AsMap(raw)
+		if rule == nil {
+			continue
+		}
+		switch strings.ToLower(value.AsString(rule["Name"])) {
+		case "prefix":
+			prefix = value.AsString(rule["Value"])
+		case "suffix":
+			suffix = value.AsString(rule["Value"])
+		}
+	}
+	return prefix, suffix
+}
+
+func asStringSlice(raw any) []string {
+	values := value.AsSlice(raw)
+	if len(values) == 0 {
+		return nil
+	}
+	out := make([]string, 0, len(values))
+	for _, item := range values {
+		if s := strings.TrimSpace(value.AsString(item)); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
 func parseScaling(props map[string]any) template.ScalingSpec {
 	var scaling template.ScalingSpec
 	if value, ok := value.AsIntPointer(props["ReservedConcurrentExecutions"]); ok {
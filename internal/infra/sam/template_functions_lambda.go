@@ -35,14 +35,18 @@ func parseLambdaFunction(
 		// AWS::Lambda::Function Zip package is out of scope.
 		return template.FunctionSpec{}, false, nil
 	}
-	if imageURI == "" {
+	imageBuild := resolveImageBuild(resource)
+	if imageBuild != nil {
+		// Like `sam build`, Metadata builds replace any Code.ImageUri.
+		imageURI = ""
+	} else if imageURI == "" {
 		return template.FunctionSpec{}, false, fmt.Errorf(
-			"image lambda function %s (%s) requires Code.ImageUri",
+			"image lambda function %s (%s) requires Code.ImageUri or Metadata.Dockerfile",
 			ResolveFunctionName(fnProps.FunctionName, logicalID),
 			logicalID,
 		)
 	}
-	if hasUnresolvedImageURI(imageURI) {
+	if imageBuild == nil && hasUnresolvedImageURI(imageURI) {
 		return template.FunctionSpec{}, false, fmt.Errorf(
 			"image lambda function %s (%s) has unresolved Code.ImageUri: %s",
 			ResolveFunctionName(fnProps.FunctionName, logicalID),
@@ -58,19 +62,21 @@ func parseLambdaFunction(
 	architectures := resolveArchitectures(props, defaults.Architectures)
 
 	return template.FunctionSpec{
-		LogicalID:       logicalID,
-		Name:            fnName,
-		ImageSource:     imageURI,
-		Timeout:         timeout,
-		MemorySize:      memory,
-		Environment:     envVars,
-		Scaling:         parseScaling(props),
-		Architectures:   architectures,
-		Layers:          nil,
-		Events:          nil,
-		Runtime:         "",
-		Handler:         "",
-		CodeURI:         "",
-		HasRequirements: false,
+		LogicalID:          logicalID,
+		Name:               fnName,
+		ImageSource:        imageURI,
+		Timeout:            timeout,
+		MemorySize:         memory,
+		Environment:        envVars,
+		Scaling:            parseScaling(props),
+		Architectures:      architectures,
+		Layers:             nil,
+		Events:             nil,
+		Runtime:            "",
+		Handler:            "",
+		CodeURI:            "",
+		HasRequirements:    false,
+		DockerfileTemplate: resolveDockerfileTemplate(resource),
+		ImageBuild:         imageBuild,
 	}, true, nil
 }
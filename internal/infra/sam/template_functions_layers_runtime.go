@@ -26,6 +26,61 @@ func mergeEnv(defaultEnv map[string]string, props map[string]any) map[string]str
 	return envVars
 }
 
+// dockerfileTemplateMetadataKey selects a repository Dockerfile template
+// from the function resource Metadata.
+const dockerfileTemplateMetadataKey = "DockerfileTemplate"
+
+func resolveDockerfileTemplate(resource map[string]any) string {
+	metadata := value.AsMap(resource["Metadata"])
+	if metadata == nil {
+		return ""
+	}
+	return strings.TrimSpace(value.AsString(metadata[dockerfileTemplateMetadataKey]))
+}
+
+// resolveImageBuild reads the SAM image build Metadata. Either Dockerfile or
+// DockerContext marks the function as built from source; the other defaults
+// like `sam build` (Dockerfile / template directory).
+func resolveImageBuild(resource map[string]any) *template.ImageBuildSpec {
+	metadata := value.AsMap(resource["Metadata"])
+	if metadata == nil {
+		return nil
+	}
+	dockerfile := strings.TrimSpace(value.AsString(metadata["Dockerfile"]))
+	dockerContext := strings.TrimSpace(value.AsString(metadata["DockerContext"]))
+	if dockerfile == "" && dockerContext == "" {
+		return nil
+	}
+	if dockerfile == "" {
+		dockerfile = "Dockerfile"
+	}
+	if dockerContext == "" {
+		dockerContext = "."
+	}
+	var args map[string]string
+	if raw := value.AsMap(metadata["DockerBuildArgs"]); len(raw) > 0 {
+		args = make(map[string]string, len(raw))
+		for key, val := range raw {
+			args[key] = value.AsString(val)
+		}
+	}
+	return &template.ImageBuildSpec{
+		Dockerfile: dockerfile,
+		Context:    dockerContext,
+		Args:       args,
+		Tag:        strings.TrimSpace(value.AsString(metadata["DockerTag"])),
+	}
+}
+
+// resolveBuildMethod returns the lower-cased SAM Metadata.BuildMethod.
+func resolveBuildMethod(resource map[string]any) string {
+	metadata := value.AsMap(resource["Metadata"])
+	if metadata == nil {
+		return ""
+	}
+	return strings.ToLower(strings.TrimSpace(value.AsString(metadata["BuildMethod"])))
+}
+
 func resolveArchitectures(props map[string]any, defaults []string) []string {
 	if archs := value.AsSlice(props["Architectures"]); archs != nil {
 		var architectures []string
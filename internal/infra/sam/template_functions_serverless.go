@@ -42,14 +42,18 @@ func parseServerlessFunction(
 		strings.TrimSpace(value.AsString(fnProps.ImageURI)) != ""
 	if isImageFunction {
 		imageURI := strings.TrimSpace(value.AsString(fnProps.ImageURI))
-		if imageURI == "" {
+		imageBuild := resolveImageBuild(resource)
+		if imageBuild != nil {
+			// Like `sam build`, Metadata builds replace any ImageUri.
+			imageURI = ""
+		} else if imageURI == "" {
 			return template.FunctionSpec{}, false, fmt.Errorf(
-				"image function %s (%s) requires ImageUri",
+				"image function %s (%s) requires ImageUri or Metadata.Dockerfile",
 				fnName,
 				logicalID,
 			)
 		}
-		if hasUnresolvedImageURI(imageURI) {
+		if imageBuild == nil && hasUnresolvedImageURI(imageURI) {
 			return template.FunctionSpec{}, false, fmt.Errorf(
 				"image function %s (%s) has unresolved ImageUri: %s",
 				fnName,
@@ -58,20 +62,22 @@ func parseServerlessFunction(
 			)
 		}
 		return template.FunctionSpec{
-			LogicalID:       logicalID,
-			Name:            fnName,
-			ImageSource:     imageURI,
-			Timeout:         timeout,
-			MemorySize:      memory,
-			Environment:     envVars,
-			Architectures:   architectures,
-			Scaling:         parseScaling(props),
-			Events:          parseEvents(value.AsMap(fnProps.Events)),
-			Layers:          nil,
-			Runtime:         "",
-			Handler:         "",
-			CodeURI:         "",
-			HasRequirements: false,
+			LogicalID:          logicalID,
+			Name:               fnName,
+			ImageSource:        imageURI,
+			Timeout:            timeout,
+			MemorySize:         memory,
+			Environment:        envVars,
+			Architectures:      architectures,
+			Scaling:            parseScaling(props),
+			Events:             parseEvents(value.AsMap(fnProps.Events)),
+			Layers:             nil,
+			Runtime:            "",
+			Handler:            "",
+			CodeURI:            "",
+			HasRequirements:    false,
+			DockerfileTemplate: resolveDockerfileTemplate(resource),
+			ImageBuild:         imageBuild,
 		}, true, nil
 	}
 
@@ -130,5 +136,7 @@ func parseServerlessFunction(
 		Layers:                  layers,
 		Architectures:           architectures,
 		RuntimeManagementConfig: runtimeManagement,
+		DockerfileTemplate:      resolveDockerfileTemplate(resource),
+		BuildMethod:             resolveBuildMethod(resource),
 	}, true, nil
 }
@@ -13,7 +13,8 @@ import (
 func TestParseFunctionsParsesServerlessAndLambdaImage(t *testing.T) {
 	resources := map[string]any{
 		"ZipFn": map[string]any{
-			"Type": "AWS::Serverless::Function",
+			"Type":     "AWS::Serverless::Function",
+			"Metadata": map[string]any{"DockerfileTemplate": "python-ca"},
 			"Properties": map[string]any{
 				"FunctionName": "zip-fn",
 				"CodeUri":      "functions/zip",
@@ -92,6 +93,9 @@ func TestParseFunctionsParsesServerlessAndLambdaImage(t *testing.T) {
 	if zipFn.Environment["GLOBAL"] != "1" {
 		t.Fatalf("zip-fn merged env missing GLOBAL: %+v", zipFn.Environment)
 	}
+	if zipFn.DockerfileTemplate != "python-ca" {
+		t.Fatalf("zip-fn DockerfileTemplate=%q", zipFn.DockerfileTemplate)
+	}
 
 	imageFn := findFunctionByName(functions, "image-fn")
 	if imageFn == nil {
@@ -103,6 +107,62 @@ func TestParseFunctionsParsesServerlessAndLambdaImage(t *testing.T) {
 	if imageFn.CodeURI != "" {
 		t.Fatalf("image-fn CodeURI should be empty, got %q", imageFn.CodeURI)
 	}
+	if imageFn.DockerfileTemplate != "" {
+		t.Fatalf("image-fn DockerfileTemplate should be empty, got %q", imageFn.DockerfileTemplate)
+	}
+}
+
+func TestParseFunctionsReadsBuildMetadata(t *testing.T) {
+	resources := map[string]any{
+		"SourceImageFn": map[string]any{
+			"Type": "AWS::Serverless::Function",
+			"Metadata": map[string]any{
+				"Dockerfile":      "Dockerfile.lambda",
+				"DockerContext":   "./image",
+				"DockerTag":       "python3.12-v1",
+				"DockerBuildArgs": map[string]any{"APP_VERSION": 2},
+			},
+			"Properties": map[string]any{
+				"FunctionName": "source-image-fn",
+				"PackageType":  "Image",
+				"ImageUri":     "${SamBuiltImage}",
+			},
+		},
+		"MakeFn": map[string]any{
+			"Type":     "AWS::Serverless::Function",
+			"Metadata": map[string]any{"BuildMethod": "Makefile"},
+			"Properties": map[string]any{
+				"FunctionName": "make-fn",
+				"CodeUri":      "functions/make",
+				"Handler":      "app.handler",
+				"Runtime":      "python3.12",
+			},
+		},
+	}
+	defaults := functionDefaults{Runtime: DefaultLambdaRuntime, Handler: DefaultLambdaHandler}
+
+	functions, err := parseFunctions(resources, defaults, nil, nil)
+	if err != nil {
+		t.Fatalf("parseFunctions error: %v", err)
+	}
+	imageFn := findFunctionByName(functions, "source-image-fn")
+	if imageFn == nil || imageFn.ImageBuild == nil {
+		t.Fatalf("expected image build metadata, got %+v", imageFn)
+	}
+	if imageFn.ImageSource != "" {
+		t.Fatalf("metadata build must ignore ImageUri, got %q", imageFn.ImageSource)
+	}
+	build := imageFn.ImageBuild
+	if build.Dockerfile != "Dockerfile.lambda" || build.Context != "./image" || build.Tag != "python3.12-v1" {
+		t.Fatalf("unexpected image build: %+v", build)
+	}
+	if build.Args["APP_VERSION"] != "2" {
+		t.Fatalf("unexpected build args: %+v", build.Args)
+	}
+	makeFn := findFunctionByName(functions, "make-fn")
+	if makeFn == nil || makeFn.BuildMethod != "makefile" {
+		t.Fatalf("expected makefile build method, got %+v", makeFn)
+	}
 }
 
 func TestParseFunctionsRejectsUnresolvedImageURI(t *testing.T) {
@@ -158,6 +218,135 @@ func TestParseEventsAndScalingHelpers(t *testing.T) {
 	}
 }
 
+func TestParseEventsAsyncSources(t *testing.T) {
+	events := parseEvents(map[string]any{
+		"Queue": map[string]any{
+			"Type": "SQS",
+			"Properties": map[string]any{
+				"Queue":     "arn:aws:local:Arn:global:OrdersQueue/Arn",
+				"BatchSize": 5,
+				"FilterCriteria": map[string]any{
+					"Filters": []any{
+						map[string]any{"Pattern": `{"body":{"kind":["order"]}}`},
+					},
+				},
+			},
+		},
+		"Topic": map[string]any{
+			"Type": "SNS",
+			"Properties": map[string]any{
+				"Topic":        "Notifications",
+				"FilterPolicy": map[string]any{"kind": []any{"alert"}},
+			},
+		},
+		"Upload": map[string]any{
+			"Type": "S3",
+			"Properties": map[string]any{
+				"Bucket": "Uploads",
+				"Events": "s3:ObjectCreated:*",
+				"Filter": map[string]any{
+					"S3Key": map[string]any{
+						"Rules": []any{
+							map[string]any{"Name": "prefix", "Value": "incoming/"},
+							map[string]any{"Name": "suffix", "Value": ".csv"},
+						},
+					},
+				},
+			},
+		},
+		"Stream": map[string]any{
+			"Type": "DynamoDB",
+			"Properties": map[string]any{
+				"Stream":           "arn:aws:local:StreamArn:global:OrdersTable/StreamArn",
+				"StartingPosition": "trim_horizon",
+				"BatchSize":        "10",
+			},
+		},
+		"Disabled": map[string]any{
+			"Type": "SQS",
+			"Properties": map[string]any{
+				"Queue":   "Ignored",
+				"Enabled": false,
+			},
+		},
+	})
+	if len(events) != 4 {
+		t.Fatalf("expected 4 events, got %d: %+v", len(events), events)
+	}
+
+	queue := events[0]
+	if queue.Type != template.EventTypeSQS || queue.Queue == "" {
+		t.Fatalf("unexpected sqs event: %+v", queue)
+	}
+	if queue.BatchSize == nil || *queue.BatchSize != 5 {
+		t.Fatalf("unexpected sqs batch size: %+v", queue.BatchSize)
+	}
+	if len(queue.FilterPatterns) != 1 || queue.FilterPatterns[0] != `{"body":{"kind":["order"]}}` {
+		t.Fatalf("unexpected sqs filter patterns: %+v", queue.FilterPatterns)
+	}
+
+	stream := events[1]
+	if stream.Type != template.EventTypeDynamoDB || stream.StartingPosition != "TRIM_HORIZON" {
+		t.Fatalf("unexpected dynamodb event: %+v", stream)
+	}
+	if stream.BatchSize == nil || *stream.BatchSize != 10 {
+		t.Fatalf("unexpected dynamodb batch size: %+v", stream.BatchSize)
+	}
+
+	topic := events[2]
+	if topic.Type != template.EventTypeSNS || topic.FilterPolicy != `{"kind":["alert"]}` {
+		t.Fatalf("unexpected sns event: %+v", topic)
+	}
+
+	upload := events[3]
+	if upload.Type != template.EventTypeS3 || upload.Bucket != "Uploads" {
+		t.Fatalf("unexpected s3 event: %+v", upload)
+	}
+	if len(upload.BucketEvents) != 1 || upload.BucketEvents[0] != "s3:ObjectCreated:*" {
+		t.Fatalf("unexpected s3 bucket events: %+v", upload.BucketEvents)
+	}
+	if upload.KeyPrefix != "incoming/" || upload.KeySuffix != ".csv" {
+		t.Fatalf("unexpected s3 key filter: %+v", upload)
+	}
+}
+
+func TestResolveEventSourceName(t *testing.T) {
+	resources := map[string]any{
+		"OrdersQueue": map[string]any{
+			"Type":       "AWS::SQS::Queue",
+			"Properties": map[string]any{"QueueName": "orders"},
+		},
+		"Uploads": map[string]any{
+			"Type": "AWS::S3::Bucket",
+		},
+		"OrdersTable": map[string]any{
+			"Type":       "AWS::DynamoDB::Table",
+			"Properties": map[string]any{"TableName": "orders-table"},
+		},
+	}
+	cases := []struct {
+		ref          string
+		resourceType string
+		want         string
+	}{
+		{ref: "arn:aws:local:Arn:global:OrdersQueue/Arn", resourceType: "AWS::SQS::Queue", want: "orders"},
+		{ref: "OrdersQueue", resourceType: "AWS::SQS::Queue", want: "orders"},
+		{ref: "arn:aws:sqs:ap-northeast-1:123456789012:external", resourceType: "AWS::SQS::Queue", want: "external"},
+		{ref: "Uploads", resourceType: "AWS::S3::Bucket", want: "uploads"},
+		{ref: "arn:aws:local:StreamArn:global:OrdersTable/StreamArn", resourceType: "AWS::DynamoDB::Table", want: "orders-table"},
+		{
+			ref:          "arn:aws:dynamodb:ap-northeast-1:123456789012:table/legacy/stream/2024-01-01T00:00:00.000",
+			resourceType: "AWS::DynamoDB::Table",
+			want:         "legacy",
+		},
+	}
+	for _, tc := range cases {
+		if got := resolveEventSourceName(tc.ref, resources, tc.resourceType); got != tc.want {
+			t.Fatalf("resolveEventSourceName(%q)=%q, want %q", tc.ref, got, tc.want)
+		}
+	}
+}
+
 func TestParseEventsDeterministicOrder(t *testing.T) {
 	events := parseEvents(map[string]any{
 		"z_event": map[string]any{
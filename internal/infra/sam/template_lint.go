@@ -0,0 +1,149 @@
+// Where: cli/internal/infra/sam/template_lint.go
+// What: Raw template checks for constructs the local runtime cannot honor.
+// Why: Surface silently dropped resources, events and layers before deploy.
+package sam
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/poruru-code/esb-cli/internal/domain/template"
+	"github.com/poruru-code/esb-cli/internal/domain/value"
+)
+
+// Lint rule identifiers reported by LintTemplate.
+const (
+	LintRuleUnsupportedResource = "unsupported-resource"
+	LintRuleUnsupportedEvent    = "unsupported-event"
+	LintRuleUnresolvedLayer     = "unresolved-layer"
+	LintRuleExternalLayer       = "external-layer"
+)
+
+// LintIssue describes a single template construct flagged by LintTemplate.
+type LintIssue struct {
+	Rule      string
+	LogicalID string
+	Message   string
+}
+
+var supportedResourceTypes = map[string]struct{}{
+	"AWS::Serverless::Function":     {},
+	"AWS::Serverless::LayerVersion": {},
+	"AWS::Serverless::Api":          {},
+	"AWS::Serverless::HttpApi":      {},
+	"AWS::Lambda::Function":         {},
+	"AWS::DynamoDB::Table":          {},
+	"AWS::S3::Bucket":               {},
+	"AWS::SQS::Queue":               {},
+	"AWS::SNS::Topic":               {},
+}
+
+// ignoredResourceTypes only grant AWS-side permissions, which the local
+// runtime does not enforce; skipping them is not a behavior change.
+var ignoredResourceTypes = map[string]struct{}{
+	"AWS::IAM::Role":          {},
+	"AWS::IAM::Policy":        {},
+	"AWS::IAM::ManagedPolicy": {},
+	"AWS::Lambda::Permission": {},
+}
+
+var supportedEventTypes = map[string]struct{}{
+	template.EventTypeAPI:      {},
+	template.EventTypeHTTPAPI:  {},
+	template.EventTypeSchedule: {},
+	template.EventTypeSQS:      {},
+	template.EventTypeSNS:      {},
+	template.EventTypeS3:       {},
+	template.EventTypeDynamoDB: {},
+}
+
+// LintTemplate resolves the template and reports resources, events and layer
+// references that ParseSAMTemplate would ignore.
+func LintTemplate(content string, parameters map[string]string) ([]LintIssue, error) {
+	resolved, err := resolveTemplateDocument(content, parameters)
+	if err != nil {
+		return nil, err
+	}
+	resources := value.AsMap(resolved["Resources"])
+	if resources == nil {
+		return nil, nil
+	}
+	globalLayers := extractFunctionGlobals(resolved)["Layers"]
+
+	issues := []LintIssue{}
+	for _, logicalID := range sortedMapKeys(resources) {
+		resource := value.AsMap(resources[logicalID])
+		if resource == nil {
+			continue
+		}
+		resourceType := value.AsString(resource["Type"])
+		if _, ok := ignoredResourceTypes[resourceType]; ok {
+			continue
+		}
+		if _, ok := supportedResourceTypes[resourceType]; !ok {
+			issues = append(issues, LintIssue{
+				Rule:      LintRuleUnsupportedResource,
+				LogicalID: logicalID,
+				Message:   fmt.Sprintf("resource type %s is not supported locally and will be ignored", resourceType),
+			})
+			continue
+		}
+		if resourceType != "AWS::Serverless::Function" {
+			continue
+		}
+		props := value.AsMap(resource["Properties"])
+		issues = append(issues, lintFunctionEvents(logicalID, value.AsMap(props["Events"]))...)
+
+		layerRefs := props["Layers"]
+		if layerRefs == nil {
+			layerRefs = globalLayers
+		}
+		issues = append(issues, lintFunctionLayers(logicalID, layerRefs, resources)...)
+	}
+	return issues, nil
+}
+
+func lintFunctionEvents(logicalID string, events map[string]any) []LintIssue {
+	var issues []LintIssue
+	for _, eventName := range sortedMapKeys(events) {
+		event := value.AsMap(events[eventName])
+		if event == nil {
+			continue
+		}
+		eventType := value.AsString(event["Type"])
+		if _, ok := supportedEventTypes[eventType]; ok {
+			continue
+		}
+		issues = append(issues, LintIssue{
+			Rule:      LintRuleUnsupportedEvent,
+			LogicalID: logicalID,
+			Message:   fmt.Sprintf("event %s has unsupported type %s and will be ignored", eventName, eventType),
+		})
+	}
+	return issues
+}
+
+func lintFunctionLayers(logicalID string, raw any, resources map[string]any) []LintIssue {
+	var issues []LintIssue
+	for _, ref := range extractLayerRefs(raw) {
+		layer := value.AsMap(resources[ref])
+		if layer != nil && value.AsString(layer["Type"]) == "AWS::Serverless::LayerVersion" {
+			continue
+		}
+		// Deploy skips external ARNs; only unknown logical IDs are broken.
+		if strings.HasPrefix(ref, "arn:") {
+			issues = append(issues, LintIssue{
+				Rule:      LintRuleExternalLayer,
+				LogicalID: logicalID,
+				Message:   fmt.Sprintf("layer %s is an external ARN that cannot be staged locally", ref),
+			})
+			continue
+		}
+		issues = append(issues, LintIssue{
+			Rule:      LintRuleUnresolvedLayer,
+			LogicalID: logicalID,
+			Message:   fmt.Sprintf("layer %s does not match an AWS::Serverless::LayerVersion in the template", ref),
+		})
+	}
+	return issues
+}
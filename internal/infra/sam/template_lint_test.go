@@ -0,0 +1,101 @@
+// Where: cli/internal/infra/sam/template_lint_test.go
+// What: Tests for raw template lint checks.
+// Why: Ensure ignored resources, events and layers are reported by validate.
+package sam
+
+import "testing"
+
+func TestLintTemplateReportsIgnoredConstructs(t *testing.T) {
+	content := `
+AWSTemplateFormatVersion: '2010-09-09'
+Transform: AWS::Serverless-2016-10-31
+Globals:
+  Function:
+    Layers:
+      - !Ref SharedLayer
+Resources:
+  SharedLayer:
+    Type: AWS::Serverless::LayerVersion
+    Properties:
+      ContentUri: layers/shared/
+  AppRule:
+    Type: AWS::Events::Rule
+    Properties: {}
+  HelloFunction:
+    Type: AWS::Serverless::Function
+    Properties:
+      CodeUri: functions/hello/
+      Handler: app.handler
+      Runtime: python3.12
+      Events:
+        Stream:
+          Type: Kinesis
+          Properties:
+            Stream: arn:aws:kinesis:us-east-1:123456789012:stream/demo
+        Api:
+          Type: Api
+          Properties:
+            Path: /hello
+            Method: get
+  ExternalFunction:
+    Type: AWS::Serverless::Function
+    Properties:
+      CodeUri: functions/external/
+      Handler: app.handler
+      Runtime: python3.12
+      Layers:
+        - arn:aws:lambda:us-east-1:123456789012:layer:shared:1
+        - MissingLayer
+`
+
+	issues, err := LintTemplate(content, nil)
+	if err != nil {
+		t.Fatalf("lint template: %v", err)
+	}
+	expected := []LintIssue{
+		{Rule: LintRuleUnsupportedResource, LogicalID: "AppRule"},
+		{Rule: LintRuleExternalLayer, LogicalID: "ExternalFunction"},
+		{Rule: LintRuleUnresolvedLayer, LogicalID: "ExternalFunction"},
+		{Rule: LintRuleUnsupportedEvent, LogicalID: "HelloFunction"},
+	}
+	if len(issues) != len(expected) {
+		t.Fatalf("expected %d issues, got %d: %+v", len(expected), len(issues), issues)
+	}
+	for i, want := range expected {
+		if issues[i].Rule != want.Rule || issues[i].LogicalID != want.LogicalID {
+			t.Fatalf("issue %d: expected %s/%s, got %+v", i, want.Rule, want.LogicalID, issues[i])
+		}
+	}
+	if issues[1].Message != "layer arn:aws:lambda:us-east-1:123456789012:layer:shared:1 is an external ARN that cannot be staged locally" {
+		t.Fatalf("unexpected external layer message: %s", issues[1].Message)
+	}
+}
+
+func TestLintTemplateCleanTemplate(t *testing.T) {
+	content := `
+Resources:
+  HelloFunction:
+    Type: AWS::Serverless::Function
+    Properties:
+      CodeUri: functions/hello/
+      Handler: app.handler
+      Runtime: python3.12
+  HelloRole:
+    Type: AWS::IAM::Role
+    Properties: {}
+  HelloPermission:
+    Type: AWS::Lambda::Permission
+    Properties:
+      FunctionName: !Ref HelloFunction
+      Action: lambda:InvokeFunction
+      Principal: events.amazonaws.com
+`
+
+	issues, err := LintTemplate(content, nil)
+	if err != nil {
+		t.Fatalf("lint template: %v", err)
+	}
+	if len(issues) != 0 {
+		t.Fatalf("expected no issues, got %+v", issues)
+	}
+}
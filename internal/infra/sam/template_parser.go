@@ -15,33 +15,10 @@ func ParseSAMTemplate(content string, parameters map[string]string) (template.Pa
 		parameters = map[string]string{}
 	}
 
-	data, err := DecodeYAML(content)
+	resolved, err := resolveTemplateDocument(content, parameters)
 	if err != nil {
 		return template.ParseResult{}, err
 	}
-	mergedParams := extractParameterDefaults(data)
-	if mergedParams == nil {
-		mergedParams = map[string]string{}
-	}
-	for k, v := range parameters {
-		mergedParams[k] = v
-	}
-
-	resolver := NewIntrinsicResolver(mergedParams)
-	resolver.RawConditions = value.AsMap(data["Conditions"])
-
-	resolvedAny, err := ResolveAll(
-		&Context{MaxDepth: maxResolveDepth},
-		data,
-		resolver,
-	)
-	if err != nil {
-		return template.ParseResult{}, err
-	}
-	resolved := value.AsMap(resolvedAny)
-	if resolved == nil {
-		return template.ParseResult{}, fmt.Errorf("unexpected yaml root")
-	}
 
 	model, err := DecodeTemplate(resolved)
 	if err != nil {
@@ -64,6 +41,8 @@ func ParseSAMTemplate(content string, parameters map[string]string) (template.Pa
 	if err != nil {
 		return template.ParseResult{}, err
 	}
+	resolveEventSources(functions, model.Resources)
+	applyAPIResources(functions, parseAPIResources(model.Resources), warnings.warnf)
 
 	return template.ParseResult{
 		Functions: functions,
@@ -72,6 +51,39 @@ func ParseSAMTemplate(content string, parameters map[string]string) (template.Pa
 	}, nil
 }
 
+// resolveTemplateDocument decodes YAML and resolves intrinsics using template
+// parameter defaults overlaid with the supplied parameters.
+func resolveTemplateDocument(content string, parameters map[string]string) (map[string]any, error) {
+	data, err := DecodeYAML(content)
+	if err != nil {
+		return nil, err
+	}
+	mergedParams := extractParameterDefaults(data)
+	if mergedParams == nil {
+		mergedParams = map[string]string{}
+	}
+	for k, v := range parameters {
+		mergedParams[k] = v
+	}
+
+	resolver := NewIntrinsicResolver(mergedParams)
+	resolver.RawConditions = value.AsMap(data["Conditions"])
+
+	resolvedAny, err := ResolveAll(
+		&Context{MaxDepth: maxResolveDepth},
+		data,
+		resolver,
+	)
+	if err != nil {
+		return nil, err
+	}
+	resolved := value.AsMap(resolvedAny)
+	if resolved == nil {
+		return nil, fmt.Errorf("unexpected yaml root")
+	}
+	return resolved, nil
+}
+
 func extractParameterDefaults(data map[string]any) map[string]string {
 	params := value.AsMap(data["Parameters"])
 	if params == nil {
@@ -499,6 +499,179 @@ func findFunction(fns []template.FunctionSpec, name string) *template.FunctionSp
 	return nil
 }
 
+func TestParseSAMTemplateExplicitAPIs(t *testing.T) {
+	content := `
+AWSTemplateFormatVersion: '2010-09-09'
+Transform: AWS::Serverless-2016-10-31
+Resources:
+  PublicApi:
+    Type: AWS::Serverless::Api
+    Properties:
+      StageName: v1
+      Domain:
+        DomainName: api.example.com
+        BasePath:
+          - orders
+      Auth:
+        DefaultAuthorizer: TokenAuth
+  EdgeApi:
+    Type: AWS::Serverless::HttpApi
+  ProxyFunction:
+    Type: AWS::Serverless::Function
+    Properties:
+      FunctionName: lambda-proxy
+      CodeUri: functions/proxy/
+      Events:
+        Proxy:
+          Type: Api
+          Properties:
+            RestApiId: !Ref PublicApi
+            Path: /{proxy+}
+            Method: ANY
+        Health:
+          Type: Api
+          Properties:
+            RestApiId: !Ref PublicApi
+            Path: /health
+            Method: get
+            Auth:
+              Authorizer: NONE
+        Edge:
+          Type: HttpApi
+          Properties:
+            ApiId: !Ref EdgeApi
+`
+
+	result, err := ParseSAMTemplate(content, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	fn := findFunction(result.Functions, "lambda-proxy")
+	if fn == nil {
+		t.Fatal("lambda-proxy not found")
+		return
+	}
+	if len(fn.Events) != 3 {
+		t.Fatalf("expected 3 events, got %+v", fn.Events)
+	}
+
+	edge := fn.Events[0]
+	if edge.Type != template.EventTypeHTTPAPI || edge.Path != "/{proxy+}" || edge.Method != "any" {
+		t.Fatalf("unexpected httpapi event: %+v", edge)
+	}
+	if edge.API != "EdgeApi" || edge.StageName != "$default" {
+		t.Fatalf("unexpected httpapi binding: %+v", edge)
+	}
+
+	health := fn.Events[1]
+	if health.StageName != "v1" || health.BasePath != "orders" || health.Authorizer != "" {
+		t.Fatalf("unexpected health event: %+v", health)
+	}
+
+	proxy := fn.Events[2]
+	if proxy.Path != "/{proxy+}" || proxy.Method != "any" {
+		t.Fatalf("unexpected proxy route: %+v", proxy)
+	}
+	if proxy.API != "PublicApi" || proxy.StageName != "v1" || proxy.Authorizer != "TokenAuth" {
+		t.Fatalf("unexpected proxy binding: %+v", proxy)
+	}
+	if len(result.Warnings) != 0 {
+		t.Fatalf("unexpected warnings: %v", result.Warnings)
+	}
+}
+
+func TestParseSAMTemplateUnknownAPIWarns(t *testing.T) {
+	content := `
+AWSTemplateFormatVersion: '2010-09-09'
+Transform: AWS::Serverless-2016-10-31
+Resources:
+  ApiFunction:
+    Type: AWS::Serverless::Function
+    Properties:
+      FunctionName: lambda-api
+      CodeUri: functions/api/
+      Events:
+        Get:
+          Type: Api
+          Properties:
+            RestApiId: MissingApi
+            Path: /items
+            Method: get
+`
+
+	result, err := ParseSAMTemplate(content, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(result.Warnings) != 1 || !strings.Contains(result.Warnings[0], "MissingApi") {
+		t.Fatalf("expected unknown api warning, got %v", result.Warnings)
+	}
+}
+
+func TestParseSAMTemplateAsyncEventSources(t *testing.T) {
+	content := `
+AWSTemplateFormatVersion: '2010-09-09'
+Transform: AWS::Serverless-2016-10-31
+Resources:
+  OrdersQueue:
+    Type: AWS::SQS::Queue
+    Properties:
+      QueueName: orders
+  OrdersTable:
+    Type: AWS::DynamoDB::Table
+    Properties:
+      TableName: orders-table
+      KeySchema:
+        - AttributeName: id
+          KeyType: HASH
+  Uploads:
+    Type: AWS::S3::Bucket
+  WorkerFunction:
+    Type: AWS::Serverless::Function
+    Properties:
+      FunctionName: lambda-worker
+      CodeUri: functions/worker/
+      Events:
+        Queue:
+          Type: SQS
+          Properties:
+            Queue: !GetAtt OrdersQueue.Arn
+            BatchSize: 10
+        Stream:
+          Type: DynamoDB
+          Properties:
+            Stream: !GetAtt OrdersTable.StreamArn
+            StartingPosition: LATEST
+        Upload:
+          Type: S3
+          Properties:
+            Bucket: !Ref Uploads
+            Events: s3:ObjectCreated:*
+`
+
+	result, err := ParseSAMTemplate(content, nil)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	fn := findFunction(result.Functions, "lambda-worker")
+	if fn == nil {
+		t.Fatal("lambda-worker not found")
+		return
+	}
+	if len(fn.Events) != 3 {
+		t.Fatalf("expected 3 events, got %+v", fn.Events)
+	}
+	if fn.Events[0].Type != template.EventTypeSQS || fn.Events[0].Queue != "orders" {
+		t.Fatalf("unexpected sqs event: %+v", fn.Events[0])
+	}
+	if fn.Events[1].Type != template.EventTypeDynamoDB || fn.Events[1].Stream != "orders-table" {
+		t.Fatalf("unexpected dynamodb event: %+v", fn.Events[1])
+	}
+	if fn.Events[2].Type != template.EventTypeS3 || fn.Events[2].Bucket != "uploads" {
+		t.Fatalf("unexpected s3 event: %+v", fn.Events[2])
+	}
+}
+
 func TestParseSAMTemplateResourcesAndLayers(t *testing.T) {
 	content := `
 AWSTemplateFormatVersion: '2010-09-09'
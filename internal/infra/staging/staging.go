@@ -81,6 +81,16 @@ func ConfigDir(templatePath, composeProject, env string) (string, error) {
 	return filepath.Join(base, "config"), nil
 }
 
+// HistoryDir returns the directory holding the deploy history ledger for a
+// project/env combination.
+func HistoryDir(templatePath, composeProject, env string) (string, error) {
+	base, err := BaseDir(templatePath, composeProject, env)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "history"), nil
+}
+
 func ensureDir(path string) (string, error) {
 	if err := os.MkdirAll(path, 0o755); err != nil {
 		return "", err
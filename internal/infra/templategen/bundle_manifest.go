@@ -13,6 +13,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/poruru-code/esb-cli/internal/domain/template"
+	"github.com/poruru-code/esb-cli/internal/infra/bundlesign"
 	"github.com/poruru-code/esb-cli/internal/infra/compose"
 	"github.com/poruru-code/esb-cli/internal/meta"
 )
@@ -29,23 +31,42 @@ func WriteBundleManifest(ctx context.Context, input BundleManifestInput) (string
 		return "", fmt.Errorf("bundle manifest runner is required")
 	}
 
+	var signer *bundlesign.Signer
+	if strings.TrimSpace(input.SignKey) != "" {
+		loaded, err := bundlesign.NewSigner(input.SignKey)
+		if err != nil {
+			return "", fmt.Errorf("bundle manifest: %w", err)
+		}
+		signer = loaded
+	}
+
 	templatePath := resolveManifestTemplatePath(input.RepoRoot, input.TemplatePath)
 	templateHash, err := hashFileSha256(input.TemplatePath)
 	if err != nil {
 		return "", err
 	}
 	parameters := stringifyParameters(input.Parameters)
-	commit, dirty, err := resolveGitMetadata(ctx, input.Runner, input.RepoRoot)
+	commit, dirty, err := ResolveGitMetadata(ctx, input.Runner, input.RepoRoot)
 	if err != nil {
 		return "", err
 	}
 
-	images, err := collectBundleImages(ctx, input)
+	images, err := collectBundleImages(ctx, input, bundleAttestationOrigin{
+		Template:       templatePath,
+		TemplateSha256: templateHash,
+		GitCommit:      commit,
+		GitDirty:       dirty,
+	})
 	if err != nil {
 		return "", err
 	}
+	if signer != nil {
+		if err := resolveManifestDigests(ctx, input, images); err != nil {
+			return "", err
+		}
+	}
 
-	template := bundleTemplate{
+	templateEntry := bundleTemplate{
 		Path:       templatePath,
 		Sha256:     templateHash,
 		Parameters: parameters,
@@ -53,14 +74,15 @@ func WriteBundleManifest(ctx context.Context, input BundleManifestInput) (string
 	manifest := bundleManifest{
 		SchemaVersion: bundleManifestSchemaVersion,
 		GeneratedAt:   time.Now().UTC().Format(time.RFC3339),
-		Template:      template,
-		Templates:     []bundleTemplate{template},
+		Template:      templateEntry,
+		Templates:     []bundleTemplate{templateEntry},
 		Build: bundleBuild{
 			Project:     input.Project,
 			Env:         input.Env,
 			Mode:        input.Mode,
 			ImagePrefix: meta.ImagePrefix,
 			ImageTag:    input.ImageTag,
+			Platforms:   template.PlatformList(input.Platforms),
 			Git: bundleBuildGit{
 				Commit: commit,
 				Dirty:  dirty,
@@ -81,10 +103,19 @@ func WriteBundleManifest(ctx context.Context, input BundleManifestInput) (string
 	if err := os.WriteFile(path, payload, 0o600); err != nil {
 		return "", fmt.Errorf("write bundle manifest: %w", err)
 	}
+	if signer != nil {
+		if _, err := bundlesign.WriteSignatures(ctx, bundleDir, signer, signedImages(images), input.Registry); err != nil {
+			return "", fmt.Errorf("bundle manifest: %w", err)
+		}
+	}
 	return path, nil
 }
 
-func collectBundleImages(ctx context.Context, input BundleManifestInput) ([]bundleManifestImage, error) {
+func collectBundleImages(
+	ctx context.Context,
+	input BundleManifestInput,
+	origin bundleAttestationOrigin,
+) ([]bundleManifestImage, error) {
 	mode := strings.ToLower(strings.TrimSpace(input.Mode))
 	if mode == "" {
 		mode = compose.ModeDocker
@@ -94,7 +125,7 @@ func collectBundleImages(ctx context.Context, input BundleManifestInput) ([]bund
 	functionRegistry := strings.TrimSpace(input.Registry)
 
 	images := make([]bundleManifestImage, 0)
-	add := func(name, kind, source string) error {
+	addExpected := func(name, kind, source, expectedPlatform string) error {
 		if strings.TrimSpace(name) == "" {
 			return nil
 		}
@@ -106,6 +137,9 @@ func collectBundleImages(ctx context.Context, input BundleManifestInput) ([]bund
 		if platform == "" {
 			return fmt.Errorf("bundle manifest: platform not found for %s", name)
 		}
+		if expectedPlatform != "" && platform != expectedPlatform {
+			return fmt.Errorf("bundle manifest: %s is built for %s, expected %s", name, platform, expectedPlatform)
+		}
 		images = append(images, bundleManifestImage{
 			Name:     name,
 			Digest:   digest,
@@ -115,21 +149,68 @@ func collectBundleImages(ctx context.Context, input BundleManifestInput) ([]bund
 		})
 		return nil
 	}
+	add := func(name, kind, source string) error {
+		return addExpected(name, kind, source, "")
+	}
+	// markUnattested records why the image added last carries none of the
+	// requested attestations.
+	markUnattested := func(reason string) {
+		if len(input.Attestations) > 0 {
+			images[len(images)-1].AttestationsUnavailable = reason
+		}
+	}
+	// attachAttestations records attestations for the image added last.
+	// Only images pushed to the function registry carry them.
+	attachAttestations := func(name string) error {
+		if len(input.Attestations) == 0 {
+			return nil
+		}
+		if functionRegistry == "" {
+			markUnattested(attestationsUnavailableLocal)
+			return nil
+		}
+		attestations, err := collectImageAttestations(ctx, input.Runner, input.RepoRoot, name, input.Attestations, origin)
+		if err != nil {
+			return err
+		}
+		images[len(images)-1].Attestations = attestations
+		return nil
+	}
 
-	if err := add(lambdaBaseImageTag(functionRegistry, input.ImageTag), "base", "generated"); err != nil {
+	lambdaBase := lambdaBaseImageTag(functionRegistry, input.ImageTag)
+	if platforms := template.PlatformList(input.Platforms); len(platforms) > 1 {
+		// Multi-platform lambda bases only exist in the registry as a manifest list.
+		digest := dockerManifestDigest(ctx, input.Runner, input.RepoRoot, lambdaBase)
+		if digest == "" {
+			return nil, fmt.Errorf("bundle manifest: manifest list not found: %s", lambdaBase)
+		}
+		images = append(images, bundleManifestImage{
+			Name:     lambdaBase,
+			Digest:   digest,
+			Kind:     "base",
+			Source:   "generated",
+			Platform: strings.Join(platforms, ","),
+		})
+	} else if err := add(lambdaBase, "base", "generated"); err != nil {
+		return nil, err
+	}
+	if err := attachAttestations(lambdaBase); err != nil {
 		return nil, err
 	}
 	if err := add(fmt.Sprintf("%s-os-base:latest", meta.ImagePrefix), "base", "internal"); err != nil {
 		return nil, err
 	}
+	markUnattested(attestationsUnavailableLocal)
 	if err := add(fmt.Sprintf("%s-python-base:latest", meta.ImagePrefix), "base", "internal"); err != nil {
 		return nil, err
 	}
+	markUnattested(attestationsUnavailableLocal)
 
 	for _, name := range controlPlaneImages(mode, serviceRegistry, input.ImageTag) {
 		if err := add(name, "service", "internal"); err != nil {
 			return nil, err
 		}
+		markUnattested(attestationsUnavailablePrebuilt)
 	}
 
 	for _, fn := range input.Functions {
@@ -141,7 +222,10 @@ func collectBundleImages(ctx context.Context, input BundleManifestInput) ([]bund
 		if imageTag == "" {
 			return nil, fmt.Errorf("bundle manifest: image name is required for function %s", fn.Name)
 		}
-		if err := add(imageTag, "function", "template"); err != nil {
+		if err := addExpected(imageTag, "function", "template", input.Platforms[fn.Name]); err != nil {
+			return nil, err
+		}
+		if err := attachAttestations(imageTag); err != nil {
 			return nil, err
 		}
 	}
@@ -153,6 +237,7 @@ func collectBundleImages(ctx context.Context, input BundleManifestInput) ([]bund
 		if err := add(name, "external", "external"); err != nil {
 			return nil, err
 		}
+		markUnattested(attestationsUnavailableExternal)
 	}
 
 	return images, nil
@@ -222,6 +307,22 @@ func dockerImagePlatform(
 	return strings.TrimSpace(string(out))
 }
 
+func dockerManifestDigest(
+	ctx context.Context,
+	runner compose.CommandRunner,
+	contextDir string,
+	imageTag string,
+) string {
+	if runner == nil || imageTag == "" {
+		return ""
+	}
+	out, err := runner.RunOutput(ctx, contextDir, "docker", "buildx", "imagetools", "inspect", "--format", "{{.Manifest.Digest}}", imageTag)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
 func ensureDockerImage(
 	ctx context.Context,
 	runner compose.CommandRunner,
@@ -0,0 +1,103 @@
+// Where: cli/internal/infra/templategen/bundle_manifest_attestations.go
+// What: SBOM/provenance attestation lookup for bundle manifest images.
+// Why: Let security review trace every shipped image to its attestations and source.
+package templategen
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/poruru-code/esb-cli/internal/infra/bundlesign"
+	"github.com/poruru-code/esb-cli/internal/infra/compose"
+)
+
+const (
+	attestationReferenceTypeKey   = "vnd.docker.reference.type"
+	attestationReferenceDigestKey = "vnd.docker.reference.digest"
+	attestationManifestType       = "attestation-manifest"
+)
+
+// Reasons recorded for images that cannot carry requested attestations.
+const (
+	// The docker exporter drops attestations; only registry pushes keep them.
+	attestationsUnavailableLocal    = "local-only image (docker exporter drops attestations)"
+	attestationsUnavailablePrebuilt = "control-plane image built by docker compose, not by esb"
+	attestationsUnavailableExternal = "external image"
+)
+
+// ociIndex is the subset of an OCI image index needed to find attestations.
+type ociIndex struct {
+	Manifests []ociDescriptor `json:"manifests"`
+}
+
+type ociDescriptor struct {
+	Digest      string            `json:"digest"`
+	Platform    *ociPlatform      `json:"platform,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+type ociPlatform struct {
+	OS           string `json:"os"`
+	Architecture string `json:"architecture"`
+}
+
+// bundleAttestationOrigin records what the attested image was derived from.
+type bundleAttestationOrigin struct {
+	Template       string `json:"template"`
+	TemplateSha256 string `json:"template_sha256"`
+	GitCommit      string `json:"git_commit"`
+	GitDirty       bool   `json:"git_dirty"`
+}
+
+// collectImageAttestations reads the registry image index for name and
+// returns its attestation manifests. It fails when attestations were
+// requested but the pushed image carries none.
+func collectImageAttestations(
+	ctx context.Context,
+	runner compose.CommandRunner,
+	contextDir string,
+	name string,
+	types []string,
+	origin bundleAttestationOrigin,
+) (*bundleImageAttestations, error) {
+	raw, err := runner.RunOutput(ctx, contextDir, "docker", "buildx", "imagetools", "inspect", "--raw", name)
+	if err != nil {
+		return nil, fmt.Errorf("bundle manifest: inspect attestations for %s: %w", name, err)
+	}
+	var index ociIndex
+	if err := json.Unmarshal(raw, &index); err != nil {
+		return nil, fmt.Errorf("bundle manifest: parse image index for %s: %w", name, err)
+	}
+	platforms := map[string]string{}
+	for _, desc := range index.Manifests {
+		if desc.Platform != nil && desc.Platform.OS != "unknown" {
+			platforms[desc.Digest] = desc.Platform.OS + "/" + desc.Platform.Architecture
+		}
+	}
+	manifests := make([]bundleAttestationManifest, 0)
+	for _, desc := range index.Manifests {
+		if desc.Annotations[attestationReferenceTypeKey] != attestationManifestType {
+			continue
+		}
+		subject := desc.Annotations[attestationReferenceDigestKey]
+		manifests = append(manifests, bundleAttestationManifest{
+			Digest:   desc.Digest,
+			Subject:  subject,
+			Platform: platforms[subject],
+		})
+	}
+	if len(manifests) == 0 {
+		return nil, fmt.Errorf("bundle manifest: no attestations found for %s (requested %s)", name, strings.Join(types, ", "))
+	}
+	sum := sha256.Sum256(raw)
+	return &bundleImageAttestations{
+		Ref:       bundlesign.Repository(name) + "@sha256:" + hex.EncodeToString(sum[:]),
+		Types:     append([]string{}, types...),
+		Manifests: manifests,
+		Origin:    origin,
+	}, nil
+}
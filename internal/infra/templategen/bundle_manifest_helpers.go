@@ -46,7 +46,9 @@ func stringifyParameters(values map[string]any) map[string]string {
 	return out
 }
 
-func resolveGitMetadata(ctx context.Context, runner compose.CommandRunner, repoRoot string) (string, bool, error) {
+// ResolveGitMetadata returns the HEAD commit of repoRoot and whether the
+// worktree has uncommitted changes.
+func ResolveGitMetadata(ctx context.Context, runner compose.CommandRunner, repoRoot string) (string, bool, error) {
 	commit, err := runGit(ctx, runner, repoRoot, "rev-parse", "HEAD")
 	if err != nil {
 		return "", false, err
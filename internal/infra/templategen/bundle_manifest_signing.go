@@ -0,0 +1,81 @@
+// Where: cli/internal/infra/templategen/bundle_manifest_signing.go
+// What: Registry manifest digest lookup and signing for bundle manifest images.
+// Why: Sign the digests `cosign verify` checks, not local image IDs.
+package templategen
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/poruru-code/esb-cli/internal/infra/bundlesign"
+	"github.com/poruru-code/esb-cli/internal/infra/compose"
+)
+
+// signatureUnavailableLocal is recorded for images that have no registry
+// manifest digest to sign.
+const signatureUnavailableLocal = "local-only image (no registry manifest digest)"
+
+// resolveManifestDigests records the registry manifest digest of every
+// image. Images esb pushes (lambda base, functions) must be in the function
+// registry; other images are signed when Docker knows a repo digest for them.
+func resolveManifestDigests(ctx context.Context, input BundleManifestInput, images []bundleManifestImage) error {
+	registry := strings.TrimSpace(input.Registry)
+	for i := range images {
+		image := &images[i]
+		if image.Kind == "function" || (image.Kind == "base" && image.Source == "generated") {
+			if registry == "" {
+				return fmt.Errorf("bundle manifest: signing requires a registry; %s was not pushed", image.Name)
+			}
+			digest := dockerManifestDigest(ctx, input.Runner, input.RepoRoot, image.Name)
+			if digest == "" {
+				return fmt.Errorf("bundle manifest: registry manifest not found: %s", image.Name)
+			}
+			image.ManifestDigest = digest
+			continue
+		}
+		if digest := dockerRepoDigest(ctx, input.Runner, input.RepoRoot, image.Name); digest != "" {
+			image.ManifestDigest = digest
+			continue
+		}
+		image.SignatureUnavailable = signatureUnavailableLocal
+	}
+	return nil
+}
+
+// signedImages lists the images that carry a registry manifest digest.
+func signedImages(images []bundleManifestImage) []bundlesign.Image {
+	signed := make([]bundlesign.Image, 0, len(images))
+	for _, image := range images {
+		if image.ManifestDigest == "" {
+			continue
+		}
+		signed = append(signed, bundlesign.Image{Name: image.Name, Digest: image.ManifestDigest})
+	}
+	return signed
+}
+
+// dockerRepoDigest returns the manifest digest Docker recorded when the
+// image was pulled from or pushed to the registry of imageTag.
+func dockerRepoDigest(
+	ctx context.Context,
+	runner compose.CommandRunner,
+	contextDir string,
+	imageTag string,
+) string {
+	if runner == nil || strings.TrimSpace(imageTag) == "" {
+		return ""
+	}
+	out, err := runner.RunOutput(ctx, contextDir, "docker", "image", "inspect", "--format", `{{join .RepoDigests "\n"}}`, imageTag)
+	if err != nil {
+		return ""
+	}
+	repository := bundlesign.Repository(imageTag)
+	for _, line := range strings.Split(string(out), "\n") {
+		repo, digest, ok := strings.Cut(strings.TrimSpace(line), "@")
+		if ok && repo == repository {
+			return digest
+		}
+	}
+	return ""
+}
@@ -5,7 +5,13 @@ package templategen
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
 	"encoding/json"
+	"encoding/pem"
+	"fmt"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -13,16 +19,23 @@ import (
 	"testing"
 
 	"github.com/poruru-code/esb-cli/internal/domain/template"
+	"github.com/poruru-code/esb-cli/internal/infra/bundlesign"
 	"github.com/poruru-code/esb-cli/internal/meta"
 )
 
 type manifestImageMeta struct {
 	id       string
 	platform string
+	// repoDigests lists the repo@digest entries Docker recorded for the image.
+	repoDigests []string
 }
 
 type manifestRunner struct {
 	images map[string]manifestImageMeta
+	// manifestLists maps tags to registry manifest list digests.
+	manifestLists map[string]string
+	// indexes maps tags to raw registry image index JSON.
+	indexes map[string]string
 }
 
 func (r *manifestRunner) Run(_ context.Context, _, _ string, _ ...string) error {
@@ -42,6 +55,18 @@ func (r *manifestRunner) RunOutput(_ context.Context, _, name string, args ...st
 			return []byte(""), nil
 		}
 	}
+	if name == "docker" && len(args) >= 5 && args[0] == "buildx" && args[1] == "imagetools" && args[3] == "--raw" {
+		if index, ok := r.indexes[args[len(args)-1]]; ok {
+			return []byte(index), nil
+		}
+		return nil, fmt.Errorf("no such manifest")
+	}
+	if name == "docker" && len(args) >= 5 && args[0] == "buildx" && args[1] == "imagetools" {
+		if digest, ok := r.manifestLists[args[len(args)-1]]; ok {
+			return []byte(digest + "\n"), nil
+		}
+		return []byte(""), nil
+	}
 	if name == "docker" && len(args) >= 3 && args[0] == "image" {
 		switch args[1] {
 		case "ls":
@@ -65,6 +90,8 @@ func (r *manifestRunner) RunOutput(_ context.Context, _, name string, args ...st
 				return []byte(meta.id + "\n"), nil
 			case "{{.Os}}/{{.Architecture}}":
 				return []byte(meta.platform + "\n"), nil
+			case `{{join .RepoDigests "\n"}}`:
+				return []byte(strings.Join(meta.repoDigests, "\n") + "\n"), nil
 			}
 		}
 	}
@@ -308,6 +335,281 @@ func TestWriteBundleManifestFailsWhenImageMissing(t *testing.T) {
 	}
 }
 
+func TestWriteBundleManifestRecordsFunctionPlatforms(t *testing.T) {
+	tmpDir := t.TempDir()
+	templatePath := filepath.Join(tmpDir, "template.yaml")
+	if err := os.WriteFile(templatePath, []byte("Resources: {}"), 0o600); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+
+	imageTag := "latest"
+	armImage := meta.ImagePrefix + "-lambda-arm:latest"
+	x86Image := meta.ImagePrefix + "-lambda-x86:latest"
+	images := dockerManifestImages(imageTag, x86Image)
+	images[armImage] = manifestImageMeta{id: "sha256:cccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccc", platform: "linux/arm64"}
+	delete(images, lambdaBaseImageTag("", imageTag))
+	runner := &manifestRunner{
+		images:        images,
+		manifestLists: map[string]string{lambdaBaseImageTag("", imageTag): "sha256:dddd"},
+	}
+
+	outputDir := filepath.Join(tmpDir, meta.OutputDir, "default")
+	if err := ensureDir(outputDir); err != nil {
+		t.Fatalf("ensure output dir: %v", err)
+	}
+	input := BundleManifestInput{
+		RepoRoot:     tmpDir,
+		OutputDir:    outputDir,
+		TemplatePath: templatePath,
+		Project:      "esb-default",
+		Env:          "default",
+		Mode:         "docker",
+		ImageTag:     imageTag,
+		Functions: []template.FunctionSpec{
+			{Name: "Arm", ImageName: "lambda-arm"},
+			{Name: "X86", ImageName: "lambda-x86"},
+		},
+		Platforms: map[string]string{"Arm": "linux/arm64", "X86": "linux/amd64"},
+		Runner:    runner,
+	}
+	path, err := WriteBundleManifest(t.Context(), input)
+	if err != nil {
+		t.Fatalf("write bundle manifest: %v", err)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read manifest: %v", err)
+	}
+	var manifest bundleManifest
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		t.Fatalf("unmarshal manifest: %v", err)
+	}
+	if !reflect.DeepEqual(manifest.Build.Platforms, []string{"linux/amd64", "linux/arm64"}) {
+		t.Fatalf("unexpected build platforms: %v", manifest.Build.Platforms)
+	}
+	for _, image := range manifest.Images {
+		if image.Name == lambdaBaseImageTag("", imageTag) {
+			if image.Platform != "linux/amd64,linux/arm64" || image.Digest != "sha256:dddd" {
+				t.Fatalf("unexpected lambda base entry: %+v", image)
+			}
+		}
+	}
+
+	input.Platforms = map[string]string{"Arm": "linux/amd64", "X86": "linux/amd64"}
+	runner.images[lambdaBaseImageTag("", imageTag)] = manifestImageMeta{id: "sha256:eeee", platform: "linux/amd64"}
+	if _, err := WriteBundleManifest(t.Context(), input); err == nil || !strings.Contains(err.Error(), "expected linux/amd64") {
+		t.Fatalf("expected platform mismatch error, got %v", err)
+	}
+}
+
+func TestWriteBundleManifestRecordsAttestations(t *testing.T) {
+	tmpDir := t.TempDir()
+	templatePath := filepath.Join(tmpDir, "template.yaml")
+	if err := os.WriteFile(templatePath, []byte("Resources: {}"), 0o600); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+
+	registry := "registry:5010/"
+	imageTag := "latest"
+	functionImage := registry + meta.ImagePrefix + "-lambda-hello:" + imageTag
+	lambdaBase := lambdaBaseImageTag(registry, imageTag)
+	images := dockerManifestImages(imageTag, functionImage)
+	images[lambdaBase] = images[lambdaBaseImageTag("", imageTag)]
+	index := `{"manifests": [
+  {"digest": "sha256:img", "platform": {"os": "linux", "architecture": "amd64"}},
+  {"digest": "sha256:att", "platform": {"os": "unknown", "architecture": "unknown"},
+   "annotations": {"vnd.docker.reference.type": "attestation-manifest", "vnd.docker.reference.digest": "sha256:img"}}
+]}`
+	runner := &manifestRunner{
+		images:  images,
+		indexes: map[string]string{functionImage: index, lambdaBase: index},
+	}
+	outputDir := filepath.Join(tmpDir, meta.OutputDir, "default")
+	input := BundleManifestInput{
+		RepoRoot:     tmpDir,
+		OutputDir:    outputDir,
+		TemplatePath: templatePath,
+		Project:      "esb-default",
+		Env:          "default",
+		Mode:         "docker",
+		ImageTag:     imageTag,
+		Registry:     registry,
+		Functions:    []template.FunctionSpec{{Name: "Hello", ImageName: "lambda-hello"}},
+		Attestations: []string{"sbom", "provenance"},
+		Runner:       runner,
+	}
+	path, err := WriteBundleManifest(t.Context(), input)
+	if err != nil {
+		t.Fatalf("write bundle manifest: %v", err)
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read manifest: %v", err)
+	}
+	var manifest bundleManifest
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		t.Fatalf("unmarshal manifest: %v", err)
+	}
+	if manifest.SchemaVersion != "1.5" {
+		t.Fatalf("unexpected schema version: %s", manifest.SchemaVersion)
+	}
+	var fn *bundleManifestImage
+	for i := range manifest.Images {
+		image := &manifest.Images[i]
+		switch image.Name {
+		case functionImage:
+			fn = image
+		case lambdaBase:
+			if image.Attestations == nil {
+				t.Fatalf("expected lambda base attestations")
+			}
+		default:
+			if image.Attestations != nil {
+				t.Fatalf("unexpected attestations for %s", image.Name)
+			}
+			// Base, control-plane and external images state why they carry none.
+			if image.AttestationsUnavailable == "" {
+				t.Fatalf("expected attestations_unavailable for %s", image.Name)
+			}
+		}
+		if image.Kind == "service" && image.AttestationsUnavailable != attestationsUnavailablePrebuilt {
+			t.Fatalf("unexpected control-plane reason for %s: %q", image.Name, image.AttestationsUnavailable)
+		}
+	}
+	if fn == nil || fn.Attestations == nil {
+		t.Fatalf("expected function attestations, got %+v", fn)
+	}
+	att := fn.Attestations
+	if !strings.HasPrefix(att.Ref, registry+meta.ImagePrefix+"-lambda-hello@sha256:") {
+		t.Fatalf("unexpected attestation ref: %s", att.Ref)
+	}
+	want := []bundleAttestationManifest{{Digest: "sha256:att", Subject: "sha256:img", Platform: "linux/amd64"}}
+	if !reflect.DeepEqual(att.Manifests, want) || !reflect.DeepEqual(att.Types, []string{"sbom", "provenance"}) {
+		t.Fatalf("unexpected attestations: %+v", att)
+	}
+	if att.Origin.Template != "template.yaml" || att.Origin.GitCommit != "deadbeef" || att.Origin.TemplateSha256 == "" {
+		t.Fatalf("unexpected attestation origin: %+v", att.Origin)
+	}
+
+	runner.indexes[functionImage] = `{"manifests": [{"digest": "sha256:img"}]}`
+	if _, err := WriteBundleManifest(t.Context(), input); err == nil || !strings.Contains(err.Error(), "no attestations found") {
+		t.Fatalf("expected missing attestation error, got %v", err)
+	}
+}
+
+func writeSigningKeyPair(t *testing.T, dir string) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("marshal key: %v", err)
+	}
+	pubDER, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("marshal public key: %v", err)
+	}
+	keyPath := filepath.Join(dir, "cosign.key")
+	pubPath := filepath.Join(dir, "cosign.pub")
+	if err := os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		t.Fatalf("write key: %v", err)
+	}
+	if err := os.WriteFile(pubPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pubDER}), 0o600); err != nil {
+		t.Fatalf("write public key: %v", err)
+	}
+	return keyPath, pubPath
+}
+
+func TestWriteBundleManifestSignsRegistryManifestDigests(t *testing.T) {
+	tmpDir := t.TempDir()
+	templatePath := filepath.Join(tmpDir, "template.yaml")
+	if err := os.WriteFile(templatePath, []byte("Resources: {}"), 0o600); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+	registry := "127.0.0.1:5010/"
+	imageTag := "latest"
+	functionImage := registry + meta.ImagePrefix + "-lambda-hello:" + imageTag
+	lambdaBase := lambdaBaseImageTag(registry, imageTag)
+	images := dockerManifestImages(imageTag, functionImage)
+	images[lambdaBase] = images[lambdaBaseImageTag("", imageTag)]
+	images[meta.ImagePrefix+"-lambda-hello:"+imageTag] = images[functionImage]
+	alpine := images["alpine:latest"]
+	alpine.repoDigests = []string{"alpine@sha256:cccc"}
+	images["alpine:latest"] = alpine
+	runner := &manifestRunner{
+		images: images,
+		manifestLists: map[string]string{
+			functionImage: "sha256:aaaa",
+			lambdaBase:    "sha256:bbbb",
+		},
+	}
+	outputDir := filepath.Join(tmpDir, meta.OutputDir, "default")
+	keyPath, pubKey := writeSigningKeyPair(t, tmpDir)
+	input := BundleManifestInput{
+		RepoRoot:     tmpDir,
+		OutputDir:    outputDir,
+		TemplatePath: templatePath,
+		Project:      "esb-default",
+		Env:          "default",
+		Mode:         "docker",
+		ImageTag:     imageTag,
+		Registry:     registry,
+		Functions:    []template.FunctionSpec{{Name: "Hello", ImageName: "lambda-hello"}},
+		SignKey:      keyPath,
+		Runner:       runner,
+	}
+
+	path, err := WriteBundleManifest(t.Context(), input)
+	if err != nil {
+		t.Fatalf("write bundle manifest: %v", err)
+	}
+	verified, err := bundlesign.VerifyBundle(path, pubKey)
+	if err != nil {
+		t.Fatalf("verify signed bundle: %v", err)
+	}
+	want := []bundlesign.Image{
+		{Name: lambdaBase, Digest: "sha256:bbbb"},
+		{Name: functionImage, Digest: "sha256:aaaa"},
+		{Name: "alpine:latest", Digest: "sha256:cccc"},
+	}
+	if !reflect.DeepEqual(verified, want) {
+		t.Fatalf("unexpected signed images: %+v", verified)
+	}
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read manifest: %v", err)
+	}
+	var manifest bundleManifest
+	if err := json.Unmarshal(raw, &manifest); err != nil {
+		t.Fatalf("unmarshal manifest: %v", err)
+	}
+	for _, image := range manifest.Images {
+		if image.Name == functionImage && image.Digest != images[functionImage].id {
+			t.Fatalf("digest must stay the local image id, got %s", image.Digest)
+		}
+		if image.Name == meta.ImagePrefix+"-os-base:latest" && image.SignatureUnavailable != signatureUnavailableLocal {
+			t.Fatalf("expected local-only reason for %s, got %+v", image.Name, image)
+		}
+	}
+
+	unpushed := input
+	unpushed.Registry = ""
+	if _, err := WriteBundleManifest(t.Context(), unpushed); err == nil ||
+		!strings.Contains(err.Error(), "signing requires a registry") {
+		t.Fatalf("expected registry requirement error, got %v", err)
+	}
+
+	missingKey := input
+	missingKey.SignKey = filepath.Join(tmpDir, "missing.key")
+	if _, err := WriteBundleManifest(t.Context(), missingKey); err == nil || !strings.Contains(err.Error(), "read signing key") {
+		t.Fatalf("expected signing key error, got %v", err)
+	}
+}
+
 func dockerManifestImages(imageTag, functionImage string) map[string]manifestImageMeta {
 	return map[string]manifestImageMeta{
 		lambdaBaseImageTag("", imageTag):            {id: "sha256:1111111111111111111111111111111111111111111111111111111111111111", platform: "linux/amd64"},
@@ -8,7 +8,7 @@ import (
 	"github.com/poruru-code/esb-cli/internal/infra/compose"
 )
 
-const bundleManifestSchemaVersion = "1.1"
+const bundleManifestSchemaVersion = "1.5"
 
 type bundleManifest struct {
 	SchemaVersion string                `json:"schema_version"`
@@ -31,6 +31,7 @@ type bundleBuild struct {
 	Mode        string         `json:"mode"`
 	ImagePrefix string         `json:"image_prefix"`
 	ImageTag    string         `json:"image_tag"`
+	Platforms   []string       `json:"platforms,omitempty"`
 	Git         bundleBuildGit `json:"git"`
 }
 
@@ -46,6 +47,29 @@ type bundleManifestImage struct {
 	Source   string            `json:"source"`
 	Labels   map[string]string `json:"labels,omitempty"`
 	Platform string            `json:"platform"`
+	// Attestations is set for pushed images built with SBOM/provenance.
+	Attestations *bundleImageAttestations `json:"attestations,omitempty"`
+	// AttestationsUnavailable explains why an image carries none of the
+	// requested attestations.
+	AttestationsUnavailable string `json:"attestations_unavailable,omitempty"`
+	// ManifestDigest is the registry manifest digest signed with --sign-key.
+	ManifestDigest string `json:"manifest_digest,omitempty"`
+	// SignatureUnavailable explains why a signed bundle leaves an image unsigned.
+	SignatureUnavailable string `json:"signature_unavailable,omitempty"`
+}
+
+type bundleImageAttestations struct {
+	// Ref pins the registry image index holding the image and attestations.
+	Ref       string                      `json:"ref"`
+	Types     []string                    `json:"types"`
+	Manifests []bundleAttestationManifest `json:"manifests"`
+	Origin    bundleAttestationOrigin     `json:"origin"`
+}
+
+type bundleAttestationManifest struct {
+	Digest   string `json:"digest"`
+	Subject  string `json:"subject"`
+	Platform string `json:"platform,omitempty"`
 }
 
 // BundleManifestInput captures bundle manifest generation inputs.
@@ -61,5 +85,13 @@ type BundleManifestInput struct {
 	Registry        string
 	ServiceRegistry string
 	Functions       []template.FunctionSpec
-	Runner          compose.CommandRunner
+	// Platforms maps function names to their target platform; nil means
+	// every image was built for the host platform.
+	Platforms map[string]string
+	// Attestations lists the attestation types requested for pushed images
+	// (sbom, provenance); empty skips attestation lookup.
+	Attestations []string
+	// SignKey is an ECDSA signing key file or "keyless"; empty skips signing.
+	SignKey string
+	Runner  compose.CommandRunner
 }
@@ -4,6 +4,7 @@
 package templategen
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
@@ -12,14 +13,18 @@ import (
 
 	runtimecfg "github.com/poruru-code/esb-cli/internal/domain/runtime"
 	"github.com/poruru-code/esb-cli/internal/domain/template"
+	"github.com/poruru-code/esb-cli/internal/infra/compose"
 	"github.com/poruru-code/esb-cli/internal/infra/config"
 	samparser "github.com/poruru-code/esb-cli/internal/infra/sam"
+	"github.com/poruru-code/esb-cli/internal/infra/ui"
 )
 
 const runtimeBaseContextDirName = "runtime-base"
 
 // GenerateOptions configures Generator.GenerateFiles behavior.
 type GenerateOptions struct {
+	// Context cancels staging and custom builds. Nil means context.Background.
+	Context             context.Context
 	ProjectRoot         string
 	DryRun              bool
 	Verbose             bool
@@ -33,12 +38,25 @@ type GenerateOptions struct {
 	ImageRuntimes       map[string]string
 	SitecustomizeSource string
 	Parser              samparser.Parser
+	// Functions limits staging to functions matching these names or globs.
+	// Other functions keep the directories staged by a previous run.
+	// Empty stages every function.
+	Functions []string
+	// Events receives template warnings in JSON output mode.
+	Events ui.EventSink
+	// Runner executes custom function builds (BuildMethod: makefile).
+	// Defaults to an exec runner writing to Out.
+	Runner compose.CommandRunner
 }
 
 // GenerateFiles runs the generator pipeline: parse, stage assets, and render configs.
 func GenerateFiles(cfg config.GeneratorConfig, opts GenerateOptions) ([]template.FunctionSpec, error) {
 	out := resolveGenerateOutput(opts.Out)
 	errOut := resolveGenerateErrOutput(opts.Out)
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
 
 	projectRoot := opts.ProjectRoot
 	if projectRoot == "" {
@@ -82,10 +100,16 @@ func GenerateFiles(cfg config.GeneratorConfig, opts GenerateOptions) ([]template
 	}
 	for _, warning := range parsed.Warnings {
 		_, _ = fmt.Fprintf(errOut, "Warning: %s\n", warning)
+		if opts.Events != nil {
+			opts.Events.Emit(ui.EventWarning, ui.WarningEvent{Message: warning})
+		}
 	}
 	if err := template.ApplyImageNames(parsed.Functions); err != nil {
 		return nil, err
 	}
+	if err := template.ValidateArchitectures(parsed.Functions); err != nil {
+		return nil, err
+	}
 	if err := applyImageSourceOverrides(parsed.Functions, opts.ImageSources); err != nil {
 		return nil, err
 	}
@@ -104,25 +128,47 @@ func GenerateFiles(cfg config.GeneratorConfig, opts GenerateOptions) ([]template
 	layerCacheDir := filepath.Join(outputDir, ".layers_cache")
 	runtimeBaseDir := filepath.Join(outputDir, runtimeBaseContextDirName)
 
+	selector, err := template.NewFunctionSelector(opts.Functions)
+	if err != nil {
+		return nil, err
+	}
 	if !opts.DryRun {
-		if err := removeDir(functionsDir); err != nil {
-			return nil, err
-		}
-		if err := removeDir(runtimeBaseDir); err != nil {
-			return nil, err
+		if selector.Empty() {
+			if err := removeDir(functionsDir); err != nil {
+				return nil, err
+			}
+			if err := removeDir(runtimeBaseDir); err != nil {
+				return nil, err
+			}
 		}
 		if err := ensureDir(layerCacheDir); err != nil {
 			return nil, err
 		}
 	}
 
+	runner := opts.Runner
+	if runner == nil {
+		runner = compose.ExecRunner{Out: out, ErrOut: errOut}
+	}
+
 	functions := make([]template.FunctionSpec, 0, len(parsed.Functions))
 
 	for _, fn := range parsed.Functions {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		if opts.Verbose {
 			_, _ = fmt.Fprintf(out, "Processing function: %s\n", fn.Name)
 		}
 
+		if fn.ImageBuild != nil {
+			imageBuild, err := resolveImageBuild(fn, baseDir)
+			if err != nil {
+				return nil, err
+			}
+			fn.ImageBuild = imageBuild
+			fn.ImageSource = template.SourceImageRef(fn)
+		}
 		if strings.TrimSpace(fn.ImageSource) != "" {
 			resolvedRuntime, err := resolveImageFunctionRuntime(fn.Name, opts.ImageRuntimes)
 			if err != nil {
@@ -131,32 +177,68 @@ func GenerateFiles(cfg config.GeneratorConfig, opts GenerateOptions) ([]template
 			fn.Runtime = resolvedRuntime
 		}
 
+		if warning := buildMethodWarning(fn); warning != "" {
+			_, _ = fmt.Fprintf(errOut, "Warning: %s\n", warning)
+			if opts.Events != nil {
+				opts.Events.Emit(ui.EventWarning, ui.WarningEvent{Message: warning})
+			}
+		}
+
+		partial := !selector.Empty()
+		reuseStaged := !opts.DryRun && partial && !selector.Match(fn.Name) &&
+			dirExists(filepath.Join(functionsDir, fn.Name))
+		if !opts.DryRun && partial && selector.Match(fn.Name) {
+			if err := removeDir(filepath.Join(functionsDir, fn.Name)); err != nil {
+				return nil, err
+			}
+		}
+
 		staged, err := stageFunction(
 			fn,
 			stageContext{
+				Context:           ctx,
 				BaseDir:           baseDir,
 				OutputDir:         outputDir,
 				FunctionsDir:      functionsDir,
 				LayerCacheDir:     layerCacheDir,
-				DryRun:            opts.DryRun,
+				DryRun:            opts.DryRun || reuseStaged,
 				Verbose:           opts.Verbose,
 				Out:               out,
 				ProjectRoot:       projectRoot,
 				SitecustomizePath: opts.SitecustomizeSource,
+				Runner:            runner,
 			},
 		)
 		if err != nil {
+			if ctx.Err() != nil && !opts.DryRun && !reuseStaged {
+				// A cancelled custom build leaves a half-staged function
+				// directory; drop it so the next run stages it from scratch.
+				_ = removeDir(filepath.Join(functionsDir, fn.Name))
+			}
 			return nil, err
 		}
 
+		runtimeTmpl, err := resolveDockerfileTemplate(projectRoot, baseDir, staged.Function)
+		if err != nil {
+			return nil, err
+		}
+		if opts.Verbose && !runtimeTmpl.Dockerfile.Empty() {
+			_, _ = fmt.Fprintf(out, "  Using dockerfile template %s\n", runtimeTmpl.Dockerfile.Source)
+		}
+		if !opts.DryRun && !reuseStaged {
+			if err := copyRuntimeTemplateAssets(runtimeTmpl.AssetsDir, staged.FunctionDir, fn.Name); err != nil {
+				return nil, err
+			}
+		}
 		dockerConfig := template.DockerConfig{
 			SitecustomizeSource: staged.SitecustomizeRef,
+			Template:            runtimeTmpl.Dockerfile,
 		}
 		dockerfile, err := template.RenderDockerfile(staged.Function, dockerConfig, buildRegistry, resolvedTag)
 		if err != nil {
 			return nil, err
 		}
-		if !opts.DryRun {
+		if !opts.DryRun && !reuseStaged {
 			if err := writeFile(filepath.Join(staged.FunctionDir, "Dockerfile"), dockerfile); err != nil {
 				return nil, err
 			}
@@ -166,6 +248,11 @@ func GenerateFiles(cfg config.GeneratorConfig, opts GenerateOptions) ([]template
 	}
 
 	sortFunctionsByName(functions)
+	// Stop before rewriting the config files so they never describe a
+	// partially staged run.
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 
 	functionsYmlPath := resolveConfigPath(cfg.Paths.FunctionsYml, baseDir, outputDir, "functions.yml")
 	functionsContent, err := template.RenderFunctionsYml(functions, runtimeRegistry, resolvedTag)
@@ -215,7 +302,7 @@ func resolveImageFunctionRuntime(functionName string, runtimes map[string]string
 		return "", fmt.Errorf("image function %s runtime: %w", functionName, err)
 	}
 	switch profile.Kind {
-	case runtimecfg.KindPython, runtimecfg.KindJava:
+	case runtimecfg.KindPython, runtimecfg.KindJava, runtimecfg.KindNode:
 		return profile.Name, nil
 	default:
 		return "", fmt.Errorf("image function %s uses unsupported runtime %q", functionName, runtimeValue)
@@ -235,10 +322,12 @@ func applyImageSourceOverrides(functions []template.FunctionSpec, imageSources m
 		if override == "" {
 			continue
 		}
-		if strings.TrimSpace(functions[i].ImageSource) == "" {
+		if strings.TrimSpace(functions[i].ImageSource) == "" && functions[i].ImageBuild == nil {
 			return fmt.Errorf("image source override for non-image function %s", functions[i].Name)
 		}
+		// An explicit image source replaces the Metadata source build.
 		functions[i].ImageSource = override
+		functions[i].ImageBuild = nil
 	}
 	return nil
 }
@@ -6,6 +6,9 @@ package templategen
 import (
 	"archive/zip"
 	"bytes"
+	"context"
+	"errors"
+	"fmt"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -48,7 +51,15 @@ func TestResolveImageFunctionRuntime(t *testing.T) {
 		t.Fatalf("expected java21 runtime, got %q", got)
 	}
 
-	if _, err := resolveImageFunctionRuntime("lambda-image", map[string]string{"lambda-image": "nodejs20.x"}); err == nil {
+	got, err = resolveImageFunctionRuntime("lambda-image", map[string]string{"lambda-image": "nodejs20.x"})
+	if err != nil {
+		t.Fatalf("resolve nodejs runtime: %v", err)
+	}
+	if got != "nodejs20.x" {
+		t.Fatalf("expected nodejs20.x runtime, got %q", got)
+	}
+
+	if _, err := resolveImageFunctionRuntime("lambda-image", map[string]string{"lambda-image": "ruby3.3"}); err == nil {
 		t.Fatalf("expected unsupported runtime error")
 	}
 }
@@ -173,6 +184,40 @@ func TestGenerateFilesUsesParserOverride(t *testing.T) {
 	}
 }
 
+func TestGenerateFilesStopsWhenCancelled(t *testing.T) {
+	root := t.TempDir()
+	writeRuntimeBaseFixture(t, root)
+	writeTestFile(t, filepath.Join(root, "template.yaml"), "Resources: {}")
+	funcDir := filepath.Join(root, "functions", "hello")
+	mustMkdirAll(t, funcDir)
+	writeTestFile(t, filepath.Join(funcDir, "app.py"), "print('hello')")
+
+	parser := &stubParser{
+		result: template.ParseResult{
+			Functions: []template.FunctionSpec{
+				{Name: "lambda-hello", CodeURI: "functions/hello/", Handler: "app.handler", Runtime: "python3.12"},
+			},
+		},
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := GenerateFiles(
+		config.GeneratorConfig{Paths: config.PathsConfig{SamTemplate: "template.yaml", OutputDir: meta.OutputDir + "/"}},
+		GenerateOptions{Context: ctx, ProjectRoot: root, Parser: parser},
+	)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	outputDir := filepath.Join(root, meta.OutputDir)
+	if _, err := os.Stat(filepath.Join(outputDir, "functions", "lambda-hello")); !os.IsNotExist(err) {
+		t.Fatalf("cancelled generate must not stage functions, stat err = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(outputDir, "config", "functions.yml")); !os.IsNotExist(err) {
+		t.Fatalf("cancelled generate must not write functions.yml, stat err = %v", err)
+	}
+}
+
 func TestGenerateFilesWritesWarningsToInjectedOutput(t *testing.T) {
 	root := t.TempDir()
 	writeRuntimeBaseFixture(t, root)
@@ -934,6 +979,118 @@ func TestGenerateFilesLayerNesting(t *testing.T) {
 	}
 }
 
+func TestGenerateFilesStagesNodejsFunction(t *testing.T) {
+	root := t.TempDir()
+	writeRuntimeBaseFixture(t, root)
+	templatePath := filepath.Join(root, "template.yaml")
+	writeTestFile(t, templatePath, "Resources: {}")
+
+	funcDir := filepath.Join(root, "functions", "node")
+	writeTestFile(t, filepath.Join(funcDir, "index.js"), "exports.handler = async () => ({})")
+	writeTestFile(t, filepath.Join(funcDir, "package.json"), `{"name":"node-fn"}`)
+
+	flatDir := filepath.Join(root, "layers", "flat")
+	writeTestFile(t, filepath.Join(flatDir, "left-pad", "index.js"), "// flat")
+	modulesDir := filepath.Join(root, "layers", "modules")
+	writeTestFile(t, filepath.Join(modulesDir, "node_modules", "dayjs", "index.js"), "// modules")
+	nestedDir := filepath.Join(root, "layers", "nested")
+	writeTestFile(t, filepath.Join(nestedDir, "nodejs", "node_modules", "uuid", "index.js"), "// nested")
+
+	parser := &stubParser{
+		result: template.ParseResult{
+			Functions: []template.FunctionSpec{
+				{
+					Name:    "lambda-node",
+					CodeURI: "functions/node/",
+					Handler: "index.handler",
+					Runtime: "nodejs22.x",
+					Layers: []manifest.LayerSpec{
+						{Name: "flat", ContentURI: "layers/flat/"},
+						{Name: "modules", ContentURI: "layers/modules/"},
+						{Name: "nested", ContentURI: "layers/nested/"},
+					},
+				},
+			},
+		},
+	}
+
+	cfg := config.GeneratorConfig{
+		Paths: config.PathsConfig{
+			SamTemplate: "template.yaml",
+			OutputDir:   "out/",
+		},
+	}
+	opts := GenerateOptions{ProjectRoot: root, Parser: parser}
+
+	functions, err := GenerateFiles(cfg, opts)
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	if len(functions) != 1 || !functions[0].HasPackageJSON {
+		t.Fatalf("expected package.json detection, got %+v", functions)
+	}
+
+	functionDir := filepath.Join(root, "out", "functions", "lambda-node")
+	if got := readFile(t, filepath.Join(functionDir, "esb-preload.js")); got != "// test preload\n" {
+		t.Fatalf("expected staged preload hook, got %q", got)
+	}
+	staged := filepath.Join(functionDir, "layers")
+	for _, rel := range []string{
+		filepath.Join("flat", "nodejs", "node_modules", "left-pad", "index.js"),
+		filepath.Join("modules", "nodejs", "node_modules", "dayjs", "index.js"),
+		filepath.Join("nested", "nodejs", "node_modules", "uuid", "index.js"),
+	} {
+		if _, err := os.Stat(filepath.Join(staged, rel)); err != nil {
+			t.Fatalf("expected staged layer file %s: %v", rel, err)
+		}
+	}
+
+	content := readFile(t, filepath.Join(functionDir, "Dockerfile"))
+	if !strings.Contains(content, "FROM public.ecr.aws/lambda/nodejs:22") {
+		t.Fatalf("expected nodejs base image in dockerfile: %s", content)
+	}
+	if !strings.Contains(content, "COPY functions/lambda-node/esb-preload.js /opt/esb/nodejs/esb-preload.js") {
+		t.Fatalf("expected preload hook copy in dockerfile")
+	}
+	if !strings.Contains(content, "npm ci --omit=dev") {
+		t.Fatalf("expected npm install in dockerfile")
+	}
+	if !strings.Contains(content, `CMD [ "index.handler" ]`) {
+		t.Fatalf("expected handler cmd in dockerfile")
+	}
+}
+
+func TestGenerateFilesFailsWhenNodejsPreloadMissing(t *testing.T) {
+	root := t.TempDir()
+	templatePath := filepath.Join(root, "template.yaml")
+	writeTestFile(t, templatePath, "Resources: {}")
+	writeTestFile(t, filepath.Join(root, "functions", "node", "index.js"), "exports.handler = async () => ({})")
+
+	parser := &stubParser{
+		result: template.ParseResult{
+			Functions: []template.FunctionSpec{
+				{
+					Name:    "lambda-node",
+					CodeURI: "functions/node/",
+					Handler: "index.handler",
+					Runtime: "nodejs20.x",
+				},
+			},
+		},
+	}
+
+	cfg := config.GeneratorConfig{
+		Paths: config.PathsConfig{
+			SamTemplate: "template.yaml",
+			OutputDir:   "out/",
+		},
+	}
+	_, err := GenerateFiles(cfg, GenerateOptions{ProjectRoot: root, Parser: parser})
+	if err == nil || !strings.Contains(err.Error(), "nodejs preload hook not found") {
+		t.Fatalf("expected missing preload error, got %v", err)
+	}
+}
+
 func TestGenerateFilesIntegrationOutputs(t *testing.T) {
 	root := t.TempDir()
 	writeRuntimeBaseFixture(t, root)
@@ -1003,6 +1160,72 @@ Resources:
 	}
 }
 
+func TestGenerateFilesRestagesOnlySelectedFunctions(t *testing.T) {
+	root := t.TempDir()
+	writeRuntimeBaseFixture(t, root)
+	templatePath := filepath.Join(root, "template.yaml")
+	writeTestFile(t, templatePath, `
+AWSTemplateFormatVersion: '2010-09-09'
+Transform: AWS::Serverless-2016-10-31
+Resources:
+  HelloFunction:
+    Type: AWS::Serverless::Function
+    Properties:
+      FunctionName: lambda-hello
+      CodeUri: functions/hello/
+      Handler: app.handler
+      Runtime: python3.12
+  WorldFunction:
+    Type: AWS::Serverless::Function
+    Properties:
+      FunctionName: lambda-world
+      CodeUri: functions/world/
+      Handler: app.handler
+      Runtime: python3.12
+`)
+	for _, name := range []string{"hello", "world"} {
+		dir := filepath.Join(root, "functions", name)
+		mustMkdirAll(t, dir)
+		writeTestFile(t, filepath.Join(dir, "app.py"), "print('v1')")
+	}
+
+	cfg := config.GeneratorConfig{
+		Paths: config.PathsConfig{
+			SamTemplate: "template.yaml",
+			OutputDir:   "out/",
+		},
+	}
+	if _, err := GenerateFiles(cfg, GenerateOptions{ProjectRoot: root}); err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+
+	for _, name := range []string{"hello", "world"} {
+		writeTestFile(t, filepath.Join(root, "functions", name, "app.py"), "print('v2')")
+	}
+	functions, err := GenerateFiles(cfg, GenerateOptions{ProjectRoot: root, Functions: []string{"lambda-hello"}})
+	if err != nil {
+		t.Fatalf("partial generate: %v", err)
+	}
+	if len(functions) != 2 {
+		t.Fatalf("expected both functions in specs, got %d", len(functions))
+	}
+
+	staged := filepath.Join(root, "out", "functions")
+	if got := readFile(t, filepath.Join(staged, "lambda-hello", "src", "app.py")); got != "print('v2')" {
+		t.Fatalf("selected function not restaged: %q", got)
+	}
+	if got := readFile(t, filepath.Join(staged, "lambda-world", "src", "app.py")); got != "print('v1')" {
+		t.Fatalf("unselected function restaged: %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(staged, "lambda-world", "Dockerfile")); err != nil {
+		t.Fatalf("unselected function lost its Dockerfile: %v", err)
+	}
+	functionsYml := readFile(t, filepath.Join(root, "out", "config", "functions.yml"))
+	if !strings.Contains(functionsYml, "lambda-world") {
+		t.Fatalf("functions.yml should keep unselected functions")
+	}
+}
+
 func TestGenerateFilesRendersRoutingEvents(t *testing.T) {
 	root := t.TempDir()
 	writeRuntimeBaseFixture(t, root)
@@ -1088,6 +1311,7 @@ func writeRuntimeBaseFixture(t *testing.T, root string) {
 	t.Helper()
 	pythonDir := filepath.Join(root, "runtime-hooks", "python")
 	javaDir := filepath.Join(root, "runtime-hooks", "java")
+	nodeDir := filepath.Join(root, "runtime-hooks", "nodejs")
 	templatesDir := filepath.Join(root, "cli", "assets", "runtime-templates")
 	writeTestFile(
 		t,
@@ -1114,6 +1338,11 @@ func writeRuntimeBaseFixture(t *testing.T, root string) {
 		filepath.Join(javaDir, "wrapper", "lambda-java-wrapper.jar"),
 		"test java wrapper\n",
 	)
+	writeTestFile(
+		t,
+		filepath.Join(nodeDir, "preload", "esb-preload.js"),
+		"// test preload\n",
+	)
 	writeTestFile(
 		t,
 		filepath.Join(templatesDir, "python", "templates", "dockerfile.tmpl"),
@@ -1172,3 +1401,289 @@ func writeZip(t *testing.T, path string, files map[string]string) {
 		t.Fatalf("zip close: %v", err)
 	}
 }
+
+func TestGenerateFilesUsesRepositoryDockerfileTemplates(t *testing.T) {
+	root := t.TempDir()
+	writeRuntimeBaseFixture(t, root)
+	templatePath := filepath.Join(root, "template.yaml")
+	writeTestFile(t, templatePath, `
+AWSTemplateFormatVersion: '2010-09-09'
+Transform: AWS::Serverless-2016-10-31
+Resources:
+  HelloFunction:
+    Type: AWS::Serverless::Function
+    Properties:
+      FunctionName: lambda-hello
+      CodeUri: functions/hello/
+      Handler: app.handler
+      Runtime: python3.12
+  WorldFunction:
+    Type: AWS::Serverless::Function
+    Metadata:
+      DockerfileTemplate: python-ca
+    Properties:
+      FunctionName: lambda-world
+      CodeUri: functions/world/
+      Handler: app.handler
+      Runtime: python3.12
+`)
+	for _, name := range []string{"hello", "world"} {
+		dir := filepath.Join(root, "functions", name)
+		mustMkdirAll(t, dir)
+		writeTestFile(t, filepath.Join(dir, "app.py"), "print('hi')")
+	}
+	overrideDir := filepath.Join(root, meta.HomeDir, "runtime-templates")
+	body := `FROM {{ .BaseImage }}
+COPY {{ .SitecustomizeSource }} /opt/python/sitecustomize.py
+{{- range .Layers }}
+COPY {{ .ContentURI }}/ /opt/
+{{- end }}
+COPY {{ .CodeURI }} ${LAMBDA_TASK_ROOT}/
+CMD [ "{{ .Handler }}" ]
+`
+	mustMkdirAll(t, filepath.Join(overrideDir, "python"))
+	writeTestFile(t, filepath.Join(overrideDir, "python", "dockerfile.tmpl"), "# kind override\n"+body)
+	mustMkdirAll(t, filepath.Join(overrideDir, "python-ca"))
+	writeTestFile(t, filepath.Join(overrideDir, "python-ca", "dockerfile.tmpl"), "# ca override\n"+body)
+
+	cfg := config.GeneratorConfig{
+		Paths: config.PathsConfig{
+			SamTemplate: "template.yaml",
+			OutputDir:   "out/",
+		},
+	}
+	if _, err := GenerateFiles(cfg, GenerateOptions{ProjectRoot: root}); err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	staged := filepath.Join(root, "out", "functions")
+	if got := readFile(t, filepath.Join(staged, "lambda-hello", "Dockerfile")); !strings.HasPrefix(got, "# kind override") {
+		t.Fatalf("expected runtime kind override, got:\n%s", got)
+	}
+	if got := readFile(t, filepath.Join(staged, "lambda-world", "Dockerfile")); !strings.HasPrefix(got, "# ca override") {
+		t.Fatalf("expected metadata-selected override, got:\n%s", got)
+	}
+
+	writeTestFile(t, filepath.Join(overrideDir, "python", "dockerfile.tmpl"), "FROM {{ .BaseImage }}\n")
+	_, err := GenerateFiles(cfg, GenerateOptions{ProjectRoot: root})
+	if err == nil || !strings.Contains(err.Error(), "must use .CodeURI, .Handler, .Layers, .SitecustomizeSource") {
+		t.Fatalf("expected required field validation error, got %v", err)
+	}
+
+	writeTestFile(t, filepath.Join(overrideDir, "python", "dockerfile.tmpl"), body)
+	writeTestFile(t, templatePath, strings.Replace(readFile(t, templatePath), "python-ca", "missing", 1))
+	_, err = GenerateFiles(cfg, GenerateOptions{ProjectRoot: root})
+	if err == nil || !strings.Contains(err.Error(), `dockerfile template "missing" not found`) {
+		t.Fatalf("expected missing template error, got %v", err)
+	}
+}
+
+func TestGenerateFilesCopiesRuntimeTemplateAssets(t *testing.T) {
+	root := t.TempDir()
+	writeRuntimeBaseFixture(t, root)
+	writeTestFile(t, filepath.Join(root, "template.yaml"), `
+AWSTemplateFormatVersion: '2010-09-09'
+Transform: AWS::Serverless-2016-10-31
+Resources:
+  HelloFunction:
+    Type: AWS::Serverless::Function
+    Metadata:
+      DockerfileTemplate: python-ca
+    Properties:
+      FunctionName: lambda-hello
+      CodeUri: functions/hello/
+      Handler: app.handler
+      Runtime: python3.12
+`)
+	writeTestFile(t, filepath.Join(root, "functions", "hello", "app.py"), "print('hi')")
+	overrideDir := filepath.Join(root, meta.HomeDir, "runtime-templates", "python-ca")
+	writeTestFile(t, filepath.Join(overrideDir, "dockerfile.tmpl"), `FROM {{ .BaseImage }}
+COPY {{ .FunctionDir }}corp-ca.pem /etc/pki/ca-trust/source/anchors/
+COPY {{ .FunctionDir }}certs/ /opt/certs/
+COPY {{ .SitecustomizeSource }} /opt/python/sitecustomize.py
+{{- range .Layers }}
+COPY {{ .ContentURI }}/ /opt/
+{{- end }}
+COPY {{ .CodeURI }} ${LAMBDA_TASK_ROOT}/
+CMD [ "{{ .Handler }}" ]
+`)
+	writeTestFile(t, filepath.Join(overrideDir, "corp-ca.pem"), "CA")
+	writeTestFile(t, filepath.Join(overrideDir, "certs", "extra.pem"), "EXTRA")
+
+	cfg := config.GeneratorConfig{
+		Paths: config.PathsConfig{
+			SamTemplate: "template.yaml",
+			OutputDir:   "out/",
+		},
+	}
+	if _, err := GenerateFiles(cfg, GenerateOptions{ProjectRoot: root}); err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	functionDir := filepath.Join(root, "out", "functions", "lambda-hello")
+	if got := readFile(t, filepath.Join(functionDir, "corp-ca.pem")); got != "CA" {
+		t.Fatalf("expected CA bundle in build context, got %q", got)
+	}
+	if got := readFile(t, filepath.Join(functionDir, "certs", "extra.pem")); got != "EXTRA" {
+		t.Fatalf("expected nested asset in build context, got %q", got)
+	}
+	if fileExists(filepath.Join(functionDir, "dockerfile.tmpl")) {
+		t.Fatal("dockerfile.tmpl must not be copied into the build context")
+	}
+	if got := readFile(t, filepath.Join(functionDir, "Dockerfile")); !strings.Contains(got, "COPY functions/lambda-hello/corp-ca.pem ") {
+		t.Fatalf("expected FunctionDir in rendered Dockerfile, got:\n%s", got)
+	}
+
+	writeTestFile(t, filepath.Join(overrideDir, "Dockerfile"), "FROM scratch\n")
+	_, err := GenerateFiles(cfg, GenerateOptions{ProjectRoot: root})
+	if err == nil || !strings.Contains(err.Error(), "runtime template asset Dockerfile conflicts") {
+		t.Fatalf("expected reserved asset error, got %v", err)
+	}
+}
+
+// makeRunner emulates `make build-<id> ARTIFACTS_DIR=...` by writing app.py.
+type makeRunner struct {
+	calls []string
+}
+
+func (r *makeRunner) Run(_ context.Context, dir, name string, args ...string) error {
+	r.calls = append(r.calls, name+" "+strings.Join(args, " "))
+	if name != "make" || !fileExists(filepath.Join(dir, "Makefile")) {
+		return fmt.Errorf("unexpected command %s in %s", name, dir)
+	}
+	for _, arg := range args {
+		if dest, ok := strings.CutPrefix(arg, "ARTIFACTS_DIR="); ok {
+			return os.WriteFile(filepath.Join(dest, "app.py"), []byte("built"), 0o600)
+		}
+	}
+	return fmt.Errorf("ARTIFACTS_DIR not passed")
+}
+
+func (r *makeRunner) RunOutput(ctx context.Context, dir, name string, args ...string) ([]byte, error) {
+	return nil, r.Run(ctx, dir, name, args...)
+}
+
+func (r *makeRunner) RunQuiet(ctx context.Context, dir, name string, args ...string) error {
+	return r.Run(ctx, dir, name, args...)
+}
+
+func TestGenerateFilesHonorsSamBuildMetadata(t *testing.T) {
+	root := t.TempDir()
+	writeRuntimeBaseFixture(t, root)
+	templatePath := filepath.Join(root, "template.yaml")
+	writeTestFile(t, templatePath, `
+AWSTemplateFormatVersion: '2010-09-09'
+Transform: AWS::Serverless-2016-10-31
+Resources:
+  ImageFunction:
+    Type: AWS::Serverless::Function
+    Metadata:
+      Dockerfile: Dockerfile
+      DockerContext: ./image
+      DockerTag: v1
+    Properties:
+      FunctionName: lambda-image
+      PackageType: Image
+  MakeFunction:
+    Type: AWS::Serverless::Function
+    Metadata:
+      BuildMethod: makefile
+    Properties:
+      FunctionName: lambda-make
+      CodeUri: functions/make/
+      Handler: app.handler
+      Runtime: python3.12
+`)
+	writeTestFile(t, filepath.Join(root, "image", "Dockerfile"), "FROM public.ecr.aws/lambda/python:3.12\n")
+	mustMkdirAll(t, filepath.Join(root, "functions", "make"))
+	writeTestFile(t, filepath.Join(root, "functions", "make", "Makefile"), "build-MakeFunction:\n\tcp app.py $(ARTIFACTS_DIR)\n")
+	writeTestFile(t, filepath.Join(root, "functions", "make", "app.py"), "source")
+
+	cfg := config.GeneratorConfig{
+		Paths: config.PathsConfig{
+			SamTemplate: "template.yaml",
+			OutputDir:   "out/",
+		},
+	}
+	runner := &makeRunner{}
+	functions, err := GenerateFiles(cfg, GenerateOptions{ProjectRoot: root, Runner: runner})
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+
+	var image template.FunctionSpec
+	for _, fn := range functions {
+		if fn.Name == "lambda-image" {
+			image = fn
+		}
+	}
+	if image.ImageBuild == nil || image.ImageBuild.Context != filepath.Join(root, "image") {
+		t.Fatalf("expected resolved image build, got %+v", image.ImageBuild)
+	}
+	sourceRef := meta.ImagePrefix + "-source-lambda-image:v1"
+	if image.ImageSource != sourceRef {
+		t.Fatalf("unexpected image source: %q", image.ImageSource)
+	}
+	staged := filepath.Join(root, "out", "functions")
+	if got := readFile(t, filepath.Join(staged, "lambda-image", "Dockerfile")); !strings.Contains(got, "FROM "+sourceRef) {
+		t.Fatalf("expected dockerfile to build on the source image, got:\n%s", got)
+	}
+
+	if len(runner.calls) != 1 || !strings.HasPrefix(runner.calls[0], "make build-MakeFunction ARTIFACTS_DIR=") {
+		t.Fatalf("unexpected make calls: %v", runner.calls)
+	}
+	if got := readFile(t, filepath.Join(staged, "lambda-make", "src", "app.py")); got != "built" {
+		t.Fatalf("expected make artifacts to be staged, got %q", got)
+	}
+	if dirExists(filepath.Join(staged, "lambda-make", ".build")) {
+		t.Fatalf("expected make scratch dir to be removed")
+	}
+
+}
+
+func TestGenerateFilesStagesUnsupportedBuildMethodWithWarning(t *testing.T) {
+	root := t.TempDir()
+	writeRuntimeBaseFixture(t, root)
+	templatePath := filepath.Join(root, "template.yaml")
+	writeTestFile(t, templatePath, `
+AWSTemplateFormatVersion: '2010-09-09'
+Transform: AWS::Serverless-2016-10-31
+Resources:
+  NodeFunction:
+    Type: AWS::Serverless::Function
+    Metadata:
+      BuildMethod: esbuild
+      BuildProperties:
+        EntryPoints:
+          - app.js
+    Properties:
+      FunctionName: lambda-node
+      CodeUri: functions/node/
+      Handler: app.handler
+      Runtime: nodejs20.x
+`)
+	writeTestFile(t, filepath.Join(root, "functions", "node", "app.js"), "exports.handler = async () => 'ok';\n")
+
+	cfg := config.GeneratorConfig{
+		Paths: config.PathsConfig{
+			SamTemplate: "template.yaml",
+			OutputDir:   "out/",
+		},
+	}
+	var errOut bytes.Buffer
+	runner := &makeRunner{}
+	functions, err := GenerateFiles(cfg, GenerateOptions{ProjectRoot: root, Runner: runner, Out: &errOut})
+	if err != nil {
+		t.Fatalf("esbuild functions must still deploy: %v", err)
+	}
+	if len(functions) != 1 || functions[0].Name != "lambda-node" {
+		t.Fatalf("unexpected functions: %+v", functions)
+	}
+	if got := readFile(t, filepath.Join(root, "out", "functions", "lambda-node", "src", "app.js")); !strings.Contains(got, "exports.handler") {
+		t.Fatalf("expected CodeUri to be staged as-is, got %q", got)
+	}
+	if len(runner.calls) != 0 {
+		t.Fatalf("no custom build must run, got %v", runner.calls)
+	}
+	if !strings.Contains(errOut.String(), `BuildMethod "esbuild" is not supported`) {
+		t.Fatalf("expected build method warning, got %q", errOut.String())
+	}
+}
@@ -0,0 +1,148 @@
+// Where: cli/internal/infra/templategen/runtime_templates.go
+// What: Repository runtime Dockerfile template override lookup.
+// Why: Let target repositories customize function images without forking the CLI.
+package templategen
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	runtimecfg "github.com/poruru-code/esb-cli/internal/domain/runtime"
+	"github.com/poruru-code/esb-cli/internal/domain/template"
+	"github.com/poruru-code/esb-cli/internal/meta"
+)
+
+const (
+	runtimeTemplatesDirName  = "runtime-templates"
+	dockerfileTemplateName   = "dockerfile.tmpl"
+	dockerfileTemplateSuffix = ".tmpl"
+)
+
+// runtimeTemplatesDir returns the repository override directory
+// (<repo>/.esb/runtime-templates).
+func runtimeTemplatesDir(projectRoot string) string {
+	return filepath.Join(projectRoot, meta.HomeDir, runtimeTemplatesDirName)
+}
+
+// runtimeTemplate is the selected Dockerfile override and, for override
+// directories, the directory whose other files are copied into the function
+// build context.
+type runtimeTemplate struct {
+	Dockerfile template.DockerfileTemplate
+	AssetsDir  string
+}
+
+// resolveDockerfileTemplate picks the Dockerfile template override for fn.
+// Metadata.DockerfileTemplate wins: a value ending in .tmpl or containing a
+// path separator is a file relative to the SAM template, otherwise it names
+// <override dir>/<name>/dockerfile.tmpl. Without Metadata the runtime name
+// (python3.12) and then the runtime kind (python) directories are tried.
+// An empty result keeps the embedded template.
+func resolveDockerfileTemplate(projectRoot, baseDir string, fn template.FunctionSpec) (runtimeTemplate, error) {
+	overrideDir := runtimeTemplatesDir(projectRoot)
+	if selected := strings.TrimSpace(fn.DockerfileTemplate); selected != "" {
+		assetsDir := filepath.Join(overrideDir, selected)
+		candidate := filepath.Join(assetsDir, dockerfileTemplateName)
+		if strings.HasSuffix(selected, dockerfileTemplateSuffix) || strings.ContainsAny(selected, `/\`) {
+			// A template file next to the SAM template carries no assets.
+			assetsDir = ""
+			candidate = selected
+			if !filepath.IsAbs(candidate) {
+				candidate = filepath.Join(baseDir, candidate)
+			}
+		}
+		if !fileExists(candidate) {
+			return runtimeTemplate{}, fmt.Errorf(
+				"function %s: dockerfile template %q not found (%s)",
+				fn.Name,
+				selected,
+				candidate,
+			)
+		}
+		return readRuntimeTemplate(projectRoot, candidate, assetsDir)
+	}
+
+	profile, err := runtimecfg.Resolve(fn.Runtime)
+	if err != nil {
+		// RenderDockerfile reports the unsupported runtime.
+		return runtimeTemplate{}, nil
+	}
+	for _, name := range []string{profile.Name, string(profile.Kind)} {
+		assetsDir := filepath.Join(overrideDir, name)
+		candidate := filepath.Join(assetsDir, dockerfileTemplateName)
+		if fileExists(candidate) {
+			return readRuntimeTemplate(projectRoot, candidate, assetsDir)
+		}
+	}
+	return runtimeTemplate{}, nil
+}
+
+func readRuntimeTemplate(projectRoot, path, assetsDir string) (runtimeTemplate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return runtimeTemplate{}, fmt.Errorf("read dockerfile template: %w", err)
+	}
+	source := path
+	if rel, err := filepath.Rel(projectRoot, path); err == nil && !strings.HasPrefix(rel, "..") {
+		source = filepath.ToSlash(rel)
+	}
+	return runtimeTemplate{
+		Dockerfile: template.DockerfileTemplate{Source: source, Text: string(data)},
+		AssetsDir:  assetsDir,
+	}, nil
+}
+
+// reservedFunctionDirEntries are written by staging into functions/<name>/;
+// override assets may not replace them.
+var reservedFunctionDirEntries = map[string]bool{
+	"Dockerfile":        true,
+	"src":               true,
+	"layers":            true,
+	"sitecustomize.py":  true,
+	javaWrapperFileName: true,
+	javaAgentFileName:   true,
+	nodePreloadFileName: true,
+}
+
+// copyRuntimeTemplateAssets copies every entry of an override directory except
+// dockerfile.tmpl into the function directory so the template can COPY them
+// (e.g. CA bundles) from {{ .FunctionDir }}.
+func copyRuntimeTemplateAssets(assetsDir, functionDir, functionName string) error {
+	if assetsDir == "" {
+		return nil
+	}
+	entries, err := os.ReadDir(assetsDir)
+	if err != nil {
+		return fmt.Errorf("read runtime template assets: %w", err)
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if name == dockerfileTemplateName {
+			continue
+		}
+		if reservedFunctionDirEntries[name] {
+			return fmt.Errorf(
+				"function %s: runtime template asset %s conflicts with a staged file (%s)",
+				functionName,
+				name,
+				filepath.Join(assetsDir, name),
+			)
+		}
+		src := filepath.Join(assetsDir, name)
+		dst := filepath.Join(functionDir, name)
+		if entry.IsDir() {
+			if err := removeDir(dst); err != nil {
+				return err
+			}
+			err = copyDir(src, dst)
+		} else {
+			err = copyFile(src, dst)
+		}
+		if err != nil {
+			return fmt.Errorf("copy runtime template asset %s: %w", name, err)
+		}
+	}
+	return nil
+}
@@ -4,6 +4,7 @@
 package templategen
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
@@ -13,9 +14,11 @@ import (
 
 	"github.com/poruru-code/esb-cli/internal/domain/runtime"
 	"github.com/poruru-code/esb-cli/internal/domain/template"
+	"github.com/poruru-code/esb-cli/internal/infra/compose"
 )
 
 type stageContext struct {
+	Context           context.Context
 	BaseDir           string
 	OutputDir         string
 	FunctionsDir      string
@@ -25,6 +28,8 @@ type stageContext struct {
 	DryRun            bool
 	Verbose           bool
 	Out               io.Writer
+	// Runner executes custom builds (BuildMethod: makefile).
+	Runner compose.CommandRunner
 }
 
 type stagedFunction struct {
@@ -33,6 +38,13 @@ type stagedFunction struct {
 	SitecustomizeRef string
 }
 
+func (ctx stageContext) runContext() context.Context {
+	if ctx.Context == nil {
+		return context.Background()
+	}
+	return ctx.Context
+}
+
 func (ctx stageContext) verbosef(format string, args ...any) {
 	if !ctx.Verbose {
 		return
@@ -62,7 +74,7 @@ func stageFunction(fn template.FunctionSpec, ctx stageContext) (stagedFunction,
 	stagingSrc := filepath.Join(functionDir, "src")
 	fn.AppCodeJarPath = ""
 	if strings.TrimSpace(fn.CodeURI) != "" {
-		sourcePath := resolveResourcePath(ctx.BaseDir, fn.CodeURI)
+		sourcePath := ResolveResourcePath(ctx.BaseDir, fn.CodeURI)
 		if !dirExists(sourcePath) && !fileExists(sourcePath) {
 			return stagedFunction{}, fmt.Errorf("code uri not found for function %s: %s", fn.Name, sourcePath)
 		}
@@ -73,6 +85,10 @@ func stageFunction(fn template.FunctionSpec, ctx stageContext) (stagedFunction,
 		}
 		if !ctx.DryRun {
 			switch {
+			case fn.BuildMethod == template.BuildMethodMakefile:
+				if err := runMakefileBuild(fn, sourcePath, functionDir, stagingSrc, ctx); err != nil {
+					return stagedFunction{}, err
+				}
 			case dirExists(sourcePath):
 				if err := copyDir(sourcePath, stagingSrc); err != nil {
 					return stagedFunction{}, err
@@ -97,9 +113,11 @@ func stageFunction(fn template.FunctionSpec, ctx stageContext) (stagedFunction,
 
 		fn.CodeURI = ensureSlash(path.Join("functions", fn.Name, "src"))
 		fn.HasRequirements = fileExists(filepath.Join(stagingSrc, "requirements.txt"))
+		fn.HasPackageJSON = fileExists(filepath.Join(stagingSrc, "package.json"))
 	} else {
 		fn.CodeURI = ""
 		fn.HasRequirements = false
+		fn.HasPackageJSON = false
 	}
 
 	stagedLayers, err := stageLayers(fn.Layers, ctx, fn.Name, functionDir, profile)
@@ -156,6 +174,16 @@ func stageFunction(fn template.FunctionSpec, ctx stageContext) (stagedFunction,
 		}
 	}
 
+	if !ctx.DryRun && profile.Kind == runtime.KindNode {
+		preloadSrc, err := ensureNodePreloadSource(ctx)
+		if err != nil {
+			return stagedFunction{}, err
+		}
+		if err := copyFile(preloadSrc, filepath.Join(functionDir, nodePreloadFileName)); err != nil {
+			return stagedFunction{}, err
+		}
+	}
+
 	return stagedFunction{
 		Function:         fn,
 		FunctionDir:      functionDir,
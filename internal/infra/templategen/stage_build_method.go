@@ -0,0 +1,112 @@
+// Where: cli/internal/infra/templategen/stage_build_method.go
+// What: SAM Metadata build handling (BuildMethod makefile, image build paths).
+// Why: Let existing SAM projects with custom builds deploy without changes.
+package templategen
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/poruru-code/esb-cli/internal/domain/runtime"
+	"github.com/poruru-code/esb-cli/internal/domain/template"
+)
+
+const makefileScratchDirName = ".build"
+
+// buildMethodWarning returns a warning for a BuildMethod other than makefile
+// or the function runtime itself (the sam build default). Such functions
+// (e.g. esbuild) fall back to the default staging of CodeUri, so existing SAM
+// projects still deploy.
+func buildMethodWarning(fn template.FunctionSpec) string {
+	method := strings.TrimSpace(fn.BuildMethod)
+	switch {
+	case method == "", method == template.BuildMethodMakefile:
+		return ""
+	case strings.EqualFold(method, fn.Runtime):
+		return ""
+	}
+	if profile, err := runtime.Resolve(fn.Runtime); err == nil && strings.EqualFold(method, profile.Name) {
+		return ""
+	}
+	return fmt.Sprintf(
+		"function %s: BuildMethod %q is not supported; staging CodeUri as-is with the default %s build",
+		fn.Name,
+		fn.BuildMethod,
+		fn.Runtime,
+	)
+}
+
+// runMakefileBuild copies the CodeUri directory to a scratch dir and runs
+// `make build-<LogicalID> ARTIFACTS_DIR=<staged src>` there, like sam build.
+func runMakefileBuild(fn template.FunctionSpec, sourceDir, functionDir, stagingSrc string, ctx stageContext) error {
+	if !dirExists(sourceDir) {
+		return fmt.Errorf("makefile build for function %s requires a CodeUri directory: %s", fn.Name, sourceDir)
+	}
+	if !fileExists(filepath.Join(sourceDir, "Makefile")) {
+		return fmt.Errorf("makefile build for function %s requires %s", fn.Name, filepath.Join(sourceDir, "Makefile"))
+	}
+	if ctx.Runner == nil {
+		return fmt.Errorf("command runner is required for makefile build")
+	}
+	logicalID := strings.TrimSpace(fn.LogicalID)
+	if logicalID == "" {
+		logicalID = fn.Name
+	}
+
+	scratch := filepath.Join(functionDir, makefileScratchDirName)
+	if err := removeDir(scratch); err != nil {
+		return err
+	}
+	if err := copyDir(sourceDir, scratch); err != nil {
+		return err
+	}
+	defer func() { _ = removeDir(scratch) }()
+
+	artifactsDir, err := filepath.Abs(stagingSrc)
+	if err != nil {
+		return err
+	}
+	if err := ensureDir(artifactsDir); err != nil {
+		return err
+	}
+	ctx.verbosef("  Running make build-%s for %s\n", logicalID, fn.Name)
+	if err := ctx.Runner.Run(
+		ctx.runContext(),
+		scratch,
+		"make",
+		"build-"+logicalID,
+		"ARTIFACTS_DIR="+artifactsDir,
+	); err != nil {
+		return fmt.Errorf("makefile build for function %s: %w", fn.Name, err)
+	}
+	return nil
+}
+
+// resolveImageBuild makes the SAM image build paths absolute and checks that
+// the Dockerfile exists.
+func resolveImageBuild(fn template.FunctionSpec, baseDir string) (*template.ImageBuildSpec, error) {
+	if fn.ImageBuild == nil {
+		return nil, nil
+	}
+	resolved := *fn.ImageBuild
+	resolved.Context = ResolveResourcePath(baseDir, fn.ImageBuild.Context)
+	if !dirExists(resolved.Context) {
+		return nil, fmt.Errorf("docker context not found for function %s: %s", fn.Name, resolved.Context)
+	}
+	resolved.Dockerfile = fn.ImageBuild.Dockerfile
+	if !filepath.IsAbs(resolved.Dockerfile) {
+		resolved.Dockerfile = filepath.Join(resolved.Context, resolved.Dockerfile)
+	}
+	resolved.Dockerfile = filepath.Clean(resolved.Dockerfile)
+	if !fileExists(resolved.Dockerfile) {
+		return nil, fmt.Errorf("dockerfile not found for function %s: %s", fn.Name, resolved.Dockerfile)
+	}
+	if len(fn.ImageBuild.Args) > 0 {
+		resolved.Args = make(map[string]string, len(fn.ImageBuild.Args))
+		for key, val := range fn.ImageBuild.Args {
+			resolved.Args[key] = val
+		}
+	}
+	return &resolved, nil
+}
@@ -13,7 +13,7 @@ import (
 )
 
 // stageLayers stages each referenced layer inside the function directory,
-// applying smart nesting for Python runtimes and sanitizing names.
+// applying smart nesting for Python/Node runtimes and sanitizing names.
 func stageLayers(
 	layers []manifest.LayerSpec,
 	ctx stageContext,
@@ -28,7 +28,7 @@ func stageLayers(
 	staged := make([]manifest.LayerSpec, 0, len(layers))
 	layersDir := filepath.Join(functionDir, "layers")
 	for _, layer := range layers {
-		source := resolveResourcePath(ctx.BaseDir, layer.ContentURI)
+		source := ResolveResourcePath(ctx.BaseDir, layer.ContentURI)
 		if !fileOrDirExists(source) {
 			continue
 		}
@@ -62,8 +62,11 @@ func stageLayers(
 			}
 
 			finalDest := targetDir
-			if shouldNestPython(profile.NestPythonLayers, finalSrc) {
+			switch {
+			case shouldNestPython(profile.NestPythonLayers, finalSrc):
 				finalDest = filepath.Join(targetDir, "python")
+			default:
+				finalDest = nodeLayerDest(profile.NestNodeLayers, targetDir, finalSrc)
 			}
 
 			if err := copyDirLinkOrCopy(finalSrc, finalDest); err != nil {
@@ -0,0 +1,41 @@
+// Where: cli/internal/infra/templategen/stage_nodejs.go
+// What: Node.js layer layout and runtime hook staging helpers.
+// Why: Keep Node-specific decisions isolated from generic staging flow.
+package templategen
+
+import (
+	"fmt"
+	"path/filepath"
+)
+
+const nodePreloadFileName = "esb-preload.js"
+
+// nodeLayerDest returns where a Node layer must be copied so modules resolve
+// from /opt/nodejs/node_modules at runtime.
+func nodeLayerDest(nest bool, targetDir, sourceDir string) string {
+	if !nest || sourceDir == "" {
+		return targetDir
+	}
+	switch {
+	case dirExists(filepath.Join(sourceDir, "nodejs")):
+		return targetDir
+	case dirExists(filepath.Join(sourceDir, "node_modules")):
+		return filepath.Join(targetDir, "nodejs")
+	default:
+		return filepath.Join(targetDir, "nodejs", "node_modules")
+	}
+}
+
+func ensureNodePreloadSource(ctx stageContext) (string, error) {
+	rel := filepath.Join("runtime-hooks", "nodejs", "preload", nodePreloadFileName)
+	candidates := []string{
+		filepath.Clean(filepath.Join(ctx.ProjectRoot, rel)),
+		filepath.Clean(filepath.Join(ctx.BaseDir, rel)),
+	}
+	for _, candidate := range candidates {
+		if fileExists(candidate) {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("nodejs preload hook not found in runtime-hooks/nodejs/preload")
+}
@@ -10,7 +10,10 @@ import (
 	"github.com/poruru-code/esb-cli/internal/domain/template"
 )
 
-func resolveResourcePath(baseDir, raw string) string {
+// ResolveResourcePath resolves a template CodeUri/ContentUri/DockerContext
+// against the template directory. A leading slash is treated as relative to
+// baseDir, as SAM does.
+func ResolveResourcePath(baseDir, raw string) string {
 	trimmed := strings.TrimLeft(raw, "/\\")
 	if trimmed == "" {
 		trimmed = raw
@@ -0,0 +1,233 @@
+// Where: cli/internal/infra/ui/events.go
+// What: Machine-readable event stream for JSON output mode.
+// Why: Let CI consume command progress and results without scraping human text.
+package ui
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	domaincfg "github.com/poruru-code/esb-cli/internal/domain/config"
+)
+
+// Event names emitted in JSON output mode.
+const (
+	EventInputs     = "inputs"
+	EventPhase      = "phase"
+	EventConfigDiff = "config_diff"
+	EventConfigPlan = "config_plan"
+	EventImage      = "image"
+	EventWarning    = "warning"
+	EventLog        = "log"
+	EventLogEntry   = "log_entry"
+	EventResult     = "result"
+)
+
+// EventSink receives structured events. A nil sink disables emission.
+type EventSink interface {
+	Emit(name string, data any)
+}
+
+// PhaseEvent reports the outcome and duration of a build phase.
+type PhaseEvent struct {
+	Label      string `json:"label"`
+	Status     string `json:"status"`
+	DurationMs int64  `json:"duration_ms"`
+	// CacheSteps and CacheCached count bake steps and cache hits.
+	CacheSteps  int `json:"cache_steps,omitempty"`
+	CacheCached int `json:"cache_cached,omitempty"`
+}
+
+// ConfigDiffEvent reports config diff counts for a deploy target.
+type ConfigDiffEvent struct {
+	Scope     string                      `json:"scope"`
+	ConfigDir string                      `json:"config_dir"`
+	Functions domaincfg.Counts            `json:"functions"`
+	Routes    domaincfg.Counts            `json:"routes"`
+	Resources map[string]domaincfg.Counts `json:"resources,omitempty"`
+}
+
+// ConfigPlanEvent reports per-entry config changes computed by a dry run.
+type ConfigPlanEvent struct {
+	LiveSource string                  `json:"live_source"`
+	Entries    []domaincfg.EntryChange `json:"entries"`
+}
+
+// ImageEvent reports a function image produced by a build.
+type ImageEvent struct {
+	Function string   `json:"function"`
+	Image    string   `json:"image"`
+	ID       string   `json:"id,omitempty"`
+	Digests  []string `json:"digests,omitempty"`
+}
+
+// WarningEvent carries a warning message.
+type WarningEvent struct {
+	Message string `json:"message"`
+}
+
+// LogEvent carries an informational message.
+type LogEvent struct {
+	Level   string `json:"level"`
+	Message string `json:"message"`
+}
+
+// LogEntryEvent carries one function log line read by the logs command.
+type LogEntryEvent struct {
+	Time      time.Time         `json:"time"`
+	Function  string            `json:"function,omitempty"`
+	RequestID string            `json:"request_id,omitempty"`
+	Level     string            `json:"level,omitempty"`
+	Message   string            `json:"message"`
+	Fields    map[string]string `json:"fields,omitempty"`
+}
+
+// ResultEvent is the final document emitted once per command.
+type ResultEvent struct {
+	Command     string            `json:"command"`
+	Status      string            `json:"status"`
+	ExitCode    int               `json:"exit_code"`
+	Error       string            `json:"error,omitempty"`
+	DurationMs  int64             `json:"duration_ms"`
+	Inputs      any               `json:"inputs,omitempty"`
+	Phases      []PhaseEvent      `json:"phases,omitempty"`
+	ConfigDiffs []ConfigDiffEvent `json:"config_diffs,omitempty"`
+	ConfigPlans []ConfigPlanEvent `json:"config_plans,omitempty"`
+	Images      []ImageEvent      `json:"images,omitempty"`
+	Warnings    []string          `json:"warnings"`
+	Details     map[string]any    `json:"details,omitempty"`
+}
+
+type eventEnvelope struct {
+	Event string    `json:"event"`
+	Time  time.Time `json:"time"`
+	Data  any       `json:"data,omitempty"`
+}
+
+// JSONEventStream writes one JSON object per line and aggregates the
+// events it sees into the final result document.
+type JSONEventStream struct {
+	mu      sync.Mutex
+	encoder *json.Encoder
+	now     func() time.Time
+	start   time.Time
+	result  ResultEvent
+}
+
+// NewJSONEventStream returns a stream writing JSON lines to out.
+func NewJSONEventStream(out io.Writer) *JSONEventStream {
+	return &JSONEventStream{
+		encoder: json.NewEncoder(out),
+		now:     time.Now,
+		start:   time.Now(),
+		result:  ResultEvent{Warnings: []string{}},
+	}
+}
+
+// Emit writes an event and records it for the result document.
+func (s *JSONEventStream) Emit(name string, data any) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch typed := data.(type) {
+	case PhaseEvent:
+		s.result.Phases = append(s.result.Phases, typed)
+	case ConfigDiffEvent:
+		s.result.ConfigDiffs = append(s.result.ConfigDiffs, typed)
+	case ConfigPlanEvent:
+		s.result.ConfigPlans = append(s.result.ConfigPlans, typed)
+	case ImageEvent:
+		s.result.Images = append(s.result.Images, typed)
+	case WarningEvent:
+		s.result.Warnings = append(s.result.Warnings, typed.Message)
+	default:
+		if name == EventInputs {
+			s.result.Inputs = data
+		}
+	}
+	s.write(name, data)
+}
+
+// SetDetail attaches a command-specific value to the result document.
+func (s *JSONEventStream) SetDetail(key string, value any) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.result.Details == nil {
+		s.result.Details = map[string]any{}
+	}
+	s.result.Details[key] = value
+}
+
+// RecordError keeps the first command error for the result document.
+func (s *JSONEventStream) RecordError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.result.Error == "" {
+		s.result.Error = err.Error()
+	}
+}
+
+// Finish emits the result document for the command.
+func (s *JSONEventStream) Finish(command string, exitCode int) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	result := s.result
+	result.Command = command
+	result.ExitCode = exitCode
+	result.Status = "ok"
+	if exitCode != 0 {
+		result.Status = "error"
+	}
+	result.DurationMs = s.now().Sub(s.start).Milliseconds()
+	s.write(EventResult, result)
+}
+
+func (s *JSONEventStream) write(name string, data any) {
+	_ = s.encoder.Encode(eventEnvelope{Event: name, Time: s.now().UTC(), Data: data})
+}
+
+// NewEventUI forwards output to base and mirrors messages into sink.
+// It returns base unchanged when sink is nil.
+func NewEventUI(base UserInterface, sink EventSink) UserInterface {
+	if sink == nil {
+		return base
+	}
+	return eventUI{base: base, sink: sink}
+}
+
+type eventUI struct {
+	base UserInterface
+	sink EventSink
+}
+
+func (e eventUI) Info(msg string) {
+	e.base.Info(msg)
+	e.sink.Emit(EventLog, LogEvent{Level: "info", Message: msg})
+}
+
+func (e eventUI) Warn(msg string) {
+	e.base.Warn(msg)
+	e.sink.Emit(EventWarning, WarningEvent{Message: msg})
+}
+
+func (e eventUI) Success(msg string) {
+	e.base.Success(msg)
+	e.sink.Emit(EventLog, LogEvent{Level: "success", Message: msg})
+}
+
+func (e eventUI) Block(emoji, title string, rows []KeyValue) {
+	e.base.Block(emoji, title, rows)
+}
@@ -0,0 +1,185 @@
+// Where: cli/internal/infra/watch/poller.go
+// What: Polling file watcher for deploy watch mode.
+// Why: Detect source edits portably without OS-specific notification APIs.
+package watch
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultInterval is the polling period used when Poller.Interval is zero.
+const DefaultInterval = 500 * time.Millisecond
+
+// ignoredDirNames are skipped while walking watched directories.
+var ignoredDirNames = map[string]struct{}{
+	".git":        {},
+	".aws-sam":    {},
+	"__pycache__": {},
+}
+
+type stamp struct {
+	size    int64
+	modTime time.Time
+	mode    fs.FileMode
+}
+
+// Snapshot maps absolute file paths to their size/mtime stamp.
+type Snapshot map[string]stamp
+
+// Poller reports files under Paths that were created, modified or removed.
+type Poller struct {
+	Paths    []string
+	Exclude  []string
+	Interval time.Duration
+
+	last Snapshot
+}
+
+// Reset records the current state as the baseline for the next Wait.
+func (p *Poller) Reset() error {
+	snapshot, err := p.Snapshot()
+	if err != nil {
+		return err
+	}
+	p.last = snapshot
+	return nil
+}
+
+// Wait blocks until at least one file changes and the tree has been stable
+// for one interval, then returns the changed paths in sorted order.
+// It returns ctx.Err() when ctx is cancelled.
+func (p *Poller) Wait(ctx context.Context) ([]string, error) {
+	if p.last == nil {
+		if err := p.Reset(); err != nil {
+			return nil, err
+		}
+	}
+	interval := p.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	pending := map[string]struct{}{}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+		next, err := p.Snapshot()
+		if err != nil {
+			return nil, err
+		}
+		changed := Diff(p.last, next)
+		p.last = next
+		if len(changed) > 0 {
+			for _, path := range changed {
+				pending[path] = struct{}{}
+			}
+			continue
+		}
+		if len(pending) > 0 {
+			return sortedKeys(pending), nil
+		}
+	}
+}
+
+// Snapshot walks every watched path and records file stamps.
+// Missing paths are skipped so they can appear later.
+func (p *Poller) Snapshot() (Snapshot, error) {
+	snapshot := Snapshot{}
+	for _, root := range p.Paths {
+		root = filepath.Clean(root)
+		info, err := os.Stat(root)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		if !info.IsDir() {
+			snapshot[root] = newStamp(info)
+			continue
+		}
+		err = filepath.WalkDir(root, func(path string, entry fs.DirEntry, walkErr error) error {
+			if walkErr != nil {
+				if os.IsNotExist(walkErr) {
+					return nil
+				}
+				return walkErr
+			}
+			if entry.IsDir() {
+				if path != root && (isIgnoredDir(entry.Name()) || p.excluded(path)) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			info, err := entry.Info()
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			snapshot[path] = newStamp(info)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return snapshot, nil
+}
+
+func (p *Poller) excluded(path string) bool {
+	for _, exclude := range p.Exclude {
+		exclude = filepath.Clean(exclude)
+		if path == exclude || strings.HasPrefix(path, exclude+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Diff returns the paths whose stamps differ between prev and next,
+// including files that were added or removed.
+func Diff(prev, next Snapshot) []string {
+	changed := map[string]struct{}{}
+	for path, current := range next {
+		if before, ok := prev[path]; !ok || before != current {
+			changed[path] = struct{}{}
+		}
+	}
+	for path := range prev {
+		if _, ok := next[path]; !ok {
+			changed[path] = struct{}{}
+		}
+	}
+	return sortedKeys(changed)
+}
+
+func newStamp(info fs.FileInfo) stamp {
+	return stamp{size: info.Size(), modTime: info.ModTime(), mode: info.Mode()}
+}
+
+func isIgnoredDir(name string) bool {
+	_, ok := ignoredDirNames[name]
+	return ok
+}
+
+func sortedKeys(values map[string]struct{}) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
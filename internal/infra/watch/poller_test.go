@@ -0,0 +1,69 @@
+// Where: cli/internal/infra/watch/poller_test.go
+// What: Tests for the polling file watcher.
+// Why: Keep change detection, exclusion and cancellation predictable.
+package watch
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPollerWaitReportsChangedFiles(t *testing.T) {
+	root := t.TempDir()
+	src := filepath.Join(root, "src")
+	out := filepath.Join(src, "out")
+	if err := os.MkdirAll(out, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	keep := filepath.Join(src, "app.py")
+	if err := os.WriteFile(keep, []byte("v1"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	poller := &Poller{Paths: []string{src}, Exclude: []string{out}, Interval: 10 * time.Millisecond}
+	if err := poller.Reset(); err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(out, "ignored.txt"), []byte("x"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	added := filepath.Join(src, "new.py")
+	if err := os.WriteFile(added, []byte("new"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keep, []byte("v2-longer"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	changed, err := poller.Wait(ctx)
+	if err != nil {
+		t.Fatalf("wait: %v", err)
+	}
+	if len(changed) != 2 || changed[0] != keep || changed[1] != added {
+		t.Fatalf("unexpected changes: %v", changed)
+	}
+}
+
+func TestPollerWaitStopsOnCancel(t *testing.T) {
+	poller := &Poller{Paths: []string{t.TempDir()}, Interval: 10 * time.Millisecond}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := poller.Wait(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestDiffDetectsRemovedFiles(t *testing.T) {
+	prev := Snapshot{"/a": stamp{size: 1}, "/b": stamp{size: 1}}
+	next := Snapshot{"/a": stamp{size: 1}}
+	changed := Diff(prev, next)
+	if len(changed) != 1 || changed[0] != "/b" {
+		t.Fatalf("unexpected diff: %v", changed)
+	}
+}
@@ -0,0 +1,138 @@
+// Where: cli/internal/usecase/bundle/archive.go
+// What: OCI image-layout tarball inspection and digest verification.
+// Why: Refuse to ship or load images that differ from the bundle manifest.
+package bundle
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+)
+
+const (
+	ociLayoutFile       = "oci-layout"
+	ociIndexFile        = "index.json"
+	dockerManifestFile  = "manifest.json"
+	ociImageNameKey     = "io.containerd.image.name"
+	maxArchiveIndexSize = 16 << 20
+)
+
+// archiveContents maps normalized image refs to the digests found in the
+// tarball: index descriptors (manifest or manifest list) and image configs.
+type archiveContents struct {
+	descriptors map[string]string
+	configs     map[string]string
+}
+
+type archiveIndex struct {
+	Manifests []struct {
+		Digest      string            `json:"digest"`
+		Annotations map[string]string `json:"annotations"`
+	} `json:"manifests"`
+}
+
+type archiveManifestEntry struct {
+	Config   string   `json:"Config"`
+	RepoTags []string `json:"RepoTags"`
+}
+
+// readArchive reads index.json and manifest.json from an OCI image-layout
+// tarball written by `docker save`.
+func readArchive(archivePath string) (archiveContents, error) {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return archiveContents{}, fmt.Errorf("open image archive: %w", err)
+	}
+	defer file.Close()
+
+	contents := archiveContents{descriptors: map[string]string{}, configs: map[string]string{}}
+	hasLayout := false
+	reader := tar.NewReader(file)
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return archiveContents{}, fmt.Errorf("read image archive %s: %w", archivePath, err)
+		}
+		switch path.Clean(header.Name) {
+		case ociLayoutFile:
+			hasLayout = true
+		case ociIndexFile:
+			var index archiveIndex
+			if err := decodeArchiveJSON(reader, &index); err != nil {
+				return archiveContents{}, fmt.Errorf("parse %s in %s: %w", ociIndexFile, archivePath, err)
+			}
+			for _, desc := range index.Manifests {
+				if name := desc.Annotations[ociImageNameKey]; name != "" {
+					contents.descriptors[normalizeRef(name)] = desc.Digest
+				}
+			}
+		case dockerManifestFile:
+			var entries []archiveManifestEntry
+			if err := decodeArchiveJSON(reader, &entries); err != nil {
+				return archiveContents{}, fmt.Errorf("parse %s in %s: %w", dockerManifestFile, archivePath, err)
+			}
+			for _, entry := range entries {
+				digest := configDigest(entry.Config)
+				for _, tag := range entry.RepoTags {
+					contents.configs[normalizeRef(tag)] = digest
+				}
+			}
+		}
+	}
+	if !hasLayout {
+		return archiveContents{}, fmt.Errorf("%s is not an OCI image layout (missing %s)", archivePath, ociLayoutFile)
+	}
+	return contents, nil
+}
+
+func decodeArchiveJSON(reader io.Reader, target any) error {
+	return json.NewDecoder(io.LimitReader(reader, maxArchiveIndexSize)).Decode(target)
+}
+
+// configDigest converts "blobs/sha256/<hex>" (or legacy "<hex>.json") to
+// "sha256:<hex>", the image ID the bundle manifest records.
+func configDigest(config string) string {
+	config = strings.TrimSuffix(path.Clean(config), ".json")
+	base := path.Base(config)
+	algo := path.Base(path.Dir(config))
+	if algo == "." || algo == "/" || algo == "" {
+		algo = "sha256"
+	}
+	return algo + ":" + base
+}
+
+// verify checks that every image is in the archive with the bundle digest.
+// Single-platform images match their config digest (image ID); multi-platform
+// images match the index descriptor digest (manifest list).
+func (c archiveContents) verify(images []Image) error {
+	var problems []string
+	for _, image := range images {
+		ref := normalizeRef(image.Name)
+		config, hasConfig := c.configs[ref]
+		descriptor, hasDescriptor := c.descriptors[ref]
+		if !hasConfig && !hasDescriptor {
+			problems = append(problems, fmt.Sprintf("%s: missing from archive", image.Name))
+			continue
+		}
+		if config == image.Digest || descriptor == image.Digest {
+			continue
+		}
+		found := config
+		if image.multiPlatform() || found == "" {
+			found = descriptor
+		}
+		problems = append(problems, fmt.Sprintf("%s: digest %s, bundle expects %s", image.Name, found, image.Digest))
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("image archive does not match bundle manifest:\n  %s", strings.Join(problems, "\n  "))
+	}
+	return nil
+}
@@ -0,0 +1,212 @@
+// Where: cli/internal/usecase/bundle/bundle.go
+// What: Bundle lookup from artifact.yml and shared image reference helpers.
+// Why: Export, import and signature checks all start from the bundle manifests.
+package bundle
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/poruru-code/esb-cli/internal/infra/compose"
+	"gopkg.in/yaml.v3"
+)
+
+// ArchiveFileName is the default tarball written next to artifact.yml.
+const ArchiveFileName = "images.oci.tar"
+
+var errRunnerNotConfigured = errors.New("command runner is not configured")
+
+// Workflow exports and imports bundle images.
+type Workflow struct {
+	Runner compose.CommandRunner
+}
+
+// Bundle is one artifact entry with its bundle manifest.
+type Bundle struct {
+	ArtifactRoot     string
+	RuntimeConfigDir string
+	ManifestPath     string
+}
+
+// Image is an image listed in a bundle manifest.
+type Image struct {
+	Name     string `json:"name"`
+	Digest   string `json:"digest"`
+	Kind     string `json:"kind"`
+	Platform string `json:"platform"`
+}
+
+// artifactBundleRefs is the subset of artifact.yml needed to locate bundles.
+type artifactBundleRefs struct {
+	Artifacts []struct {
+		ArtifactRoot     string `yaml:"artifact_root"`
+		RuntimeConfigDir string `yaml:"runtime_config_dir"`
+		BundleManifest   string `yaml:"bundle_manifest"`
+	} `yaml:"artifacts"`
+}
+
+// ReadBundles resolves the bundle manifest of every artifact entry. Paths in
+// artifact.yml are relative to its directory. Every entry must have a bundle.
+func ReadBundles(artifactPath string) ([]Bundle, error) {
+	data, err := os.ReadFile(artifactPath)
+	if err != nil {
+		return nil, fmt.Errorf("read artifact manifest: %w", err)
+	}
+	var refs artifactBundleRefs
+	if err := yaml.Unmarshal(data, &refs); err != nil {
+		return nil, fmt.Errorf("parse artifact manifest: %w", err)
+	}
+	if len(refs.Artifacts) == 0 {
+		return nil, fmt.Errorf("artifact manifest %s has no artifacts", artifactPath)
+	}
+	manifestDir := filepath.Dir(artifactPath)
+	bundles := make([]Bundle, 0, len(refs.Artifacts))
+	for _, entry := range refs.Artifacts {
+		root := strings.TrimSpace(entry.ArtifactRoot)
+		manifest := strings.TrimSpace(entry.BundleManifest)
+		if manifest == "" {
+			return nil, fmt.Errorf("artifact %s has no bundle manifest (generate with --bundle-manifest)", root)
+		}
+		if !filepath.IsAbs(root) {
+			root = filepath.Join(manifestDir, root)
+		}
+		if !filepath.IsAbs(manifest) {
+			manifest = filepath.Join(root, manifest)
+		}
+		bundles = append(bundles, Bundle{
+			ArtifactRoot:     root,
+			RuntimeConfigDir: strings.TrimSpace(entry.RuntimeConfigDir),
+			ManifestPath:     manifest,
+		})
+	}
+	return bundles, nil
+}
+
+// ReadImages returns the images listed in a bundle manifest.
+func ReadImages(manifestPath string) ([]Image, error) {
+	data, err := os.ReadFile(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("read bundle manifest: %w", err)
+	}
+	var manifest struct {
+		Images []Image `json:"images"`
+	}
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("parse bundle manifest %s: %w", manifestPath, err)
+	}
+	return manifest.Images, nil
+}
+
+// collectImages returns the images of every bundle, deduplicated by name.
+// The same name with two digests is an error.
+func collectImages(bundles []Bundle) ([]Image, error) {
+	seen := map[string]string{}
+	images := make([]Image, 0)
+	for _, b := range bundles {
+		listed, err := ReadImages(b.ManifestPath)
+		if err != nil {
+			return nil, err
+		}
+		for _, image := range listed {
+			if digest, ok := seen[image.Name]; ok {
+				if digest != image.Digest {
+					return nil, fmt.Errorf("image %s has conflicting digests %s and %s", image.Name, digest, image.Digest)
+				}
+				continue
+			}
+			seen[image.Name] = image.Digest
+			images = append(images, image)
+		}
+	}
+	if len(images) == 0 {
+		return nil, fmt.Errorf("bundle manifests list no images")
+	}
+	return images, nil
+}
+
+// defaultArchivePath places the tarball next to artifact.yml.
+func defaultArchivePath(artifactPath, archive string) string {
+	if trimmed := strings.TrimSpace(archive); trimmed != "" {
+		return trimmed
+	}
+	return filepath.Join(filepath.Dir(artifactPath), ArchiveFileName)
+}
+
+// FunctionsPath returns the runtime functions.yml of the artifact entry.
+func (b Bundle) FunctionsPath() string {
+	configDir := b.RuntimeConfigDir
+	if configDir == "" {
+		configDir = defaultRuntimeConfigDir
+	}
+	return filepath.Join(b.ArtifactRoot, configDir, functionsFileName)
+}
+
+// FunctionImages returns the functions.<name>.image refs of the artifact
+// entry's functions.yml. A missing file has no refs.
+func (b Bundle) FunctionImages() ([]string, error) {
+	path := b.FunctionsPath()
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	functions := mappingValue(documentRoot(&doc), "functions")
+	if functions == nil || functions.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	refs := make([]string, 0, len(functions.Content)/2)
+	for i := 1; i < len(functions.Content); i += 2 {
+		image := mappingValue(functions.Content[i], "image")
+		if image == nil || image.Kind != yaml.ScalarNode || strings.TrimSpace(image.Value) == "" {
+			continue
+		}
+		refs = append(refs, strings.TrimSpace(image.Value))
+	}
+	return refs, nil
+}
+
+// multiPlatform reports whether the bundle recorded a manifest list digest.
+func (i Image) multiPlatform() bool {
+	return strings.Contains(i.Platform, ",")
+}
+
+// splitRegistry splits an image reference into registry host and path.
+// The host is empty for Docker Hub references.
+func splitRegistry(name string) (string, string) {
+	first, rest, ok := strings.Cut(name, "/")
+	if !ok {
+		return "", name
+	}
+	if strings.ContainsAny(first, ".:") || first == "localhost" {
+		return first, rest
+	}
+	return "", name
+}
+
+// normalizeRef expands a reference to the fully qualified form containerd
+// and OCI layout annotations use (docker.io/library/alpine:latest).
+func normalizeRef(name string) string {
+	name = strings.TrimSpace(name)
+	host, path := splitRegistry(name)
+	if host == "" {
+		host = "docker.io"
+		if !strings.Contains(path, "/") {
+			path = "library/" + path
+		}
+	}
+	last := path[strings.LastIndex(path, "/")+1:]
+	if !strings.ContainsAny(last, ":@") {
+		path += ":latest"
+	}
+	return host + "/" + path
+}
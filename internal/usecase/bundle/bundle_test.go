@@ -0,0 +1,254 @@
+// Where: cli/internal/usecase/bundle/bundle_test.go
+// What: Tests for bundle image export/import.
+// Why: Digests must be verified before images are shipped or loaded.
+package bundle
+
+import (
+	"archive/tar"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type archiveImage struct {
+	name   string
+	config string
+}
+
+type fakeRunner struct {
+	commands []string
+	ids      map[string]string
+	// save is written as the docker save output.
+	save []archiveImage
+}
+
+func (r *fakeRunner) Run(_ context.Context, _, name string, args ...string) error {
+	r.commands = append(r.commands, strings.Join(append([]string{name}, args...), " "))
+	if name == "docker" && len(args) > 2 && args[0] == "save" {
+		return writeTestArchive(args[2], r.save)
+	}
+	return nil
+}
+
+func (r *fakeRunner) RunQuiet(ctx context.Context, dir, name string, args ...string) error {
+	return r.Run(ctx, dir, name, args...)
+}
+
+func (r *fakeRunner) RunOutput(_ context.Context, _, _ string, args ...string) ([]byte, error) {
+	id, ok := r.ids[args[len(args)-1]]
+	if !ok {
+		return nil, fmt.Errorf("no such image")
+	}
+	return []byte(id + "\n"), nil
+}
+
+func writeTestArchive(path string, images []archiveImage) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	writer := tar.NewWriter(file)
+	manifest := make([]archiveManifestEntry, 0, len(images))
+	index := map[string]any{"schemaVersion": 2}
+	descriptors := []map[string]any{}
+	for _, image := range images {
+		hex := strings.TrimPrefix(image.config, "sha256:")
+		manifest = append(manifest, archiveManifestEntry{Config: "blobs/sha256/" + hex, RepoTags: []string{image.name}})
+		descriptors = append(descriptors, map[string]any{
+			"digest":      "sha256:manifest-" + hex,
+			"annotations": map[string]string{ociImageNameKey: normalizeRef(image.name)},
+		})
+	}
+	index["manifests"] = descriptors
+	files := map[string]any{ociIndexFile: index, dockerManifestFile: manifest}
+	for _, name := range []string{ociLayoutFile, ociIndexFile, dockerManifestFile} {
+		data := []byte(`{"imageLayoutVersion": "1.0.0"}`)
+		if content, ok := files[name]; ok {
+			if data, err = json.Marshal(content); err != nil {
+				return err
+			}
+		}
+		if err := writer.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(data))}); err != nil {
+			return err
+		}
+		if _, err := writer.Write(data); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
+func writeTestArtifact(t *testing.T, images string) string {
+	t.Helper()
+	root := t.TempDir()
+	files := map[string]string{
+		"artifact.yml":             "artifacts:\n  - artifact_root: app\n    runtime_config_dir: config\n    bundle_manifest: bundle/manifest.json\n",
+		"app/bundle/manifest.json": `{"images": ` + images + `}`,
+		"app/config/functions.yml": "# generated\nfunctions:\n  lambda-hello:\n    image: \"registry:5010/esb-lambda-hello:latest\"\n    timeout: 30\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("mkdir: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	return filepath.Join(root, "artifact.yml")
+}
+
+const testBundleImages = `[
+  {"name": "127.0.0.1:5010/esb-lambda-hello:latest", "digest": "sha256:aaa", "kind": "function", "platform": "linux/amd64"},
+  {"name": "alpine:latest", "digest": "sha256:bbb", "kind": "external", "platform": "linux/amd64"}
+]`
+
+func TestExportSavesAndVerifiesBundleImages(t *testing.T) {
+	artifactPath := writeTestArtifact(t, testBundleImages)
+	runner := &fakeRunner{
+		ids: map[string]string{"127.0.0.1:5010/esb-lambda-hello:latest": "sha256:aaa"},
+		save: []archiveImage{
+			{name: "127.0.0.1:5010/esb-lambda-hello:latest", config: "sha256:aaa"},
+			{name: "alpine:latest", config: "sha256:bbb"},
+		},
+	}
+	// alpine is missing locally and gets pulled first.
+	pullThenPresent := &pullingRunner{fakeRunner: runner, pulled: map[string]string{"alpine:latest": "sha256:bbb"}}
+
+	result, err := Workflow{Runner: pullThenPresent}.Export(t.Context(), ExportRequest{ArtifactPath: artifactPath})
+	if err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	if result.Path != filepath.Join(filepath.Dir(artifactPath), ArchiveFileName) || len(result.Images) != 2 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	joined := strings.Join(runner.commands, "\n")
+	if !strings.Contains(joined, "docker pull alpine:latest") ||
+		!strings.Contains(joined, "docker save -o "+result.Path+" 127.0.0.1:5010/esb-lambda-hello:latest alpine:latest") {
+		t.Fatalf("unexpected commands:\n%s", joined)
+	}
+}
+
+// pullingRunner makes pulled images visible to later inspects.
+type pullingRunner struct {
+	*fakeRunner
+	pulled map[string]string
+}
+
+func (r *pullingRunner) Run(ctx context.Context, dir, name string, args ...string) error {
+	if name == "docker" && len(args) == 2 && args[0] == "pull" {
+		r.ids[args[1]] = r.pulled[args[1]]
+	}
+	return r.fakeRunner.Run(ctx, dir, name, args...)
+}
+
+func TestExportRejectsStaleLocalImage(t *testing.T) {
+	artifactPath := writeTestArtifact(t, testBundleImages)
+	runner := &fakeRunner{ids: map[string]string{
+		"127.0.0.1:5010/esb-lambda-hello:latest": "sha256:rebuilt",
+		"alpine:latest":                          "sha256:bbb",
+	}}
+	_, err := Workflow{Runner: runner}.Export(t.Context(), ExportRequest{ArtifactPath: artifactPath})
+	if err == nil || !strings.Contains(err.Error(), "is sha256:rebuilt, bundle expects sha256:aaa") {
+		t.Fatalf("expected stale image error, got %v", err)
+	}
+}
+
+func TestImportVerifiesLoadsAndRewritesRefs(t *testing.T) {
+	artifactPath := writeTestArtifact(t, testBundleImages)
+	archive := filepath.Join(filepath.Dir(artifactPath), ArchiveFileName)
+	if err := writeTestArchive(archive, []archiveImage{
+		{name: "127.0.0.1:5010/esb-lambda-hello:latest", config: "sha256:aaa"},
+		{name: "alpine:latest", config: "sha256:bbb"},
+	}); err != nil {
+		t.Fatalf("write archive: %v", err)
+	}
+	runner := &fakeRunner{}
+	result, err := Workflow{Runner: runner}.Import(t.Context(), ImportRequest{
+		ArtifactPath: artifactPath,
+		Registry:     "registry.internal:5000/",
+	})
+	if err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	want := []string{
+		"docker load -i " + archive,
+		"docker tag 127.0.0.1:5010/esb-lambda-hello:latest registry.internal:5000/esb-lambda-hello:latest",
+		"docker push registry.internal:5000/esb-lambda-hello:latest",
+	}
+	if strings.Join(runner.commands, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("unexpected commands:\n%s", strings.Join(runner.commands, "\n"))
+	}
+	if len(result.RewrittenConfig) != 1 {
+		t.Fatalf("expected functions.yml rewrite, got %+v", result)
+	}
+	data, err := os.ReadFile(result.RewrittenConfig[0])
+	if err != nil {
+		t.Fatalf("read functions.yml: %v", err)
+	}
+	if !strings.Contains(string(data), `image: "registry.internal:5000/esb-lambda-hello:latest"`) ||
+		!strings.Contains(string(data), "# generated") {
+		t.Fatalf("unexpected functions.yml:\n%s", data)
+	}
+}
+
+func TestImportIntoContainerdNamespace(t *testing.T) {
+	artifactPath := writeTestArtifact(t, testBundleImages)
+	archive := filepath.Join(t.TempDir(), "images.tar")
+	if err := writeTestArchive(archive, []archiveImage{
+		{name: "127.0.0.1:5010/esb-lambda-hello:latest", config: "sha256:aaa"},
+		{name: "alpine:latest", config: "sha256:bbb"},
+	}); err != nil {
+		t.Fatalf("write archive: %v", err)
+	}
+	runner := &fakeRunner{}
+	if _, err := (Workflow{Runner: runner}).Import(t.Context(), ImportRequest{
+		ArtifactPath: artifactPath,
+		Archive:      archive,
+		Namespace:    "esb",
+	}); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+	if strings.Join(runner.commands, "\n") != "ctr -n esb images import "+archive {
+		t.Fatalf("unexpected commands:\n%s", strings.Join(runner.commands, "\n"))
+	}
+}
+
+func TestImportRejectsDigestMismatch(t *testing.T) {
+	artifactPath := writeTestArtifact(t, testBundleImages)
+	archive := filepath.Join(filepath.Dir(artifactPath), ArchiveFileName)
+	if err := writeTestArchive(archive, []archiveImage{
+		{name: "127.0.0.1:5010/esb-lambda-hello:latest", config: "sha256:other"},
+	}); err != nil {
+		t.Fatalf("write archive: %v", err)
+	}
+	runner := &fakeRunner{}
+	_, err := Workflow{Runner: runner}.Import(t.Context(), ImportRequest{ArtifactPath: artifactPath})
+	if err == nil ||
+		!strings.Contains(err.Error(), "esb-lambda-hello:latest: digest sha256:other, bundle expects sha256:aaa") ||
+		!strings.Contains(err.Error(), "alpine:latest: missing from archive") {
+		t.Fatalf("expected verification error, got %v", err)
+	}
+	if len(runner.commands) != 0 {
+		t.Fatalf("nothing should be loaded after a failed verification: %v", runner.commands)
+	}
+}
+
+func TestNormalizeRef(t *testing.T) {
+	cases := map[string]string{
+		"alpine":                            "docker.io/library/alpine:latest",
+		"rustfs/rustfs:latest":              "docker.io/rustfs/rustfs:latest",
+		"127.0.0.1:5010/esb-lambda-base:v1": "127.0.0.1:5010/esb-lambda-base:v1",
+		"localhost/esb-gateway":             "localhost/esb-gateway:latest",
+	}
+	for input, want := range cases {
+		if got := normalizeRef(input); got != want {
+			t.Fatalf("normalizeRef(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
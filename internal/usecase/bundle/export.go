@@ -0,0 +1,92 @@
+// Where: cli/internal/usecase/bundle/export.go
+// What: Save every bundle image into one OCI image-layout tarball.
+// Why: Ship artifacts to air-gapped environments without registry access.
+package bundle
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ExportRequest captures artifact export inputs.
+type ExportRequest struct {
+	ArtifactPath string
+	// Output is the tarball path; empty writes images.oci.tar next to artifact.yml.
+	Output string
+}
+
+// ExportResult describes the written tarball.
+type ExportResult struct {
+	Path   string
+	Images []Image
+}
+
+// Export saves the images of every bundle in artifact.yml with `docker save`
+// and checks the tarball against the bundle digests.
+func (w Workflow) Export(ctx context.Context, req ExportRequest) (ExportResult, error) {
+	if w.Runner == nil {
+		return ExportResult{}, errRunnerNotConfigured
+	}
+	bundles, err := ReadBundles(req.ArtifactPath)
+	if err != nil {
+		return ExportResult{}, err
+	}
+	images, err := collectImages(bundles)
+	if err != nil {
+		return ExportResult{}, err
+	}
+	dir := filepath.Dir(req.ArtifactPath)
+	names := make([]string, 0, len(images))
+	for _, image := range images {
+		if err := w.ensureLocalImage(ctx, dir, image); err != nil {
+			return ExportResult{}, err
+		}
+		names = append(names, image.Name)
+	}
+
+	output := defaultArchivePath(req.ArtifactPath, req.Output)
+	if err := os.MkdirAll(filepath.Dir(output), 0o755); err != nil {
+		return ExportResult{}, fmt.Errorf("create archive dir: %w", err)
+	}
+	args := append([]string{"save", "-o", output}, names...)
+	if err := w.Runner.Run(ctx, dir, "docker", args...); err != nil {
+		return ExportResult{}, fmt.Errorf("save images: %w", err)
+	}
+	contents, err := readArchive(output)
+	if err != nil {
+		return ExportResult{}, fmt.Errorf("%w (docker save writes an OCI layout since Docker 25)", err)
+	}
+	if err := contents.verify(images); err != nil {
+		return ExportResult{}, err
+	}
+	return ExportResult{Path: output, Images: images}, nil
+}
+
+// ensureLocalImage pulls images missing from the local store and checks that
+// single-platform images still have the bundle digest.
+func (w Workflow) ensureLocalImage(ctx context.Context, dir string, image Image) error {
+	id, err := w.localImageID(ctx, dir, image.Name)
+	if err != nil {
+		if pullErr := w.Runner.Run(ctx, dir, "docker", "pull", image.Name); pullErr != nil {
+			return fmt.Errorf("image %s is not available locally and pull failed: %w", image.Name, pullErr)
+		}
+		if id, err = w.localImageID(ctx, dir, image.Name); err != nil {
+			return fmt.Errorf("inspect %s: %w", image.Name, err)
+		}
+	}
+	if !image.multiPlatform() && id != image.Digest {
+		return fmt.Errorf("local image %s is %s, bundle expects %s", image.Name, id, image.Digest)
+	}
+	return nil
+}
+
+func (w Workflow) localImageID(ctx context.Context, dir, name string) (string, error) {
+	out, err := w.Runner.RunOutput(ctx, dir, "docker", "image", "inspect", "--format", "{{.Id}}", name)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
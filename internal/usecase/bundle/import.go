@@ -0,0 +1,207 @@
+// Where: cli/internal/usecase/bundle/import.go
+// What: Load an exported image tarball and retarget function image refs.
+// Why: Deploy bundles in air-gapped environments from a single archive.
+package bundle
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	defaultRuntimeConfigDir = "config"
+	functionsFileName       = "functions.yml"
+	functionImageKind       = "function"
+)
+
+// ImportRequest captures artifact import inputs.
+type ImportRequest struct {
+	ArtifactPath string
+	// Archive is the tarball path; empty reads images.oci.tar next to artifact.yml.
+	Archive string
+	// Registry receives the function images; empty keeps the original refs.
+	Registry string
+	// Namespace loads into this containerd namespace (ctr) instead of Docker.
+	Namespace string
+}
+
+// ImportedImage maps a bundle image to the ref it was loaded as.
+type ImportedImage struct {
+	Source string
+	Target string
+	Digest string
+}
+
+// ImportResult lists the loaded images and rewritten config files.
+type ImportResult struct {
+	Images          []ImportedImage
+	RewrittenConfig []string
+}
+
+// Import verifies the tarball against the bundle digests, loads it into
+// Docker or a containerd namespace and, with a registry, pushes function
+// images there and rewrites their refs in functions.yml.
+func (w Workflow) Import(ctx context.Context, req ImportRequest) (ImportResult, error) {
+	if w.Runner == nil {
+		return ImportResult{}, errRunnerNotConfigured
+	}
+	bundles, err := ReadBundles(req.ArtifactPath)
+	if err != nil {
+		return ImportResult{}, err
+	}
+	images, err := collectImages(bundles)
+	if err != nil {
+		return ImportResult{}, err
+	}
+	archive := defaultArchivePath(req.ArtifactPath, req.Archive)
+	contents, err := readArchive(archive)
+	if err != nil {
+		return ImportResult{}, err
+	}
+	if err := contents.verify(images); err != nil {
+		return ImportResult{}, err
+	}
+
+	dir := filepath.Dir(req.ArtifactPath)
+	namespace := strings.TrimSpace(req.Namespace)
+	if namespace != "" {
+		err = w.Runner.Run(ctx, dir, "ctr", "-n", namespace, "images", "import", archive)
+	} else {
+		err = w.Runner.Run(ctx, dir, "docker", "load", "-i", archive)
+	}
+	if err != nil {
+		return ImportResult{}, fmt.Errorf("load image archive: %w", err)
+	}
+
+	result := ImportResult{}
+	registry := strings.TrimSuffix(strings.TrimSpace(req.Registry), "/")
+	rewrites := map[string]string{}
+	for _, image := range images {
+		imported := ImportedImage{Source: image.Name, Target: image.Name, Digest: image.Digest}
+		if registry != "" && image.Kind == functionImageKind {
+			imported.Target = retarget(image.Name, registry)
+			if err := w.pushImage(ctx, dir, namespace, image.Name, imported.Target); err != nil {
+				return ImportResult{}, err
+			}
+			rewrites[ImagePath(image.Name)] = imported.Target
+		}
+		result.Images = append(result.Images, imported)
+	}
+	if len(rewrites) == 0 {
+		return result, nil
+	}
+	for _, b := range bundles {
+		path := b.FunctionsPath()
+		changed, err := rewriteFunctionImages(path, rewrites)
+		if err != nil {
+			return ImportResult{}, err
+		}
+		if changed {
+			result.RewrittenConfig = append(result.RewrittenConfig, path)
+		}
+	}
+	return result, nil
+}
+
+func (w Workflow) pushImage(ctx context.Context, dir, namespace, source, target string) error {
+	if namespace != "" {
+		if err := w.Runner.Run(ctx, dir, "ctr", "-n", namespace, "images", "tag", "--force", normalizeRef(source), target); err != nil {
+			return fmt.Errorf("tag %s as %s: %w", source, target, err)
+		}
+		if err := w.Runner.Run(ctx, dir, "ctr", "-n", namespace, "images", "push", target); err != nil {
+			return fmt.Errorf("push %s: %w", target, err)
+		}
+		return nil
+	}
+	if err := w.Runner.Run(ctx, dir, "docker", "tag", source, target); err != nil {
+		return fmt.Errorf("tag %s as %s: %w", source, target, err)
+	}
+	if err := w.Runner.Run(ctx, dir, "docker", "push", target); err != nil {
+		return fmt.Errorf("push %s: %w", target, err)
+	}
+	return nil
+}
+
+// retarget replaces the registry host of name with registry.
+func retarget(name, registry string) string {
+	return registry + "/" + ImagePath(name)
+}
+
+// ImagePath strips the registry host from name, so refs can be compared
+// across the registries a bundle is imported into.
+func ImagePath(name string) string {
+	_, path := splitRegistry(strings.TrimSpace(name))
+	return path
+}
+
+// rewriteFunctionImages replaces functions.<name>.image values whose path
+// (ref without registry host) is in rewrites. A missing file is not an error.
+func rewriteFunctionImages(path string, rewrites map[string]string) (bool, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("read %s: %w", path, err)
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return false, fmt.Errorf("parse %s: %w", path, err)
+	}
+	functions := mappingValue(documentRoot(&doc), "functions")
+	if functions == nil || functions.Kind != yaml.MappingNode {
+		return false, nil
+	}
+	changed := false
+	for i := 1; i < len(functions.Content); i += 2 {
+		image := mappingValue(functions.Content[i], "image")
+		if image == nil || image.Kind != yaml.ScalarNode {
+			continue
+		}
+		if target, ok := rewrites[ImagePath(image.Value)]; ok && image.Value != target {
+			image.Value = target
+			changed = true
+		}
+	}
+	if !changed {
+		return false, nil
+	}
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return false, fmt.Errorf("encode %s: %w", path, err)
+	}
+	if err := encoder.Close(); err != nil {
+		return false, fmt.Errorf("encode %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return false, fmt.Errorf("write %s: %w", path, err)
+	}
+	return true, nil
+}
+
+func documentRoot(doc *yaml.Node) *yaml.Node {
+	if doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 {
+		return doc.Content[0]
+	}
+	return doc
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
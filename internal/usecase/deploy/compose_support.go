@@ -3,15 +3,18 @@
 // Why: Keep usecase focused on orchestration, not compose command details.
 package deploy
 
-func (w Workflow) checkServicesStatus(composeProject, mode string) {
+import "context"
+
+func (w Workflow) checkServicesStatus(ctx context.Context, composeProject, mode string) {
 	provisioner := w.composeProvisioner()
 	if provisioner == nil {
 		return
 	}
-	provisioner.CheckServicesStatus(composeProject, mode)
+	provisioner.CheckServicesStatus(ctx, composeProject, mode)
 }
 
 func (w Workflow) runProvisioner(
+	ctx context.Context,
 	composeProject,
 	mode string,
 	noDeps bool,
@@ -24,6 +27,7 @@ func (w Workflow) runProvisioner(
 		return nil
 	}
 	return provisioner.RunProvisioner(
+		ctx,
 		composeProject,
 		mode,
 		noDeps,
@@ -4,6 +4,7 @@
 package deploy
 
 import (
+	"context"
 	"errors"
 	"testing"
 )
@@ -26,13 +27,14 @@ type recordProvisioner struct {
 	runErr error
 }
 
-func (p *recordProvisioner) CheckServicesStatus(composeProject, mode string) {
+func (p *recordProvisioner) CheckServicesStatus(_ context.Context, composeProject, mode string) {
 	p.statusCalls++
 	p.statusReq.project = composeProject
 	p.statusReq.mode = mode
 }
 
 func (p *recordProvisioner) RunProvisioner(
+	_ context.Context,
 	composeProject string,
 	mode string,
 	noDeps bool,
@@ -54,7 +56,7 @@ func TestCheckServicesStatusDelegatesToProvisioner(t *testing.T) {
 	provisioner := &recordProvisioner{}
 	workflow := Workflow{ComposeProvisioner: provisioner}
 
-	workflow.checkServicesStatus("esb-dev", "docker")
+	workflow.checkServicesStatus(context.Background(), "esb-dev", "docker")
 
 	if provisioner.statusCalls != 1 {
 		t.Fatalf("expected one status call, got %d", provisioner.statusCalls)
@@ -72,6 +74,7 @@ func TestRunProvisionerDelegatesRequest(t *testing.T) {
 	workflow := Workflow{ComposeProvisioner: provisioner}
 
 	err := workflow.runProvisioner(
+		context.Background(),
 		"esb-dev",
 		"containerd",
 		true,
@@ -110,7 +113,7 @@ func TestRunProvisionerPropagatesError(t *testing.T) {
 	provisioner := &recordProvisioner{runErr: wantErr}
 	workflow := Workflow{ComposeProvisioner: provisioner}
 
-	err := workflow.runProvisioner("esb-dev", "docker", false, false, "/tmp/project", nil)
+	err := workflow.runProvisioner(context.Background(), "esb-dev", "docker", false, false, "/tmp/project", nil)
 	if !errors.Is(err, wantErr) {
 		t.Fatalf("expected propagated error, got %v", err)
 	}
@@ -87,6 +87,19 @@ func emitTemplateDeltaSummary(printer ui.UserInterface, configDir string, diff d
 	printer.Block("🧾", "Template delta summary", rows)
 }
 
+func emitConfigDiffEvent(events ui.EventSink, scope, configDir string, diff domaincfg.Diff) {
+	if events == nil {
+		return
+	}
+	events.Emit(ui.EventConfigDiff, ui.ConfigDiffEvent{
+		Scope:     scope,
+		ConfigDir: configDir,
+		Functions: diff.Functions,
+		Routes:    diff.Routes,
+		Resources: diff.Resources,
+	})
+}
+
 func buildConfigSummaryRows(
 	configLabel string,
 	configDir string,
@@ -4,11 +4,13 @@
 package deploy
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
 	deployport "github.com/poruru-code/esb-cli/internal/domain/deployport"
 	"github.com/poruru-code/esb-cli/internal/domain/state"
+	"github.com/poruru-code/esb-cli/internal/domain/timeout"
 	"github.com/poruru-code/esb-cli/internal/infra/build"
 	"github.com/poruru-code/esb-cli/internal/infra/compose"
 	"github.com/poruru-code/esb-cli/internal/infra/ui"
@@ -19,26 +21,48 @@ var (
 	errComposeRunnerNotConfigured = errors.New("compose runner is not configured")
 	errDockerClientNotConfigured  = errors.New("docker client is not configured")
 	errRegistryNotResponding      = errors.New("registry not responding")
+	errDeployInterrupted          = errors.New("deploy interrupted")
 )
 
 // Request captures the inputs required to run a deploy.
 type Request struct {
-	Context        state.Context
-	ArtifactPath   string
-	SecretEnvPath  string
-	OutputDir      string
-	Parameters     map[string]string
-	ImageSources   map[string]string
-	ImageRuntimes  map[string]string
-	Tag            string
-	NoCache        bool
-	NoDeps         bool
-	Verbose        bool
-	ComposeFiles   []string
-	BuildOnly      bool
-	BuildImages    *bool
-	BundleManifest bool
-	Emoji          bool
+	// Ctx cancels the workflow (Ctrl-C): running docker commands are
+	// interrupted and temp files, locks and partial config syncs are cleaned
+	// up. Nil means context.Background.
+	Ctx           context.Context
+	Context       state.Context
+	ArtifactPath  string
+	SecretEnvPath string
+	OutputDir     string
+	Parameters    map[string]string
+	// NoEchoParameters names Parameters declared NoEcho: true; history
+	// records them masked.
+	NoEchoParameters []string
+	ImageSources     map[string]string
+	ImageRuntimes    map[string]string
+	Tag              string
+	NoCache          bool
+	NoDeps           bool
+	Verbose          bool
+	ComposeFiles     []string
+	BuildOnly        bool
+	BuildImages      *bool
+	BundleManifest   bool
+	Emoji            bool
+	// Functions limits the build to functions matching these names or globs
+	// (--function and watch mode). Empty builds every function.
+	Functions []string
+	// BuildCache is passed to build.BuildRequest.BuildCache.
+	BuildCache string
+	// BundleSignKey is passed to build.BuildRequest.BundleSignKey.
+	BundleSignKey string
+	// Explain is passed to build.BuildRequest.Explain.
+	Explain bool
+	// Timeouts bounds each deploy phase; build phases get it through
+	// build.BuildRequest.Timeouts.
+	Timeouts timeout.Budget
+	// Events receives structured progress events in JSON output mode.
+	Events ui.EventSink
 }
 
 // Workflow executes the deploy orchestration steps.
@@ -80,6 +104,7 @@ func (w Workflow) buildRequest(req Request) build.BuildRequest {
 		buildImages = *req.BuildImages
 	}
 	return build.BuildRequest{
+		Ctx:           req.Ctx,
 		ProjectDir:    req.Context.ProjectDir,
 		ProjectName:   req.Context.ComposeProject,
 		TemplatePath:  req.Context.TemplatePath,
@@ -95,9 +120,35 @@ func (w Workflow) buildRequest(req Request) build.BuildRequest {
 		BuildImages:   buildImages,
 		Bundle:        req.BundleManifest,
 		Emoji:         req.Emoji,
+		Functions:     req.Functions,
+		BuildCache:    req.BuildCache,
+		BundleSignKey: req.BundleSignKey,
+		Explain:       req.Explain,
+		Timeouts:      req.Timeouts,
+		Events:        req.Events,
 	}
 }
 
+func (r Request) requestContext() context.Context {
+	if r.Ctx == nil {
+		return context.Background()
+	}
+	return r.Ctx
+}
+
+// interruptedError reports a cancelled workflow as such instead of as the
+// failure of whichever docker command was running. A workflow stopped by the
+// --timeout budget reports that budget instead.
+func interruptedError(ctx context.Context, err error) error {
+	if err == nil || ctx.Err() == nil {
+		return err
+	}
+	if exceeded, ok := timeout.Cause(ctx); ok {
+		return fmt.Errorf("%w: %w", exceeded, err)
+	}
+	return fmt.Errorf("%w: %w", errDeployInterrupted, ctx.Err())
+}
+
 func (w Workflow) successMessage(req Request) string {
 	if req.BuildOnly {
 		return "Build complete"
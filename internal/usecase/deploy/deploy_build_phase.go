@@ -4,5 +4,8 @@
 package deploy
 
 func (w Workflow) runBuildPhase(req Request) error {
+	if err := req.requestContext().Err(); err != nil {
+		return err
+	}
 	return w.Build(w.buildRequest(req))
 }
@@ -0,0 +1,167 @@
+// Where: cli/internal/usecase/deploy/deploy_history.go
+// What: Deploy history recording after a successful deploy/apply/rollback.
+// Why: Keep an audit trail of what was applied and the config rollback restores.
+package deploy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"os/user"
+	"sort"
+	"strings"
+	"time"
+
+	domaincfg "github.com/poruru-code/esb-cli/internal/domain/config"
+	"github.com/poruru-code/esb-cli/internal/infra/history"
+	"github.com/poruru-code/esb-cli/internal/infra/templategen"
+	"github.com/poruru-code/esb/pkg/yamlshape"
+)
+
+// maskedParameterValue replaces NoEcho parameter values in entry.json.
+const maskedParameterValue = "****"
+
+// recordHistory appends a history entry for the applied staging config. The
+// deploy already succeeded, so failures only warn.
+func (w Workflow) recordHistory(req Request, kind string) {
+	configDir, err := resolveApplyConfigDir(req.Context)
+	if err != nil {
+		w.warnf("Warning: failed to record deploy history: %v", err)
+		return
+	}
+	entry := w.historyEntry(req.requestContext(), req, kind, configDir)
+	recorded, err := history.Record(req.Context.TemplatePath, entry, func(dir string) ([]string, error) {
+		return copyPresentConfigFiles(configDir, dir)
+	})
+	if err != nil {
+		w.warnf("Warning: failed to record deploy history: %v", err)
+		return
+	}
+	if w.UserInterface != nil {
+		w.UserInterface.Info(fmt.Sprintf("Recorded deploy history %s", recorded.ID))
+	}
+}
+
+// recordRollbackHistory appends a rollback entry holding the restored config,
+// so the latest entry is again the config the runtime serves.
+func (w Workflow) recordRollbackHistory(repoRoot string, restored history.Entry) {
+	entry := restored
+	entry.ID, entry.Dir, entry.Files = "", "", nil
+	entry.CreatedAt = time.Time{}
+	entry.Kind = history.KindRollback
+	entry.User = currentUserName()
+	entry.RolledBackTo = restored.ID
+	entry.ConfigDiff = historyConfigDiff(repoRoot, restored.Project, restored.Env, restored.ConfigDir())
+	recorded, err := history.Record(repoRoot, entry, func(dir string) ([]string, error) {
+		return copyPresentConfigFiles(restored.ConfigDir(), dir)
+	})
+	if err != nil {
+		w.warnf("Warning: failed to record deploy history: %v", err)
+		return
+	}
+	if w.UserInterface != nil {
+		w.UserInterface.Info(fmt.Sprintf("Recorded deploy history %s", recorded.ID))
+	}
+}
+
+func (w Workflow) historyEntry(ctx context.Context, req Request, kind, configDir string) history.Entry {
+	entry := history.Entry{
+		Kind:       kind,
+		Project:    req.Context.ComposeProject,
+		Env:        req.Context.Env,
+		Mode:       req.Context.Mode,
+		User:       currentUserName(),
+		Template:   history.Template{Path: req.Context.TemplatePath},
+		Parameters: map[string]string{},
+		Images:     w.historyImages(ctx, req.Context.ProjectDir, configDir),
+	}
+	for key, value := range req.Parameters {
+		entry.Parameters[key] = value
+	}
+	for _, key := range req.NoEchoParameters {
+		if _, ok := entry.Parameters[key]; ok {
+			entry.Parameters[key] = maskedParameterValue
+		}
+	}
+	if data, err := os.ReadFile(req.Context.TemplatePath); err == nil {
+		sum := sha256.Sum256(data)
+		entry.Template.Sha256 = hex.EncodeToString(sum[:])
+	}
+	if w.ComposeRunner != nil {
+		if commit, dirty, err := templategen.ResolveGitMetadata(ctx, w.ComposeRunner, req.Context.ProjectDir); err == nil {
+			entry.Git = history.Git{Commit: commit, Dirty: dirty}
+		}
+	}
+	entry.ConfigDiff = historyConfigDiff(req.Context.TemplatePath, req.Context.ComposeProject, req.Context.Env, configDir)
+	return entry
+}
+
+// historyConfigDiff counts the changes of configDir against the latest entry
+// of project/env.
+func historyConfigDiff(path, project, env, configDir string) history.ConfigDiff {
+	before := domaincfg.Snapshot{}
+	previous, ok, err := history.Latest(path, project, env)
+	if err == nil && ok {
+		if snapshot, err := loadConfigSnapshot(previous.ConfigDir()); err == nil {
+			before = snapshot
+		}
+	}
+	after, err := loadConfigSnapshot(configDir)
+	if err != nil {
+		return history.ConfigDiff{}
+	}
+	diff := diffConfigSnapshots(before, after)
+	return history.ConfigDiff{
+		Functions: diff.Functions,
+		Routes:    diff.Routes,
+		Resources: diff.Resources,
+	}
+}
+
+// historyImages lists the function images of the applied functions.yml with
+// their local image ids; an image missing locally is kept without digest.
+func (w Workflow) historyImages(ctx context.Context, projectDir, configDir string) []history.Image {
+	images := []history.Image{}
+	snapshot, err := loadConfigSnapshot(configDir)
+	if err != nil {
+		return images
+	}
+	for name, raw := range snapshot.Functions {
+		image, _ := yamlshape.AsMap(raw)["image"].(string)
+		if strings.TrimSpace(image) == "" {
+			continue
+		}
+		images = append(images, history.Image{
+			Function: name,
+			Image:    image,
+			Digest:   w.localImageID(ctx, projectDir, image),
+		})
+	}
+	sort.Slice(images, func(i, j int) bool { return images[i].Function < images[j].Function })
+	return images
+}
+
+func (w Workflow) localImageID(ctx context.Context, dir, image string) string {
+	if w.ComposeRunner == nil {
+		return ""
+	}
+	out, err := w.ComposeRunner.RunOutput(ctx, dir, "docker", "image", "inspect", "--format", "{{.Id}}", image)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func currentUserName() string {
+	if current, err := user.Current(); err == nil && strings.TrimSpace(current.Username) != "" {
+		return current.Username
+	}
+	for _, key := range []string{"USER", "USERNAME"} {
+		if value := strings.TrimSpace(os.Getenv(key)); value != "" {
+			return value
+		}
+	}
+	return ""
+}
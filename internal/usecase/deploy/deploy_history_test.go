@@ -0,0 +1,105 @@
+// Where: cli/internal/usecase/deploy/deploy_history_test.go
+// What: Tests for deploy history recording.
+// Why: Every successful apply must leave an auditable ledger entry.
+package deploy
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/poruru-code/esb-cli/internal/domain/state"
+	"github.com/poruru-code/esb-cli/internal/infra/history"
+)
+
+// historyRunner answers the git and docker image queries of recordHistory.
+type historyRunner struct {
+	fakeComposeRunner
+}
+
+func (r *historyRunner) RunOutput(_ context.Context, _ string, name string, args ...string) ([]byte, error) {
+	command := strings.Join(append([]string{name}, args...), " ")
+	switch {
+	case command == "git rev-parse HEAD":
+		return []byte("0123456789abcdef\n"), nil
+	case command == "git status --porcelain":
+		return []byte(" M template.yaml\n"), nil
+	case strings.HasPrefix(command, "docker image inspect"):
+		return []byte("sha256:feed\n"), nil
+	}
+	return nil, nil
+}
+
+func TestDeployWorkflowApplyRecordsHistory(t *testing.T) {
+	t.Setenv("ENV_PREFIX", "ESB")
+	ui := &testUI{}
+	workflow := NewDeployWorkflow(nil, nil, ui, &historyRunner{})
+	workflow.RegistryWaiter = noopRegistryWaiter
+	repoRoot := newTestRepoRoot(t)
+	templatePath := filepath.Join(repoRoot, "template.yaml")
+	artifactPath := writeTestArtifactManifest(t)
+	artifactConfig := filepath.Join(filepath.Dir(filepath.Dir(artifactPath)), "artifact", "config")
+	req := Request{
+		Context: state.Context{
+			ComposeProject: "esb-dev",
+			ProjectDir:     repoRoot,
+			TemplatePath:   templatePath,
+			Env:            "dev",
+			Mode:           "docker",
+		},
+		ArtifactPath:     artifactPath,
+		Parameters:       map[string]string{"Stage": "dev", "DbPassword": "s3cret"},
+		NoEchoParameters: []string{"DbPassword"},
+	}
+
+	if err := workflow.Apply(req); err != nil {
+		t.Fatalf("first apply: %v", err)
+	}
+	writeRuntimeConfigFile(t, filepath.Join(artifactConfig, "functions.yml"),
+		"functions:\n  hello:\n    image: \"esb-hello:latest\"\n")
+	if err := workflow.Apply(req); err != nil {
+		t.Fatalf("second apply: %v", err)
+	}
+
+	entries, err := history.List(repoRoot, history.Filter{Env: "dev"})
+	if err != nil {
+		t.Fatalf("list history: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 history entries, got %d", len(entries))
+	}
+	latest := entries[0]
+	if latest.Kind != history.KindApply || latest.Project != "esb-dev" || latest.Mode != "docker" {
+		t.Fatalf("unexpected entry: %#v", latest)
+	}
+	if latest.Git != (history.Git{Commit: "0123456789abcdef", Dirty: true}) {
+		t.Fatalf("unexpected git metadata: %#v", latest.Git)
+	}
+	if latest.Template.Sha256 == "" || latest.Parameters["Stage"] != "dev" {
+		t.Fatalf("missing template sha or parameters: %#v", latest)
+	}
+	if latest.Parameters["DbPassword"] != "****" {
+		t.Fatalf("NoEcho parameter must be masked, got %q", latest.Parameters["DbPassword"])
+	}
+	if raw, err := os.ReadFile(filepath.Join(latest.Dir, "entry.json")); err != nil || strings.Contains(string(raw), "s3cret") {
+		t.Fatalf("NoEcho value must not be persisted in entry.json (err=%v)", err)
+	}
+	if req.Parameters["DbPassword"] != "s3cret" {
+		t.Fatal("masking must not modify the request parameters")
+	}
+	if len(latest.Images) != 1 || latest.Images[0] != (history.Image{Function: "hello", Image: "esb-hello:latest", Digest: "sha256:feed"}) {
+		t.Fatalf("unexpected images: %#v", latest.Images)
+	}
+	if latest.ConfigDiff.Functions.Added != 1 || entries[1].ConfigDiff.Functions.Added != 0 {
+		t.Fatalf("config diff must compare with the previous entry: %#v / %#v", latest.ConfigDiff, entries[1].ConfigDiff)
+	}
+	data, err := os.ReadFile(filepath.Join(latest.ConfigDir(), "functions.yml"))
+	if err != nil || !strings.Contains(string(data), "esb-hello:latest") {
+		t.Fatalf("applied config not saved: %q (%v)", data, err)
+	}
+	if !strings.Contains(strings.Join(ui.info, "\n"), "Recorded deploy history "+latest.ID) {
+		t.Fatalf("expected history notice, got %#v", ui.info)
+	}
+}
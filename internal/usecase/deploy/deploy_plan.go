@@ -0,0 +1,182 @@
+// Where: cli/internal/usecase/deploy/deploy_plan.go
+// What: Dry-run plan flow for deploy (render, merge, diff against live config).
+// Why: Preview per-entry config changes without building images or syncing.
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	domaincfg "github.com/poruru-code/esb-cli/internal/domain/config"
+	"github.com/poruru-code/esb-cli/internal/infra/compose"
+	"github.com/poruru-code/esb/pkg/artifactcore"
+)
+
+// PlanResult describes the planned runtime config changes.
+type PlanResult struct {
+	// LiveSource names where the current config was read from
+	// (bind:<path>, container:<id>, volume:<name>, staging:<dir> or none).
+	LiveSource string
+	Plan       domaincfg.Plan
+}
+
+// Render runs render-only generation for req. It never builds images.
+func (w Workflow) Render(req Request) error {
+	if w.Build == nil {
+		return errBuilderNotConfigured
+	}
+	req = w.alignGatewayRuntime(req)
+	if w.ApplyRuntimeEnv != nil {
+		if err := w.ApplyRuntimeEnv(req.Context); err != nil {
+			return err
+		}
+	}
+	buildImages := false
+	req.BuildOnly = true
+	req.BuildImages = &buildImages
+	req.BundleManifest = false
+	return w.runBuildPhase(req)
+}
+
+// Plan merges the rendered artifact (req.ArtifactPath) into a scratch config
+// dir and diffs it against the live runtime config. Nothing is synced.
+func (w Workflow) Plan(req Request) (PlanResult, error) {
+	req = w.alignGatewayRuntime(req)
+	req, err := normalizeApplyRequest(req)
+	if err != nil {
+		return PlanResult{}, err
+	}
+
+	scratchDir, err := os.MkdirTemp("", "esb-plan-config-*")
+	if err != nil {
+		return PlanResult{}, fmt.Errorf("create plan config dir: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(scratchDir) }()
+
+	observation, observationWarnings := w.resolveRuntimeObservation(req)
+	result, err := artifactcore.ExecuteApply(artifactcore.ApplyInput{
+		ArtifactPath:  req.ArtifactPath,
+		OutputDir:     scratchDir,
+		SecretEnvPath: req.SecretEnvPath,
+		Runtime:       observation,
+	})
+	if err != nil {
+		return PlanResult{}, fmt.Errorf("merge planned runtime config: %w", err)
+	}
+	if w.UserInterface != nil {
+		for _, warning := range append(observationWarnings, result.Warnings...) {
+			w.UserInterface.Warn(fmt.Sprintf("Warning: %s", warning))
+		}
+	}
+	planned, err := loadConfigSnapshot(scratchDir)
+	if err != nil {
+		return PlanResult{}, err
+	}
+
+	live, source, err := w.loadLiveConfigSnapshot(req)
+	if err != nil {
+		return PlanResult{}, err
+	}
+	return PlanResult{
+		LiveSource: source,
+		Plan:       domaincfg.PlanSnapshots(live, planned),
+	}, nil
+}
+
+// loadLiveConfigSnapshot reads the config currently mounted into the runtime,
+// falling back to the last applied staging dir when no container is found.
+func (w Workflow) loadLiveConfigSnapshot(req Request) (domaincfg.Snapshot, string, error) {
+	target, err := w.resolveRuntimeConfigTarget(req.requestContext(), req.Context.ComposeProject)
+	if err != nil {
+		return domaincfg.Snapshot{}, "", err
+	}
+	switch {
+	case target.BindPath != "":
+		snapshot, err := loadConfigSnapshot(target.BindPath)
+		return snapshot, "bind:" + target.BindPath, err
+	case target.ContainerID != "" || target.VolumeName != "":
+		return w.readRuntimeConfigTarget(req.requestContext(), target)
+	}
+
+	stagingDir, err := resolveApplyConfigDir(req.Context)
+	if err != nil {
+		return domaincfg.Snapshot{}, "", err
+	}
+	if _, err := os.Stat(stagingDir); err != nil {
+		return domaincfg.Snapshot{}, "none", nil
+	}
+	snapshot, err := loadConfigSnapshot(stagingDir)
+	return snapshot, "staging:" + stagingDir, err
+}
+
+// readRuntimeConfigTarget copies config files out of a container or volume
+// into a temp dir (read-only on the target side) and loads them.
+func (w Workflow) readRuntimeConfigTarget(ctx context.Context, target runtimeConfigTarget) (domaincfg.Snapshot, string, error) {
+	readDir, err := os.MkdirTemp("", "esb-plan-live-*")
+	if err != nil {
+		return domaincfg.Snapshot{}, "", fmt.Errorf("create live config dir: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(readDir) }()
+
+	var containerErr error
+	if target.ContainerID != "" {
+		containerErr = copyConfigFromContainer(ctx, w.ComposeRunner, target.ContainerID, readDir)
+		if containerErr == nil {
+			snapshot, err := loadConfigSnapshot(readDir)
+			return snapshot, "container:" + target.ContainerID, err
+		}
+	}
+	if target.VolumeName != "" {
+		if err := copyConfigFromVolume(ctx, w.ComposeRunner, target.VolumeName, readDir); err != nil {
+			return domaincfg.Snapshot{}, "", err
+		}
+		snapshot, err := loadConfigSnapshot(readDir)
+		return snapshot, "volume:" + target.VolumeName, err
+	}
+	return domaincfg.Snapshot{}, "", containerErr
+}
+
+func copyConfigFromContainer(ctx context.Context, runner compose.CommandRunner, containerID, destDir string) error {
+	if runner == nil {
+		return errComposeRunnerNotConfigured
+	}
+	found := false
+	for _, name := range runtimeConfigFiles {
+		src := containerID + ":" + runtimeConfigMountPath + "/" + name
+		// Missing files are expected (e.g. no resources.yml); only fail when
+		// nothing could be read at all.
+		if err := runner.RunQuiet(ctx, "", "docker", "cp", src, filepath.Join(destDir, name)); err == nil {
+			found = true
+		}
+	}
+	if !found {
+		return fmt.Errorf("read config from container %s: no config files found", containerID)
+	}
+	return nil
+}
+
+func copyConfigFromVolume(ctx context.Context, runner compose.CommandRunner, volume, destDir string) error {
+	if runner == nil {
+		return errComposeRunnerNotConfigured
+	}
+	cmd := "for f in " + strings.Join(runtimeConfigFiles, " ") + "; do " +
+		"if [ -f \"" + runtimeConfigMountPath + "/${f}\" ]; then cp -f \"" + runtimeConfigMountPath + "/${f}\" \"/dst/${f}\"; fi; " +
+		"done"
+	args := []string{
+		"run",
+		"--rm",
+		"-v", volume + ":" + runtimeConfigMountPath + ":ro",
+		"-v", destDir + ":/dst",
+		"alpine",
+		"sh",
+		"-c",
+		cmd,
+	}
+	if err := runner.RunQuiet(ctx, "", "docker", args...); err != nil {
+		return fmt.Errorf("read config from volume: %w", err)
+	}
+	return nil
+}
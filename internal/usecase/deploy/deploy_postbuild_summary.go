@@ -24,6 +24,7 @@ func (w Workflow) emitPostBuildSummary(req Request) {
 		} else {
 			diff := diffConfigSnapshots(domaincfg.Snapshot{}, templateSnapshot)
 			emitTemplateDeltaSummary(w.UserInterface, templateConfigDir, diff)
+			emitConfigDiffEvent(req.Events, "template", templateConfigDir, diff)
 		}
 	}
 
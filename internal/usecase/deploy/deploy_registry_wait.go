@@ -5,6 +5,7 @@ package deploy
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
@@ -12,12 +13,14 @@ import (
 	"os"
 	"strings"
 	"time"
+
+	"github.com/poruru-code/esb-cli/internal/domain/timeout"
 )
 
-// RegistryWaiter checks registry readiness.
-type RegistryWaiter func(registry string, timeout time.Duration) error
+// RegistryWaiter checks registry readiness until timeout or ctx cancellation.
+type RegistryWaiter func(ctx context.Context, registry string, timeout time.Duration) error
 
-func defaultRegistryWaiter(registry string, timeout time.Duration) error {
+func defaultRegistryWaiter(ctx context.Context, registry string, timeout time.Duration) error {
 	if strings.TrimSpace(registry) == "" {
 		return nil
 	}
@@ -30,7 +33,7 @@ func defaultRegistryWaiter(registry string, timeout time.Duration) error {
 	for time.Now().Before(deadline) {
 		for _, probeURL := range probeURLs {
 			client := registryWaitHTTPClient(probeURL)
-			req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, probeURL, nil)
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, probeURL, nil)
 			if err != nil {
 				return fmt.Errorf("create registry request: %w", err)
 			}
@@ -43,7 +46,11 @@ func defaultRegistryWaiter(registry string, timeout time.Duration) error {
 				}
 			}
 		}
-		time.Sleep(1 * time.Second)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(1 * time.Second):
+		}
 	}
 	return fmt.Errorf("%w at %s", errRegistryNotResponding, probeURLs[0])
 }
@@ -53,15 +60,23 @@ func (w Workflow) waitRegistryAndServices(req Request) error {
 		return nil
 	}
 
+	ctx := req.requestContext()
 	registry := w.resolveRegistryAddress()
 	if w.RegistryWaiter != nil {
-		if err := w.RegistryWaiter(registry, 60*time.Second); err != nil {
+		wait := req.Timeouts.For(timeout.PhaseRegistryWait)
+		if wait <= 0 {
+			wait = timeout.DefaultRegistryWait
+		}
+		if err := w.RegistryWaiter(ctx, registry, wait); err != nil {
+			if errors.Is(err, errRegistryNotResponding) {
+				err = timeout.Exceeded(timeout.PhaseRegistryWait, wait, err)
+			}
 			return fmt.Errorf("registry not ready: %w", err)
 		}
 	}
 
 	// Check gateway/agent status (warning only).
-	w.checkServicesStatus(req.Context.ComposeProject, req.Context.Mode)
+	w.checkServicesStatus(ctx, req.Context.ComposeProject, req.Context.Mode)
 	return nil
 }
 
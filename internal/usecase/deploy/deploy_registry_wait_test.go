@@ -1,6 +1,7 @@
 package deploy
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -19,7 +20,7 @@ func TestDefaultRegistryWaiterAcceptsUnauthorized(t *testing.T) {
 	}))
 	defer server.Close()
 
-	if err := defaultRegistryWaiter(server.URL, 2*time.Second); err != nil {
+	if err := defaultRegistryWaiter(context.Background(), server.URL, 2*time.Second); err != nil {
 		t.Fatalf("defaultRegistryWaiter should accept unauthorized registry response: %v", err)
 	}
 }
@@ -45,7 +46,7 @@ func TestDefaultRegistryWaiterBypassesProxyForLocalRegistry(t *testing.T) {
 	t.Setenv("NO_PROXY", "")
 	t.Setenv("no_proxy", "")
 
-	if err := defaultRegistryWaiter(parsed.Host, 2*time.Second); err != nil {
+	if err := defaultRegistryWaiter(context.Background(), parsed.Host, 2*time.Second); err != nil {
 		t.Fatalf("defaultRegistryWaiter should bypass proxy for local registry: %v", err)
 	}
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/poruru-code/esb-cli/internal/constants"
 	"github.com/poruru-code/esb-cli/internal/domain/state"
 	"github.com/poruru-code/esb-cli/internal/infra/envutil"
+	"github.com/poruru-code/esb-cli/internal/infra/history"
 	"github.com/poruru-code/esb-cli/internal/infra/staging"
 )
 
@@ -25,6 +26,10 @@ var (
 
 // Run executes the deploy workflow.
 func (w Workflow) Run(req Request) error {
+	return interruptedError(req.requestContext(), w.run(req))
+}
+
+func (w Workflow) run(req Request) error {
 	if w.Build == nil {
 		return errBuilderNotConfigured
 	}
@@ -53,6 +58,7 @@ func (w Workflow) Run(req Request) error {
 		if err := w.runApplyPhase(req); err != nil {
 			return err
 		}
+		w.recordHistory(req, history.KindDeploy)
 	}
 
 	// For containerd mode, function images are pulled by agent/runtime-node.
@@ -67,6 +73,10 @@ func (w Workflow) Run(req Request) error {
 
 // Apply executes apply-only deploy flow (no build/generation).
 func (w Workflow) Apply(req Request) error {
+	return interruptedError(req.requestContext(), w.apply(req))
+}
+
+func (w Workflow) apply(req Request) error {
 	if w.ComposeRunner == nil {
 		return errComposeRunnerNotConfigured
 	}
@@ -86,6 +96,7 @@ func (w Workflow) Apply(req Request) error {
 	if err := w.runApplyPhase(req); err != nil {
 		return err
 	}
+	w.recordHistory(req, history.KindApply)
 
 	if w.UserInterface != nil {
 		w.UserInterface.Success(w.successMessage(req))
@@ -1,6 +1,7 @@
 package deploy
 
 import (
+	"context"
 	"errors"
 	"path/filepath"
 	"strings"
@@ -8,6 +9,7 @@ import (
 	"time"
 
 	"github.com/poruru-code/esb-cli/internal/domain/state"
+	"github.com/poruru-code/esb-cli/internal/domain/timeout"
 	"github.com/poruru-code/esb/pkg/artifactcore"
 )
 
@@ -26,7 +28,7 @@ func TestRunApplyPhaseReturnsRegistryWaitError(t *testing.T) {
 	}
 
 	workflow := Workflow{
-		RegistryWaiter: func(string, time.Duration) error {
+		RegistryWaiter: func(context.Context, string, time.Duration) error {
 			return errors.New("registry timeout")
 		},
 	}
@@ -43,6 +45,39 @@ func TestRunApplyPhaseReturnsRegistryWaitError(t *testing.T) {
 	}
 }
 
+func TestRunApplyPhaseReportsRegistryWaitBudget(t *testing.T) {
+	t.Setenv("ENV_PREFIX", "")
+	repoRoot := newTestRepoRoot(t)
+
+	req := Request{
+		Context: state.Context{
+			ProjectDir:     repoRoot,
+			ComposeProject: "esb-dev",
+			TemplatePath:   filepath.Join(repoRoot, "template.yaml"),
+			Env:            "dev",
+			Mode:           "docker",
+		},
+		Timeouts: timeout.Budget{Phases: map[timeout.Phase]time.Duration{timeout.PhaseRegistryWait: 5 * time.Second}},
+	}
+
+	var waited time.Duration
+	workflow := Workflow{
+		RegistryWaiter: func(_ context.Context, _ string, wait time.Duration) error {
+			waited = wait
+			return errRegistryNotResponding
+		},
+	}
+
+	err := workflow.runApplyPhase(req)
+	if waited != 5*time.Second {
+		t.Fatalf("registry waiter got %v, want the registry_wait budget", waited)
+	}
+	var exceeded *timeout.ExceededError
+	if !errors.As(err, &exceeded) || exceeded.Phase != timeout.PhaseRegistryWait {
+		t.Fatalf("expected registry_wait budget error, got: %v", err)
+	}
+}
+
 func TestRunApplyPhaseReturnsArtifactPathRequiredWhenWaitPasses(t *testing.T) {
 	t.Setenv("ENV_PREFIX", "")
 	repoRoot := newTestRepoRoot(t)
@@ -4,29 +4,54 @@
 package deploy
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/poruru-code/esb-cli/internal/domain/timeout"
 	"github.com/poruru-code/esb/pkg/artifactcore"
 )
 
+// runRuntimeProvisionPhase syncs the runtime config and runs the
+// provisioner. When either fails, the config served before the sync is
+// restored; on success the caller records the applied config in the history.
 func (w Workflow) runRuntimeProvisionPhase(req Request, stagingDir string) error {
+	ctx := req.requestContext()
 	if err := w.applyArtifactRuntimeConfig(req, stagingDir); err != nil {
 		return err
 	}
-	if err := w.syncRuntimeConfigFromDir(req.Context.ComposeProject, stagingDir); err != nil {
-		return err
+	var rollback runtimeConfigRollback
+	defer func() { rollback.cleanup() }()
+	if err := timeout.Run(ctx, req.Timeouts, timeout.PhaseSync, func(ctx context.Context) error {
+		target, err := w.resolveRuntimeConfigTarget(ctx, req.Context.ComposeProject)
+		if err != nil {
+			return err
+		}
+		rollback = w.prepareRuntimeConfigRollback(ctx, req, stagingDir, target)
+		return w.syncRuntimeConfigToTarget(ctx, stagingDir, target)
+	}); err != nil {
+		return w.rollbackRuntimeConfig(ctx, rollback, err)
+	}
+	if err := timeout.Run(ctx, req.Timeouts, timeout.PhaseProvision, func(ctx context.Context) error {
+		return w.runProvisioner(
+			ctx,
+			req.Context.ComposeProject,
+			req.Context.Mode,
+			req.NoDeps,
+			req.Verbose,
+			req.Context.ProjectDir,
+			req.ComposeFiles,
+		)
+	}); err != nil {
+		return w.rollbackRuntimeConfig(ctx, rollback, w.wrapProvisionerError(err))
 	}
-	return w.wrapProvisionerError(w.runProvisioner(
-		req.Context.ComposeProject,
-		req.Context.Mode,
-		req.NoDeps,
-		req.Verbose,
-		req.Context.ProjectDir,
-		req.ComposeFiles,
-	))
+	return nil
 }
 
 func (w Workflow) applyArtifactRuntimeConfig(req Request, stagingDir string) error {
+	// The staging config is rewritten in place; never start once cancelled.
+	if err := req.requestContext().Err(); err != nil {
+		return err
+	}
 	observation, observationWarnings := w.resolveRuntimeObservation(req)
 	result, err := artifactcore.ExecuteApply(artifactcore.ApplyInput{
 		ArtifactPath:  req.ArtifactPath,
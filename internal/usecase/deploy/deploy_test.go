@@ -1,14 +1,18 @@
 package deploy
 
 import (
+	"context"
 	"errors"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/poruru-code/esb-cli/internal/constants"
 	"github.com/poruru-code/esb-cli/internal/domain/state"
+	"github.com/poruru-code/esb-cli/internal/domain/timeout"
+	"github.com/poruru-code/esb-cli/internal/infra/build"
 	infradeploy "github.com/poruru-code/esb-cli/internal/infra/deploy"
 	"github.com/poruru-code/esb-cli/internal/infra/envutil"
 	"github.com/poruru-code/esb-cli/internal/infra/staging"
@@ -190,6 +194,70 @@ func TestDeployWorkflowRunWithExternalTemplate(t *testing.T) {
 	}
 }
 
+func TestDeployWorkflowRunReportsInterruption(t *testing.T) {
+	t.Setenv("ESB_SKIP_GATEWAY_ALIGN", "1")
+	ctx, cancel := context.WithCancel(context.Background())
+	builder := &recordBuilder{}
+	buildFn := func(request build.BuildRequest) error {
+		if request.Ctx != ctx {
+			t.Fatalf("build request must carry the workflow context")
+		}
+		cancel()
+		return builder.Build(request)
+	}
+	builder.err = errors.New("run docker: signal: interrupt")
+	workflow := NewDeployWorkflow(buildFn, nil, &testUI{}, &fakeComposeRunner{})
+	workflow.RegistryWaiter = noopRegistryWaiter
+
+	err := workflow.Run(Request{
+		Ctx: ctx,
+		Context: state.Context{
+			ProjectDir:     t.TempDir(),
+			ComposeProject: "esb-dev",
+			TemplatePath:   "template.yaml",
+			Env:            "dev",
+			Mode:           "docker",
+		},
+		Tag:       "latest",
+		BuildOnly: true,
+	})
+	if !errors.Is(err, errDeployInterrupted) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected interrupted error, got %v", err)
+	}
+}
+
+func TestDeployWorkflowRunReportsTotalTimeout(t *testing.T) {
+	t.Setenv("ESB_SKIP_GATEWAY_ALIGN", "1")
+	ctx, cancel := timeout.WithTotal(context.Background(), time.Millisecond)
+	defer cancel()
+	buildFn := func(request build.BuildRequest) error {
+		<-request.Ctx.Done()
+		return errors.New("run docker: signal: interrupt")
+	}
+	workflow := NewDeployWorkflow(buildFn, nil, &testUI{}, &fakeComposeRunner{})
+	workflow.RegistryWaiter = noopRegistryWaiter
+
+	err := workflow.Run(Request{
+		Ctx: ctx,
+		Context: state.Context{
+			ProjectDir:     t.TempDir(),
+			ComposeProject: "esb-dev",
+			TemplatePath:   "template.yaml",
+			Env:            "dev",
+			Mode:           "docker",
+		},
+		Tag:       "latest",
+		BuildOnly: true,
+	})
+	var exceeded *timeout.ExceededError
+	if !errors.As(err, &exceeded) || exceeded.Phase != timeout.PhaseTotal {
+		t.Fatalf("expected total timeout error, got %v", err)
+	}
+	if errors.Is(err, errDeployInterrupted) {
+		t.Fatalf("timeout must not be reported as an interruption: %v", err)
+	}
+}
+
 func TestDeployWorkflowRunMissingBuilder(t *testing.T) {
 	workflow := NewDeployWorkflow(nil, nil, nil, nil)
 	err := workflow.Run(Request{Context: state.Context{}})
@@ -425,6 +493,7 @@ func TestRunProvisionerUsesComposeOverride(t *testing.T) {
 		t.Fatalf("write compose file: %v", err)
 	}
 	if err := workflow.runProvisioner(
+		context.Background(),
 		"esb-test",
 		"docker",
 		false,
@@ -465,6 +534,7 @@ func TestRunProvisionerSkipsOverrideMissingServicesPrecheck(t *testing.T) {
 		t.Fatalf("write compose file: %v", err)
 	}
 	err := workflow.runProvisioner(
+		context.Background(),
 		"esb-test",
 		"docker",
 		false,
@@ -495,6 +565,7 @@ func TestRunProvisionerWithNoDepsAddsFlag(t *testing.T) {
 		t.Fatalf("write compose file: %v", err)
 	}
 	if err := workflow.runProvisioner(
+		context.Background(),
 		"esb-test",
 		"docker",
 		true,
@@ -86,7 +86,7 @@ func (r *fakeComposeRunner) RunQuiet(ctx context.Context, dir, name string, args
 	return r.err
 }
 
-func noopRegistryWaiter(_ string, _ time.Duration) error {
+func noopRegistryWaiter(_ context.Context, _ string, _ time.Duration) error {
 	return nil
 }
 
@@ -96,11 +96,12 @@ type spyProvisioner struct {
 	runFn      func(composeProject, mode string, noDeps, verbose bool, projectDir string, composeFiles []string) error
 }
 
-func (p *spyProvisioner) CheckServicesStatus(_ string, _ string) {
+func (p *spyProvisioner) CheckServicesStatus(_ context.Context, _ string, _ string) {
 	p.checkCalls++
 }
 
 func (p *spyProvisioner) RunProvisioner(
+	_ context.Context,
 	composeProject string,
 	mode string,
 	noDeps bool,
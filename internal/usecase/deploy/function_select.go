@@ -0,0 +1,51 @@
+// Where: cli/internal/usecase/deploy/function_select.go
+// What: Template function lookup for selective deploys.
+// Why: Reject --function patterns that match nothing before any build starts.
+package deploy
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/poruru-code/esb-cli/internal/domain/template"
+	"github.com/poruru-code/esb-cli/internal/infra/sam"
+)
+
+// TemplateFunctionNames returns the function names defined in templatePath.
+func TemplateFunctionNames(templatePath string, parameters map[string]string) ([]string, error) {
+	_, functions, err := parseTemplateFunctions(templatePath, parameters)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(functions))
+	for _, fn := range functions {
+		names = append(names, fn.Name)
+	}
+	return names, nil
+}
+
+// parseTemplateFunctions parses templatePath offline and returns its
+// absolute path together with the parsed functions.
+func parseTemplateFunctions(
+	templatePath string,
+	parameters map[string]string,
+) (string, []template.FunctionSpec, error) {
+	absTemplate, err := filepath.Abs(templatePath)
+	if err != nil {
+		return "", nil, fmt.Errorf("resolve template path: %w", err)
+	}
+	content, err := os.ReadFile(absTemplate)
+	if err != nil {
+		return "", nil, fmt.Errorf("read template: %w", err)
+	}
+	params := make(map[string]string, len(parameters))
+	for key, value := range parameters {
+		params[key] = value
+	}
+	parsed, err := sam.ParseSAMTemplate(string(content), params)
+	if err != nil {
+		return "", nil, fmt.Errorf("parse template: %w", err)
+	}
+	return absTemplate, parsed.Functions, nil
+}
@@ -28,7 +28,7 @@ func (w Workflow) alignGatewayRuntime(req Request) Request {
 	if skipGatewayAlign() {
 		return req
 	}
-	info, err := w.resolveGatewayRuntime(req.Context.ComposeProject)
+	info, err := w.resolveGatewayRuntime(req.requestContext(), req.Context.ComposeProject)
 	if err != nil {
 		if w.UserInterface != nil {
 			w.UserInterface.Warn(fmt.Sprintf("Warning: failed to resolve gateway runtime: %v", err))
@@ -49,7 +49,7 @@ func (w Workflow) alignGatewayRuntime(req Request) Request {
 	if info.ContainersNetwork != "" && strings.TrimSpace(os.Getenv(constants.EnvNetworkExternal)) != info.ContainersNetwork {
 		_ = os.Setenv(constants.EnvNetworkExternal, info.ContainersNetwork)
 	}
-	w.warnInfraNetworkMismatch(req.Context.ComposeProject, info.ContainersNetwork)
+	w.warnInfraNetworkMismatch(req.requestContext(), req.Context.ComposeProject, info.ContainersNetwork)
 	return req
 }
 
@@ -58,7 +58,7 @@ func skipGatewayAlign() bool {
 	return value == "1" || value == "true" || value == "yes"
 }
 
-func (w Workflow) resolveGatewayRuntime(composeProject string) (gatewayRuntimeInfo, error) {
+func (w Workflow) resolveGatewayRuntime(ctx context.Context, composeProject string) (gatewayRuntimeInfo, error) {
 	if w.DockerClient == nil {
 		return gatewayRuntimeInfo{}, nil
 	}
@@ -67,28 +67,13 @@ func (w Workflow) resolveGatewayRuntime(composeProject string) (gatewayRuntimeIn
 		return gatewayRuntimeInfo{}, fmt.Errorf("create docker client: %w", err)
 	}
 
-	ctx := context.Background()
-	filterArgs := filters.NewArgs()
-	filterArgs.Add("label", fmt.Sprintf("%s=gateway", compose.ComposeServiceLabel))
-	if strings.TrimSpace(composeProject) != "" {
-		filterArgs.Add("label", fmt.Sprintf("%s=%s", compose.ComposeProjectLabel, composeProject))
-	}
-	containers, err := client.ContainerList(ctx, container.ListOptions{All: true, Filters: filterArgs})
+	selected, ok, err := compose.SelectGatewayContainer(ctx, client, composeProject)
 	if err != nil {
-		return gatewayRuntimeInfo{}, fmt.Errorf("list containers: %w", err)
+		return gatewayRuntimeInfo{}, err
 	}
-	if len(containers) == 0 {
+	if !ok {
 		return gatewayRuntimeInfo{}, nil
 	}
-	sort.SliceStable(containers, func(i, j int) bool {
-		iRunning := strings.EqualFold(containers[i].State, "running")
-		jRunning := strings.EqualFold(containers[j].State, "running")
-		if iRunning != jRunning {
-			return iRunning
-		}
-		return gatewayContainerName(containers[i]) < gatewayContainerName(containers[j])
-	})
-	selected := containers[0]
 	inspect, err := client.ContainerInspect(ctx, selected.ID)
 	if err != nil {
 		return gatewayRuntimeInfo{}, fmt.Errorf("inspect container: %w", err)
@@ -129,7 +114,7 @@ func pickGatewayNetwork(networks map[string]*network.EndpointSettings) string {
 	return ""
 }
 
-func (w Workflow) warnInfraNetworkMismatch(composeProject, gatewayNetwork string) {
+func (w Workflow) warnInfraNetworkMismatch(ctx context.Context, composeProject, gatewayNetwork string) {
 	if w.UserInterface == nil {
 		return
 	}
@@ -143,7 +128,6 @@ func (w Workflow) warnInfraNetworkMismatch(composeProject, gatewayNetwork string
 	if err != nil {
 		return
 	}
-	ctx := context.Background()
 	filterArgs := filters.NewArgs()
 	if strings.TrimSpace(composeProject) != "" {
 		filterArgs.Add("label", fmt.Sprintf("%s=%s", compose.ComposeProjectLabel, composeProject))
@@ -45,7 +45,7 @@ func TestPickGatewayNetworkFallsBackToSortedName(t *testing.T) {
 
 func TestResolveGatewayRuntimeSkipsWhenDockerClientNotConfigured(t *testing.T) {
 	workflow := Workflow{}
-	info, err := workflow.resolveGatewayRuntime("esb-dev")
+	info, err := workflow.resolveGatewayRuntime(context.Background(), "esb-dev")
 	if err != nil {
 		t.Fatalf("resolve gateway runtime: %v", err)
 	}
@@ -62,7 +62,7 @@ func TestResolveGatewayRuntimeWithEmptyProjectListsContainersOnce(t *testing.T)
 		},
 	}
 
-	info, err := workflow.resolveGatewayRuntime("")
+	info, err := workflow.resolveGatewayRuntime(context.Background(), "")
 	if err != nil {
 		t.Fatalf("resolve gateway runtime: %v", err)
 	}
@@ -94,7 +94,7 @@ func TestResolveGatewayRuntimeHandlesNilInspectFields(t *testing.T) {
 		},
 	}
 
-	info, err := workflow.resolveGatewayRuntime("esb-dev")
+	info, err := workflow.resolveGatewayRuntime(context.Background(), "esb-dev")
 	if err != nil {
 		t.Fatalf("resolve gateway runtime: %v", err)
 	}
@@ -287,7 +287,7 @@ func TestWarnInfraNetworkMismatchReportsMissingServices(t *testing.T) {
 		},
 	}
 
-	workflow.warnInfraNetworkMismatch("esb-dev", "external")
+	workflow.warnInfraNetworkMismatch(context.Background(), "esb-dev", "external")
 
 	if len(ui.warn) != 1 {
 		t.Fatalf("expected one warning, got %d", len(ui.warn))
@@ -33,7 +33,7 @@ var runtimeConfigFiles = []string{
 	"resources.yml",
 }
 
-func (w Workflow) syncRuntimeConfigFromDir(composeProject, stagingDir string) error {
+func (w Workflow) syncRuntimeConfigFromDir(ctx context.Context, composeProject, stagingDir string) error {
 	if strings.TrimSpace(composeProject) == "" {
 		return nil
 	}
@@ -46,14 +46,14 @@ func (w Workflow) syncRuntimeConfigFromDir(composeProject, stagingDir string) er
 		}
 		return fmt.Errorf("stat staging dir: %w", err)
 	}
-	target, err := w.resolveRuntimeConfigTarget(composeProject)
+	target, err := w.resolveRuntimeConfigTarget(ctx, composeProject)
 	if err != nil {
 		return err
 	}
-	return w.syncRuntimeConfigToTarget(stagingDir, target)
+	return w.syncRuntimeConfigToTarget(ctx, stagingDir, target)
 }
 
-func (w Workflow) syncRuntimeConfigToTarget(stagingDir string, target runtimeConfigTarget) error {
+func (w Workflow) syncRuntimeConfigToTarget(ctx context.Context, stagingDir string, target runtimeConfigTarget) error {
 	if target.BindPath == "" && target.VolumeName == "" && target.ContainerID == "" {
 		return nil
 	}
@@ -61,18 +61,21 @@ func (w Workflow) syncRuntimeConfigToTarget(stagingDir string, target runtimeCon
 		if samePath(target.BindPath, stagingDir) {
 			return nil
 		}
-		return copyConfigFiles(stagingDir, target.BindPath)
+		return copyConfigFiles(ctx, stagingDir, target.BindPath)
 	}
 	var containerErr error
 	if target.ContainerID != "" {
-		copyErr := copyConfigToContainer(w.ComposeRunner, stagingDir, target.ContainerID)
+		copyErr := copyConfigToContainer(ctx, w.ComposeRunner, stagingDir, target.ContainerID)
 		if copyErr == nil {
 			return nil
 		}
+		if ctx.Err() != nil {
+			return copyErr
+		}
 		containerErr = copyErr
 	}
 	if target.VolumeName != "" {
-		volumeErr := copyConfigToVolume(w.ComposeRunner, stagingDir, target.VolumeName)
+		volumeErr := copyConfigToVolume(ctx, w.ComposeRunner, stagingDir, target.VolumeName)
 		if volumeErr == nil {
 			return nil
 		}
@@ -84,7 +87,7 @@ func (w Workflow) syncRuntimeConfigToTarget(stagingDir string, target runtimeCon
 	return containerErr
 }
 
-func (w Workflow) resolveRuntimeConfigTarget(composeProject string) (runtimeConfigTarget, error) {
+func (w Workflow) resolveRuntimeConfigTarget(ctx context.Context, composeProject string) (runtimeConfigTarget, error) {
 	if w.DockerClient == nil {
 		return runtimeConfigTarget{}, nil
 	}
@@ -92,7 +95,6 @@ func (w Workflow) resolveRuntimeConfigTarget(composeProject string) (runtimeConf
 	if err != nil {
 		return runtimeConfigTarget{}, fmt.Errorf("create docker client: %w", err)
 	}
-	ctx := context.Background()
 	filterArgs := filters.NewArgs()
 	filterArgs.Add("label", fmt.Sprintf("%s=%s", compose.ComposeProjectLabel, composeProject))
 	containers, err := client.ContainerList(ctx, container.ListOptions{All: true, Filters: filterArgs})
@@ -159,24 +161,53 @@ func runtimeConfigServicePriority(summary container.Summary) int {
 	return 1
 }
 
-func copyConfigFiles(srcDir, destDir string) error {
+// copyConfigFiles replaces the runtime config files in destDir. When ctx is
+// cancelled or a copy fails midway, the files already replaced are restored so
+// the runtime never reads a mix of old and new config.
+func copyConfigFiles(ctx context.Context, srcDir, destDir string) (err error) {
 	if err := os.MkdirAll(destDir, 0o755); err != nil {
 		return fmt.Errorf("create config dir: %w", err)
 	}
+	// previous holds the replaced contents; nil marks a file that did not exist.
+	previous := map[string][]byte{}
+	defer func() {
+		if err != nil {
+			restoreConfigFiles(destDir, previous)
+		}
+	}()
 	for _, name := range runtimeConfigFiles {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		src := filepath.Join(srcDir, name)
 		if _, err := os.Stat(src); err != nil {
 			continue
 		}
 		dest := filepath.Join(destDir, name)
+		data, readErr := os.ReadFile(dest)
+		if readErr != nil && !os.IsNotExist(readErr) {
+			return fmt.Errorf("read %s: %w", dest, readErr)
+		}
 		if err := copyFile(src, dest); err != nil {
 			return err
 		}
+		previous[name] = data
 	}
 	return nil
 }
 
-func copyConfigToVolume(runner compose.CommandRunner, srcDir, volume string) error {
+func restoreConfigFiles(destDir string, previous map[string][]byte) {
+	for name, data := range previous {
+		dest := filepath.Join(destDir, name)
+		if data == nil {
+			_ = os.Remove(dest)
+			continue
+		}
+		_ = os.WriteFile(dest, data, 0o644)
+	}
+}
+
+func copyConfigToVolume(ctx context.Context, runner compose.CommandRunner, srcDir, volume string) error {
 	if runner == nil {
 		return errComposeRunnerNotConfigured
 	}
@@ -194,26 +225,40 @@ func copyConfigToVolume(runner compose.CommandRunner, srcDir, volume string) err
 		"-c",
 		cmd,
 	}
-	if err := runner.Run(context.Background(), "", "docker", args...); err != nil {
+	if err := runner.Run(ctx, "", "docker", args...); err != nil {
 		return fmt.Errorf("copy config to volume: %w", err)
 	}
 	return nil
 }
 
-func copyConfigToContainer(runner compose.CommandRunner, srcDir, containerID string) error {
+// copyConfigToContainer copies the config files with a single `docker cp` of
+// a temp dir, so an interrupted sync cannot leave only some files replaced.
+func copyConfigToContainer(ctx context.Context, runner compose.CommandRunner, srcDir, containerID string) error {
 	if runner == nil {
 		return errComposeRunnerNotConfigured
 	}
-	ctx := context.Background()
+	bundleDir, err := os.MkdirTemp("", "esb-config-sync-*")
+	if err != nil {
+		return fmt.Errorf("create config sync dir: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(bundleDir) }()
+	found := false
 	for _, name := range runtimeConfigFiles {
 		src := filepath.Join(srcDir, name)
 		if _, err := os.Stat(src); err != nil {
 			continue
 		}
-		dest := containerID + ":" + runtimeConfigMountPath + "/" + name
-		if err := runner.Run(ctx, "", "docker", "cp", src, dest); err != nil {
-			return fmt.Errorf("copy config to container: %w", err)
+		if err := copyFile(src, filepath.Join(bundleDir, name)); err != nil {
+			return err
 		}
+		found = true
+	}
+	if !found {
+		return nil
+	}
+	dest := containerID + ":" + runtimeConfigMountPath
+	if err := runner.Run(ctx, "", "docker", "cp", bundleDir+string(filepath.Separator)+".", dest); err != nil {
+		return fmt.Errorf("copy config to container: %w", err)
 	}
 	return nil
 }
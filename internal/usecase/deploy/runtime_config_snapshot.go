@@ -0,0 +1,355 @@
+// Where: cli/internal/usecase/deploy/runtime_config_snapshot.go
+// What: Runtime config snapshots and rollback after a failed apply.
+// Why: A failed provisioner must not leave the gateway serving config whose
+// resources were never created.
+package deploy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/poruru-code/esb-cli/internal/infra/compose"
+	"github.com/poruru-code/esb-cli/internal/infra/history"
+	"github.com/poruru-code/esb-cli/internal/infra/staging"
+	"github.com/poruru-code/esb-cli/internal/meta"
+)
+
+// rollbackTimeout bounds a restore that runs after ctx was cancelled.
+const rollbackTimeout = 2 * time.Minute
+
+// ErrNoRuntimeConfigSnapshot reports that no deploy has been recorded in the
+// history for the project/env, so there is nothing to roll back to.
+var ErrNoRuntimeConfigSnapshot = errors.New("no deploy history to roll back to")
+
+// configSnapshot is a saved copy of the runtime config files.
+type configSnapshot struct {
+	// Files lists the config files that existed; the others are removed on
+	// restore.
+	Files []string
+	// Dir holds the snapshot files.
+	Dir string
+}
+
+// RollbackRequest selects the project/env and the history entry whose
+// runtime config is restored.
+type RollbackRequest struct {
+	// Ctx cancels the restore. Nil means context.Background.
+	Ctx context.Context
+	// RepoRoot locates the staging root holding the history.
+	RepoRoot string
+	Project  string
+	Env      string
+	// ID is the history entry to restore; empty uses the latest entry.
+	ID string
+}
+
+// runtimeConfigRollback is the restore plan prepared before a sync.
+type runtimeConfigRollback struct {
+	snapshot configSnapshot
+	target   runtimeConfigTarget
+	ready    bool
+	// tempDir holds a snapshot captured from the target; removed by cleanup.
+	tempDir string
+}
+
+func (r runtimeConfigRollback) cleanup() {
+	if r.tempDir != "" {
+		_ = os.RemoveAll(r.tempDir)
+	}
+}
+
+// Rollback restores the runtime config of a deploy history entry (the latest
+// one unless req.ID is set) to the running gateway, or to the staging config
+// dir when no gateway container exists, and records the rollback in the
+// history.
+func (w Workflow) Rollback(req RollbackRequest) (history.Entry, error) {
+	ctx := req.Ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	entry, err := loadRollbackEntry(req.RepoRoot, req.Project, req.Env, req.ID)
+	if err != nil {
+		return history.Entry{}, err
+	}
+	target, err := w.resolveRuntimeConfigTarget(ctx, req.Project)
+	if err != nil {
+		return entry, err
+	}
+	if target == (runtimeConfigTarget{}) {
+		stagingDir, err := staging.ConfigDir(req.RepoRoot, req.Project, req.Env)
+		if err != nil {
+			return entry, err
+		}
+		target.BindPath = stagingDir
+	}
+	if err := w.restoreRuntimeConfig(ctx, historySnapshot(entry), target); err != nil {
+		return entry, fmt.Errorf("restore runtime config: %w", err)
+	}
+	w.recordRollbackHistory(req.RepoRoot, entry)
+	return entry, nil
+}
+
+// prepareRuntimeConfigRollback snapshots the config the target serves before
+// it is replaced. When the runtime reads the staging dir itself, the build
+// already replaced that config, so the latest deploy history entry is used.
+func (w Workflow) prepareRuntimeConfigRollback(
+	ctx context.Context,
+	req Request,
+	stagingDir string,
+	target runtimeConfigTarget,
+) runtimeConfigRollback {
+	project := req.Context.ComposeProject
+	env := req.Context.Env
+	if target == (runtimeConfigTarget{}) || (target.BindPath != "" && samePath(target.BindPath, stagingDir)) {
+		entry, err := loadRollbackEntry(req.Context.TemplatePath, project, env, "")
+		if err != nil {
+			if !errors.Is(err, ErrNoRuntimeConfigSnapshot) {
+				w.warnf("Warning: runtime config rollback unavailable: %v", err)
+			}
+			return runtimeConfigRollback{}
+		}
+		return runtimeConfigRollback{
+			snapshot: historySnapshot(entry),
+			target:   runtimeConfigTarget{BindPath: stagingDir},
+			ready:    true,
+		}
+	}
+	dir, err := os.MkdirTemp("", "esb-config-snapshot-*")
+	if err != nil {
+		w.warnf("Warning: runtime config rollback unavailable: %v", err)
+		return runtimeConfigRollback{}
+	}
+	files, err := w.captureRuntimeConfig(ctx, target, dir)
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		w.warnf("Warning: runtime config rollback unavailable: %v", err)
+		return runtimeConfigRollback{}
+	}
+	return runtimeConfigRollback{
+		snapshot: configSnapshot{
+			Files: files,
+			Dir:   dir,
+		},
+		target:  target,
+		ready:   true,
+		tempDir: dir,
+	}
+}
+
+// rollbackRuntimeConfig restores the prepared snapshot after cause and
+// returns cause. The restore runs even when ctx was cancelled or timed out.
+func (w Workflow) rollbackRuntimeConfig(ctx context.Context, rollback runtimeConfigRollback, cause error) error {
+	if !rollback.ready {
+		return cause
+	}
+	restoreCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), rollbackTimeout)
+	defer cancel()
+	if err := w.restoreRuntimeConfig(restoreCtx, rollback.snapshot, rollback.target); err != nil {
+		return fmt.Errorf("%w; runtime config rollback failed (run `%s rollback`): %w", cause, meta.AppName, err)
+	}
+	w.warnf("Restored the previous runtime config (%s)", describeSnapshotFiles(rollback.snapshot.Files))
+	return cause
+}
+
+// restoreRuntimeConfig writes snapshot to target and removes config files
+// the snapshot did not have.
+func (w Workflow) restoreRuntimeConfig(ctx context.Context, snapshot configSnapshot, target runtimeConfigTarget) error {
+	if err := w.syncRuntimeConfigToTarget(ctx, snapshot.Dir, target); err != nil {
+		return err
+	}
+	present := map[string]struct{}{}
+	for _, name := range snapshot.Files {
+		present[name] = struct{}{}
+	}
+	absent := []string{}
+	for _, name := range runtimeConfigFiles {
+		if _, ok := present[name]; !ok {
+			absent = append(absent, name)
+		}
+	}
+	if len(absent) == 0 {
+		return nil
+	}
+	return w.removeRuntimeConfigFiles(ctx, target, absent)
+}
+
+// captureRuntimeConfig copies the config files target serves into destDir
+// and returns their names.
+func (w Workflow) captureRuntimeConfig(ctx context.Context, target runtimeConfigTarget, destDir string) ([]string, error) {
+	if target.BindPath != "" {
+		return copyPresentConfigFiles(target.BindPath, destDir)
+	}
+	var containerErr error
+	if target.ContainerID != "" {
+		copyErr := captureConfigFromContainer(ctx, w.ComposeRunner, target.ContainerID, destDir)
+		if copyErr == nil {
+			return presentConfigFiles(destDir), nil
+		}
+		if ctx.Err() != nil {
+			return nil, copyErr
+		}
+		containerErr = copyErr
+	}
+	if target.VolumeName != "" {
+		volumeErr := captureConfigFromVolume(ctx, w.ComposeRunner, target.VolumeName, destDir)
+		if volumeErr == nil {
+			return presentConfigFiles(destDir), nil
+		}
+		if containerErr != nil {
+			return nil, fmt.Errorf("snapshot runtime config failed: %w", errors.Join(containerErr, volumeErr))
+		}
+		return nil, fmt.Errorf("snapshot runtime config failed: %w", volumeErr)
+	}
+	return nil, containerErr
+}
+
+func captureConfigFromContainer(ctx context.Context, runner compose.CommandRunner, containerID, destDir string) error {
+	if runner == nil {
+		return errComposeRunnerNotConfigured
+	}
+	copyDir, err := os.MkdirTemp("", "esb-config-capture-*")
+	if err != nil {
+		return fmt.Errorf("create config capture dir: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(copyDir) }()
+	src := containerID + ":" + runtimeConfigMountPath + "/."
+	if err := runner.Run(ctx, "", "docker", "cp", src, copyDir); err != nil {
+		return fmt.Errorf("copy config from container: %w", err)
+	}
+	_, err = copyPresentConfigFiles(copyDir, destDir)
+	return err
+}
+
+func captureConfigFromVolume(ctx context.Context, runner compose.CommandRunner, volume, destDir string) error {
+	if runner == nil {
+		return errComposeRunnerNotConfigured
+	}
+	cmd := "for f in " + strings.Join(runtimeConfigFiles, " ") + "; do " +
+		"if [ -f \"" + runtimeConfigMountPath + "/${f}\" ]; then cp \"" + runtimeConfigMountPath + "/${f}\" \"/dst/${f}\"; fi; " +
+		"done"
+	args := []string{
+		"run",
+		"--rm",
+		"--user", fmt.Sprintf("%d:%d", os.Getuid(), os.Getgid()),
+		"-v", volume + ":" + runtimeConfigMountPath + ":ro",
+		"-v", destDir + ":/dst",
+		"alpine",
+		"sh",
+		"-c",
+		cmd,
+	}
+	if err := runner.Run(ctx, "", "docker", args...); err != nil {
+		return fmt.Errorf("copy config from volume: %w", err)
+	}
+	return nil
+}
+
+// removeRuntimeConfigFiles deletes names from target, trying the container
+// before the volume like syncRuntimeConfigToTarget.
+func (w Workflow) removeRuntimeConfigFiles(ctx context.Context, target runtimeConfigTarget, names []string) error {
+	if target.BindPath != "" {
+		for _, name := range names {
+			if err := os.Remove(filepath.Join(target.BindPath, name)); err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("remove %s: %w", name, err)
+			}
+		}
+		return nil
+	}
+	if w.ComposeRunner == nil {
+		return errComposeRunnerNotConfigured
+	}
+	paths := make([]string, 0, len(names))
+	for _, name := range names {
+		paths = append(paths, runtimeConfigMountPath+"/"+name)
+	}
+	var containerErr error
+	if target.ContainerID != "" {
+		args := append([]string{"exec", target.ContainerID, "rm", "-f"}, paths...)
+		err := w.ComposeRunner.Run(ctx, "", "docker", args...)
+		if err == nil {
+			return nil
+		}
+		containerErr = fmt.Errorf("remove config from container: %w", err)
+	}
+	if target.VolumeName != "" {
+		args := append([]string{
+			"run", "--rm",
+			"-v", target.VolumeName + ":" + runtimeConfigMountPath,
+			"alpine", "rm", "-f",
+		}, paths...)
+		if err := w.ComposeRunner.Run(ctx, "", "docker", args...); err != nil {
+			return fmt.Errorf("remove config from volume: %w", errors.Join(containerErr, err))
+		}
+		return nil
+	}
+	return containerErr
+}
+
+// loadRollbackEntry returns the history entry id of project/env, or the
+// latest one when id is empty. path is any path inside the repository
+// (template path or repo root).
+func loadRollbackEntry(path, project, env, id string) (history.Entry, error) {
+	if strings.TrimSpace(id) != "" {
+		return history.Find(path, id, history.Filter{Project: project, Env: env})
+	}
+	entry, ok, err := history.Latest(path, project, env)
+	if err != nil {
+		return history.Entry{}, err
+	}
+	if !ok {
+		return history.Entry{}, fmt.Errorf("%w for %s", ErrNoRuntimeConfigSnapshot, staging.ComposeProjectKey(project, env))
+	}
+	return entry, nil
+}
+
+func historySnapshot(entry history.Entry) configSnapshot {
+	return configSnapshot{Files: entry.Files, Dir: entry.ConfigDir()}
+}
+
+// copyPresentConfigFiles copies the runtime config files that exist in
+// srcDir and returns their names.
+func copyPresentConfigFiles(srcDir, destDir string) ([]string, error) {
+	files := []string{}
+	for _, name := range runtimeConfigFiles {
+		src := filepath.Join(srcDir, name)
+		if _, err := os.Stat(src); err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("stat %s: %w", src, err)
+		}
+		if err := copyFile(src, filepath.Join(destDir, name)); err != nil {
+			return nil, err
+		}
+		files = append(files, name)
+	}
+	return files, nil
+}
+
+func presentConfigFiles(dir string) []string {
+	files := []string{}
+	for _, name := range runtimeConfigFiles {
+		if _, err := os.Stat(filepath.Join(dir, name)); err == nil {
+			files = append(files, name)
+		}
+	}
+	return files
+}
+
+func describeSnapshotFiles(files []string) string {
+	if len(files) == 0 {
+		return "no config files"
+	}
+	return strings.Join(files, ", ")
+}
+
+func (w Workflow) warnf(format string, args ...any) {
+	if w.UserInterface != nil {
+		w.UserInterface.Warn(fmt.Sprintf(format, args...))
+	}
+}
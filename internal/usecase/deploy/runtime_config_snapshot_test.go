@@ -0,0 +1,226 @@
+// Where: cli/internal/usecase/deploy/runtime_config_snapshot_test.go
+// What: Unit tests for runtime config snapshots and rollback.
+// Why: A failed provisioner must leave the gateway on the previous config.
+package deploy
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/poruru-code/esb-cli/internal/domain/state"
+	"github.com/poruru-code/esb-cli/internal/infra/compose"
+	"github.com/poruru-code/esb-cli/internal/infra/history"
+	"github.com/poruru-code/esb-cli/internal/infra/staging"
+)
+
+func bindMountDockerClient(source string) DockerClientFactory {
+	return func() (compose.DockerClient, error) {
+		return runtimeConfigDockerClient{
+			containers: []container.Summary{
+				{
+					ID:     "gateway-1",
+					State:  "running",
+					Labels: map[string]string{compose.ComposeServiceLabel: "gateway"},
+					Mounts: []container.MountPoint{
+						{Destination: runtimeConfigMountPath, Type: "bind", Source: source},
+					},
+				},
+			},
+		}, nil
+	}
+}
+
+func snapshotTestRequest(t *testing.T) (Request, string) {
+	t.Helper()
+	t.Setenv("ENV_PREFIX", "")
+	repoRoot := newTestRepoRoot(t)
+	templatePath := filepath.Join(repoRoot, "template.yaml")
+	stagingDir, err := staging.ConfigDir(templatePath, "esb-dev", "dev")
+	if err != nil {
+		t.Fatalf("resolve config dir: %v", err)
+	}
+	return Request{
+		Context: state.Context{
+			ProjectDir:     repoRoot,
+			ComposeProject: "esb-dev",
+			TemplatePath:   templatePath,
+			Env:            "dev",
+			Mode:           "docker",
+		},
+		ArtifactPath: writeTestArtifactManifest(t),
+	}, stagingDir
+}
+
+func readConfigFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read %s: %v", path, err)
+	}
+	return string(data)
+}
+
+func TestRunRuntimeProvisionPhaseRestoresTargetWhenProvisionerFails(t *testing.T) {
+	req, stagingDir := snapshotTestRequest(t)
+	gatewayDir := t.TempDir()
+	writeRuntimeConfigFile(t, filepath.Join(gatewayDir, "functions.yml"), "functions: {old: {}}\n")
+
+	ui := &testUI{}
+	provisioner := &spyProvisioner{
+		runFn: func(_ string, _ string, _ bool, _ bool, _ string, _ []string) error {
+			if got := readConfigFile(t, filepath.Join(gatewayDir, "functions.yml")); got != "functions: {}\n" {
+				t.Fatalf("provisioner must see the synced config, got %q", got)
+			}
+			return errors.New("provisioner exited 1")
+		},
+	}
+	workflow := Workflow{
+		UserInterface:      ui,
+		ComposeRunner:      &fakeComposeRunner{},
+		ComposeProvisioner: provisioner,
+		DockerClient:       bindMountDockerClient(gatewayDir),
+	}
+
+	err := workflow.runRuntimeProvisionPhase(req, stagingDir)
+	if err == nil || !strings.Contains(err.Error(), "provisioner failed") {
+		t.Fatalf("expected provisioner error, got %v", err)
+	}
+	if got := readConfigFile(t, filepath.Join(gatewayDir, "functions.yml")); got != "functions: {old: {}}\n" {
+		t.Fatalf("functions.yml not restored: %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(gatewayDir, "routing.yml")); !os.IsNotExist(err) {
+		t.Fatalf("routing.yml did not exist before the sync and must be removed: %v", err)
+	}
+	if len(ui.warn) == 0 || !strings.Contains(ui.warn[len(ui.warn)-1], "Restored the previous runtime config") {
+		t.Fatalf("expected rollback notice, got %#v", ui.warn)
+	}
+}
+
+// applyAndRecord runs a successful apply and records it like Apply does.
+func applyAndRecord(t *testing.T, workflow Workflow, req Request, stagingDir string) history.Entry {
+	t.Helper()
+	if err := workflow.runRuntimeProvisionPhase(req, stagingDir); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	workflow.recordHistory(req, history.KindApply)
+	entry, ok, err := history.Latest(req.Context.TemplatePath, "esb-dev", "dev")
+	if err != nil || !ok {
+		t.Fatalf("history not recorded: %v", err)
+	}
+	return entry
+}
+
+func TestRunRuntimeProvisionPhaseFallsBackToLatestHistoryEntry(t *testing.T) {
+	req, stagingDir := snapshotTestRequest(t)
+	provisioner := &spyProvisioner{}
+	workflow := Workflow{
+		UserInterface:      &testUI{},
+		ComposeRunner:      &fakeComposeRunner{},
+		ComposeProvisioner: provisioner,
+	}
+	entry := applyAndRecord(t, workflow, req, stagingDir)
+	if !reflect.DeepEqual(entry.Files, []string{"functions.yml", "routing.yml"}) {
+		t.Fatalf("unexpected history files: %v", entry.Files)
+	}
+
+	// The runtime reads the staging dir; a failed apply restores the
+	// latest history entry there.
+	provisioner.runFn = func(_ string, _ string, _ bool, _ bool, _ string, _ []string) error {
+		writeRuntimeConfigFile(t, filepath.Join(stagingDir, "functions.yml"), "functions: {broken: {}}\n")
+		writeRuntimeConfigFile(t, filepath.Join(stagingDir, "resources.yml"), "resources: {}\n")
+		return errors.New("provisioner exited 1")
+	}
+	if err := workflow.runRuntimeProvisionPhase(req, stagingDir); err == nil {
+		t.Fatal("expected provisioner error")
+	}
+	if got := readConfigFile(t, filepath.Join(stagingDir, "functions.yml")); got != "functions: {}\n" {
+		t.Fatalf("functions.yml not restored: %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(stagingDir, "resources.yml")); !os.IsNotExist(err) {
+		t.Fatalf("resources.yml must be removed: %v", err)
+	}
+}
+
+func TestWorkflowRollbackRestoresHistoryEntry(t *testing.T) {
+	req, stagingDir := snapshotTestRequest(t)
+	workflow := Workflow{
+		UserInterface:      &testUI{},
+		ComposeRunner:      &fakeComposeRunner{},
+		ComposeProvisioner: &spyProvisioner{},
+	}
+	rollbackReq := RollbackRequest{
+		Ctx:      context.Background(),
+		RepoRoot: req.Context.ProjectDir,
+		Project:  "esb-dev",
+		Env:      "dev",
+	}
+	if _, err := workflow.Rollback(rollbackReq); !errors.Is(err, ErrNoRuntimeConfigSnapshot) {
+		t.Fatalf("expected missing snapshot error, got %v", err)
+	}
+	first := applyAndRecord(t, workflow, req, stagingDir)
+	writeRuntimeConfigFile(t, filepath.Join(stagingDir, "functions.yml"), "functions: {second: {}}\n")
+	workflow.recordHistory(req, history.KindApply)
+
+	gatewayDir := t.TempDir()
+	writeRuntimeConfigFile(t, filepath.Join(gatewayDir, "functions.yml"), "functions: {manual: {}}\n")
+	workflow.DockerClient = bindMountDockerClient(gatewayDir)
+	restored, err := workflow.Rollback(rollbackReq)
+	if err != nil {
+		t.Fatalf("rollback: %v", err)
+	}
+	if restored.ID == first.ID {
+		t.Fatalf("rollback without --to must restore the latest entry, got %s", restored.ID)
+	}
+	if got := readConfigFile(t, filepath.Join(gatewayDir, "functions.yml")); got != "functions: {second: {}}\n" {
+		t.Fatalf("functions.yml not restored: %q", got)
+	}
+
+	rollbackReq.ID = first.ID
+	restored, err = workflow.Rollback(rollbackReq)
+	if err != nil {
+		t.Fatalf("rollback --to: %v", err)
+	}
+	if restored.ID != first.ID {
+		t.Fatalf("expected entry %s, got %s", first.ID, restored.ID)
+	}
+	if got := readConfigFile(t, filepath.Join(gatewayDir, "functions.yml")); got != "functions: {}\n" {
+		t.Fatalf("functions.yml not restored: %q", got)
+	}
+	if got := readConfigFile(t, filepath.Join(gatewayDir, "routing.yml")); got != "routes: []\n" {
+		t.Fatalf("routing.yml not restored: %q", got)
+	}
+	latest, ok, err := history.Latest(req.Context.TemplatePath, "esb-dev", "dev")
+	if err != nil || !ok || latest.Kind != history.KindRollback || latest.RolledBackTo != first.ID {
+		t.Fatalf("rollback must be recorded as the latest entry: %#v (%v)", latest, err)
+	}
+
+	rollbackReq.ID = "unknown"
+	if _, err := workflow.Rollback(rollbackReq); !errors.Is(err, history.ErrEntryNotFound) {
+		t.Fatalf("expected unknown entry error, got %v", err)
+	}
+}
+
+func TestCaptureRuntimeConfigCopiesFromContainer(t *testing.T) {
+	var calls [][]string
+	runner := &runtimeConfigRunner{runFunc: func(args ...string) error {
+		calls = append(calls, args)
+		return nil
+	}}
+	workflow := Workflow{ComposeRunner: runner}
+	files, err := workflow.captureRuntimeConfig(context.Background(), runtimeConfigTarget{ContainerID: "ctr-1"}, t.TempDir())
+	if err != nil {
+		t.Fatalf("capture: %v", err)
+	}
+	if len(files) != 0 {
+		t.Fatalf("expected no files from an empty copy, got %v", files)
+	}
+	if len(calls) != 1 || calls[0][0] != "cp" || calls[0][1] != "ctr-1:"+runtimeConfigMountPath+"/." {
+		t.Fatalf("unexpected docker calls: %v", calls)
+	}
+}
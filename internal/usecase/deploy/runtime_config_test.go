@@ -36,7 +36,7 @@ func TestSyncRuntimeConfigToTarget_ContainerCopyFailureIsReturned(t *testing.T)
 	}
 	workflow := Workflow{ComposeRunner: runner}
 
-	err := workflow.syncRuntimeConfigToTarget(stagingDir, runtimeConfigTarget{ContainerID: "ctr-1"})
+	err := workflow.syncRuntimeConfigToTarget(context.Background(), stagingDir, runtimeConfigTarget{ContainerID: "ctr-1"})
 	if err == nil {
 		t.Fatal("expected sync error, got nil")
 	}
@@ -56,7 +56,7 @@ func TestSyncRuntimeConfigFromDirSkipsWhenComposeProjectEmpty(t *testing.T) {
 			return runtimeConfigDockerClient{}, nil
 		},
 	}
-	if err := workflow.syncRuntimeConfigFromDir("", t.TempDir()); err != nil {
+	if err := workflow.syncRuntimeConfigFromDir(context.Background(), "", t.TempDir()); err != nil {
 		t.Fatalf("syncRuntimeConfigFromDir() error = %v, want nil", err)
 	}
 	if called {
@@ -66,7 +66,7 @@ func TestSyncRuntimeConfigFromDirSkipsWhenComposeProjectEmpty(t *testing.T) {
 
 func TestSyncRuntimeConfigFromDirRequiresStagingDir(t *testing.T) {
 	workflow := Workflow{}
-	err := workflow.syncRuntimeConfigFromDir("esb-dev", "")
+	err := workflow.syncRuntimeConfigFromDir(context.Background(), "esb-dev", "")
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -78,7 +78,7 @@ func TestSyncRuntimeConfigFromDirRequiresStagingDir(t *testing.T) {
 func TestSyncRuntimeConfigFromDirSkipsWhenStagingDirMissing(t *testing.T) {
 	workflow := Workflow{}
 	missing := filepath.Join(t.TempDir(), "missing")
-	if err := workflow.syncRuntimeConfigFromDir("esb-dev", missing); err != nil {
+	if err := workflow.syncRuntimeConfigFromDir(context.Background(), "esb-dev", missing); err != nil {
 		t.Fatalf("syncRuntimeConfigFromDir() error = %v, want nil", err)
 	}
 }
@@ -90,7 +90,7 @@ func TestSyncRuntimeConfigFromDirPropagatesResolveTargetError(t *testing.T) {
 		},
 	}
 
-	err := workflow.syncRuntimeConfigFromDir("esb-dev", t.TempDir())
+	err := workflow.syncRuntimeConfigFromDir(context.Background(), "esb-dev", t.TempDir())
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -136,7 +136,7 @@ func TestSyncRuntimeConfigFromDirCopiesToResolvedBindPath(t *testing.T) {
 		},
 	}
 
-	if err := workflow.syncRuntimeConfigFromDir("esb-dev", stagingDir); err != nil {
+	if err := workflow.syncRuntimeConfigFromDir(context.Background(), "esb-dev", stagingDir); err != nil {
 		t.Fatalf("syncRuntimeConfigFromDir() error = %v", err)
 	}
 
@@ -173,6 +173,7 @@ func TestSyncRuntimeConfigToTarget_JoinsContainerAndVolumeErrors(t *testing.T) {
 	workflow := Workflow{ComposeRunner: runner}
 
 	err := workflow.syncRuntimeConfigToTarget(
+		context.Background(),
 		stagingDir,
 		runtimeConfigTarget{
 			ContainerID: "ctr-1",
@@ -193,7 +194,7 @@ func TestSyncRuntimeConfigToTarget_JoinsContainerAndVolumeErrors(t *testing.T) {
 
 func TestResolveRuntimeConfigTargetSkipsWhenDockerClientNotConfigured(t *testing.T) {
 	workflow := Workflow{}
-	target, err := workflow.resolveRuntimeConfigTarget("esb-dev")
+	target, err := workflow.resolveRuntimeConfigTarget(context.Background(), "esb-dev")
 	if err != nil {
 		t.Fatalf("resolve runtime config target: %v", err)
 	}
@@ -285,7 +286,7 @@ func TestResolveRuntimeConfigTargetUsesDeterministicContainerSelection(t *testin
 		},
 	}
 
-	target, err := workflow.resolveRuntimeConfigTarget("esb-dev")
+	target, err := workflow.resolveRuntimeConfigTarget(context.Background(), "esb-dev")
 	if err != nil {
 		t.Fatalf("resolve runtime config target: %v", err)
 	}
@@ -328,7 +329,7 @@ func TestResolveRuntimeConfigTargetSkipsContainersWithoutRuntimeConfigMount(t *t
 		},
 	}
 
-	target, err := workflow.resolveRuntimeConfigTarget("esb-dev")
+	target, err := workflow.resolveRuntimeConfigTarget(context.Background(), "esb-dev")
 	if err != nil {
 		t.Fatalf("resolve runtime config target: %v", err)
 	}
@@ -357,7 +358,7 @@ func TestResolveRuntimeConfigTargetReturnsEmptyWhenNoRuntimeConfigMountExists(t
 		},
 	}
 
-	target, err := workflow.resolveRuntimeConfigTarget("esb-dev")
+	target, err := workflow.resolveRuntimeConfigTarget(context.Background(), "esb-dev")
 	if err != nil {
 		t.Fatalf("resolve runtime config target: %v", err)
 	}
@@ -382,7 +383,7 @@ func TestSyncRuntimeConfigToTargetBindPathCopiesConfigFiles(t *testing.T) {
 	}
 
 	workflow := Workflow{}
-	if err := workflow.syncRuntimeConfigToTarget(srcDir, runtimeConfigTarget{BindPath: destDir}); err != nil {
+	if err := workflow.syncRuntimeConfigToTarget(context.Background(), srcDir, runtimeConfigTarget{BindPath: destDir}); err != nil {
 		t.Fatalf("sync runtime config: %v", err)
 	}
 
@@ -397,6 +398,81 @@ func TestSyncRuntimeConfigToTargetBindPathCopiesConfigFiles(t *testing.T) {
 	}
 }
 
+func TestCopyConfigFilesRestoresReplacedFilesOnFailure(t *testing.T) {
+	srcDir := t.TempDir()
+	destDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(srcDir, "functions.yml"), []byte("new"), 0o644); err != nil {
+		t.Fatalf("write src functions.yml: %v", err)
+	}
+	// A directory named routing.yml makes the second copy fail.
+	if err := os.Mkdir(filepath.Join(srcDir, "routing.yml"), 0o755); err != nil {
+		t.Fatalf("mkdir src routing.yml: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(destDir, "functions.yml"), []byte("old"), 0o644); err != nil {
+		t.Fatalf("write dest functions.yml: %v", err)
+	}
+
+	if err := copyConfigFiles(context.Background(), srcDir, destDir); err == nil {
+		t.Fatal("expected copy error, got nil")
+	}
+	got, err := os.ReadFile(filepath.Join(destDir, "functions.yml"))
+	if err != nil {
+		t.Fatalf("read dest functions.yml: %v", err)
+	}
+	if string(got) != "old" {
+		t.Fatalf("expected functions.yml to be restored, got %q", string(got))
+	}
+}
+
+func TestCopyConfigFilesStopsWhenCancelled(t *testing.T) {
+	srcDir := t.TempDir()
+	destDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(srcDir, "functions.yml"), []byte("new"), 0o644); err != nil {
+		t.Fatalf("write src functions.yml: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := copyConfigFiles(ctx, srcDir, destDir)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(destDir, "functions.yml")); !os.IsNotExist(err) {
+		t.Fatalf("expected no config to be written, stat err = %v", err)
+	}
+}
+
+func TestSyncRuntimeConfigToTargetCopiesContainerConfigInOneCommand(t *testing.T) {
+	stagingDir := t.TempDir()
+	for _, name := range []string{"functions.yml", "routing.yml"} {
+		if err := os.WriteFile(filepath.Join(stagingDir, name), []byte(name), 0o644); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	var calls [][]string
+	runner := &runtimeConfigRunner{
+		runFunc: func(args ...string) error {
+			calls = append(calls, append([]string{}, args...))
+			return nil
+		},
+	}
+	workflow := Workflow{ComposeRunner: runner}
+
+	if err := workflow.syncRuntimeConfigToTarget(
+		context.Background(),
+		stagingDir,
+		runtimeConfigTarget{ContainerID: "ctr-1"},
+	); err != nil {
+		t.Fatalf("sync runtime config: %v", err)
+	}
+	if len(calls) != 1 {
+		t.Fatalf("expected one docker cp, got %v", calls)
+	}
+	if calls[0][0] != "cp" || calls[0][2] != "ctr-1:"+runtimeConfigMountPath {
+		t.Fatalf("unexpected docker cp args: %v", calls[0])
+	}
+}
+
 func TestSamePath(t *testing.T) {
 	base := t.TempDir()
 	left := filepath.Join(base, ".", "runtime-config")
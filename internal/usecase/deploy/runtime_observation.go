@@ -4,7 +4,6 @@
 package deploy
 
 import (
-	"context"
 	"fmt"
 	"strings"
 
@@ -35,7 +34,7 @@ func (w Workflow) resolveRuntimeObservation(req Request) (*artifactcore.RuntimeO
 
 	filterArgs := filters.NewArgs()
 	filterArgs.Add("label", fmt.Sprintf("%s=%s", compose.ComposeProjectLabel, project))
-	containers, err := client.ContainerList(context.Background(), container.ListOptions{
+	containers, err := client.ContainerList(req.requestContext(), container.ListOptions{
 		All:     false,
 		Filters: filterArgs,
 	})
@@ -0,0 +1,133 @@
+// Where: cli/internal/usecase/deploy/watch.go
+// What: Watch-mode helpers mapping source edits to functions and hot resync.
+// Why: Rebuild only the functions a change touches instead of the whole template.
+package deploy
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/poruru-code/esb-cli/internal/infra/templategen"
+)
+
+// WatchTarget is a watched path and the functions built from it.
+// Template targets trigger a full rebuild of their template.
+type WatchTarget struct {
+	Path      string
+	Functions []string
+	Template  bool
+}
+
+// WatchTargets parses templatePath and returns the template file plus every
+// local CodeUri, Metadata DockerContext and layer ContentUri, each tagged with
+// the owning functions.
+func WatchTargets(templatePath string, parameters map[string]string) ([]WatchTarget, error) {
+	absTemplate, functions, err := parseTemplateFunctions(templatePath, parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	baseDir := filepath.Dir(absTemplate)
+	owners := map[string]map[string]struct{}{}
+	addOwner := func(raw, function string) {
+		if strings.TrimSpace(raw) == "" || strings.Contains(raw, "://") {
+			return
+		}
+		path := templategen.ResolveResourcePath(baseDir, raw)
+		if owners[path] == nil {
+			owners[path] = map[string]struct{}{}
+		}
+		owners[path][function] = struct{}{}
+	}
+	for _, fn := range functions {
+		if fn.ImageBuild != nil {
+			addOwner(fn.ImageBuild.Context, fn.Name)
+			continue
+		}
+		if strings.TrimSpace(fn.ImageSource) != "" {
+			continue
+		}
+		addOwner(fn.CodeURI, fn.Name)
+		for _, layer := range fn.Layers {
+			addOwner(layer.ContentURI, fn.Name)
+		}
+	}
+
+	targets := []WatchTarget{{Path: absTemplate, Template: true}}
+	paths := make([]string, 0, len(owners))
+	for path := range owners {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		functions := make([]string, 0, len(owners[path]))
+		for name := range owners[path] {
+			functions = append(functions, name)
+		}
+		sort.Strings(functions)
+		targets = append(targets, WatchTarget{Path: path, Functions: functions})
+	}
+	return targets, nil
+}
+
+// AffectedFunctions maps changed file paths onto targets. templateChanged is
+// true when a template target changed, in which case functions is nil.
+func AffectedFunctions(targets []WatchTarget, changed []string) (functions []string, templateChanged bool) {
+	selected := map[string]struct{}{}
+	for _, path := range changed {
+		for _, target := range targets {
+			if !pathWithin(path, target.Path) {
+				continue
+			}
+			if target.Template {
+				return nil, true
+			}
+			for _, name := range target.Functions {
+				selected[name] = struct{}{}
+			}
+		}
+	}
+	functions = make([]string, 0, len(selected))
+	for name := range selected {
+		functions = append(functions, name)
+	}
+	sort.Strings(functions)
+	return functions, false
+}
+
+// Resync applies the artifact manifest to the staging config and copies it
+// into the running stack without re-running the provisioner.
+func (w Workflow) Resync(req Request) error {
+	if w.ComposeRunner == nil {
+		return errComposeRunnerNotConfigured
+	}
+	req = w.alignGatewayRuntime(req)
+	req, err := normalizeApplyRequest(req)
+	if err != nil {
+		return err
+	}
+	stagingDir, err := resolveApplyConfigDir(req.Context)
+	if err != nil {
+		return err
+	}
+	if err := setConfigDirEnv(stagingDir); err != nil {
+		return err
+	}
+	if err := w.applyArtifactRuntimeConfig(req, stagingDir); err != nil {
+		return err
+	}
+	if err := w.syncRuntimeConfigFromDir(req.requestContext(), req.Context.ComposeProject, stagingDir); err != nil {
+		return err
+	}
+	if w.UserInterface != nil {
+		w.UserInterface.Success("Runtime config synced")
+	}
+	return nil
+}
+
+func pathWithin(path, root string) bool {
+	path = filepath.Clean(path)
+	root = filepath.Clean(root)
+	return path == root || strings.HasPrefix(path, root+string(filepath.Separator))
+}
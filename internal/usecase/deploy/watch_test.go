@@ -0,0 +1,88 @@
+package deploy
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestWatchTargetsMapsCodeAndLayerPathsToFunctions(t *testing.T) {
+	root := t.TempDir()
+	templatePath := filepath.Join(root, "template.yaml")
+	writeRuntimeConfigFile(t, templatePath, `
+AWSTemplateFormatVersion: '2010-09-09'
+Transform: AWS::Serverless-2016-10-31
+Resources:
+  CommonLayer:
+    Type: AWS::Serverless::LayerVersion
+    Properties:
+      LayerName: common
+      ContentUri: layers/common/
+  HelloFunction:
+    Type: AWS::Serverless::Function
+    Properties:
+      FunctionName: lambda-hello
+      CodeUri: functions/hello/
+      Handler: app.handler
+      Runtime: python3.12
+      Layers:
+        - !Ref CommonLayer
+  WorldFunction:
+    Type: AWS::Serverless::Function
+    Properties:
+      FunctionName: lambda-world
+      CodeUri: functions/world/
+      Handler: app.handler
+      Runtime: python3.12
+      Layers:
+        - !Ref CommonLayer
+`)
+	for _, dir := range []string{"functions/hello", "functions/world", "layers/common"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	targets, err := WatchTargets(templatePath, nil)
+	if err != nil {
+		t.Fatalf("WatchTargets: %v", err)
+	}
+	want := []WatchTarget{
+		{Path: templatePath, Template: true},
+		{Path: filepath.Join(root, "functions", "hello"), Functions: []string{"lambda-hello"}},
+		{Path: filepath.Join(root, "functions", "world"), Functions: []string{"lambda-world"}},
+		{Path: filepath.Join(root, "layers", "common"), Functions: []string{"lambda-hello", "lambda-world"}},
+	}
+	if !reflect.DeepEqual(targets, want) {
+		t.Fatalf("unexpected targets:\n got %#v\nwant %#v", targets, want)
+	}
+
+	functions, templateChanged := AffectedFunctions(targets, []string{
+		filepath.Join(root, "functions", "hello", "app.py"),
+	})
+	if templateChanged || !reflect.DeepEqual(functions, []string{"lambda-hello"}) {
+		t.Fatalf("unexpected code change mapping: %v %v", functions, templateChanged)
+	}
+	functions, _ = AffectedFunctions(targets, []string{
+		filepath.Join(root, "layers", "common", "util.py"),
+	})
+	if !reflect.DeepEqual(functions, []string{"lambda-hello", "lambda-world"}) {
+		t.Fatalf("unexpected layer change mapping: %v", functions)
+	}
+	functions, _ = AffectedFunctions(targets, []string{
+		filepath.Join(root, "functions", "hello-extra", "app.py"),
+	})
+	if len(functions) != 0 {
+		t.Fatalf("sibling prefix should not match: %v", functions)
+	}
+	if _, templateChanged := AffectedFunctions(targets, []string{templatePath}); !templateChanged {
+		t.Fatalf("expected template change")
+	}
+}
+
+func TestResyncRequiresComposeRunner(t *testing.T) {
+	if err := (Workflow{}).Resync(Request{}); err == nil {
+		t.Fatalf("expected error without compose runner")
+	}
+}
@@ -0,0 +1,58 @@
+// Where: cli/internal/usecase/invoke/gateway.go
+// What: Gateway endpoint discovery for invoke.
+// Why: Find the published gateway port without reading compose files by hand.
+package invoke
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/poruru-code/esb-cli/internal/constants"
+	"github.com/poruru-code/esb-cli/internal/infra/compose"
+)
+
+var errGatewayNotFound = errors.New("running gateway not found (deploy first or pass --url)")
+
+// resolveGatewayURL picks the gateway endpoint in priority order:
+// explicit URL, GATEWAY_URL, discovered gateway port, PORT_GATEWAY_HTTPS.
+func (w Workflow) resolveGatewayURL(req Request) (string, error) {
+	if value := strings.TrimSpace(req.GatewayURL); value != "" {
+		return value, nil
+	}
+	if value := strings.TrimSpace(os.Getenv(constants.EnvGatewayURL)); value != "" {
+		return value, nil
+	}
+	port, err := w.discoverGatewayPort(req.ComposeProject)
+	if err != nil {
+		return "", err
+	}
+	if port == 0 {
+		if value, convErr := strconv.Atoi(strings.TrimSpace(os.Getenv(constants.EnvPortGatewayHTTPS))); convErr == nil && value > 0 {
+			port = value
+		}
+	}
+	if port == 0 {
+		return "", errGatewayNotFound
+	}
+	return fmt.Sprintf("https://localhost:%d", port), nil
+}
+
+// discoverGatewayPort selects the gateway like deploy alignment does and
+// returns the host port published for the gateway HTTPS port mapping.
+func (w Workflow) discoverGatewayPort(composeProject string) (int, error) {
+	if w.DockerClient == nil {
+		return 0, nil
+	}
+	client, err := w.DockerClient()
+	if err != nil {
+		return 0, fmt.Errorf("create docker client: %w", err)
+	}
+	if client == nil {
+		return 0, nil
+	}
+	return compose.DiscoverServicePort(context.Background(), client, composeProject, constants.EnvPortGatewayHTTPS)
+}
@@ -0,0 +1,150 @@
+// Where: cli/internal/usecase/invoke/invoke.go
+// What: Local function invocation through the running gateway.
+// Why: Invoke deployed functions without hand-crafting gateway requests.
+package invoke
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/poruru-code/esb-cli/internal/infra/compose"
+)
+
+var (
+	errFunctionRequired        = errors.New("function name is required")
+	errHTTPClientNotConfigured = errors.New("http client is not configured")
+)
+
+const (
+	headerLogType       = "X-Amz-Log-Type"
+	headerLogResult     = "X-Amz-Log-Result"
+	headerFunctionError = "X-Amz-Function-Error"
+)
+
+// DockerClientFactory constructs Docker SDK clients used for gateway discovery.
+type DockerClientFactory func() (compose.DockerClient, error)
+
+// HTTPDoer sends invocation requests to the gateway.
+type HTTPDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Request captures the inputs required to invoke a function.
+type Request struct {
+	Function       string
+	Payload        []byte
+	ComposeProject string
+	// GatewayURL skips discovery when set.
+	GatewayURL string
+	Timeout    time.Duration
+	// NoLogs disables the log tail request.
+	NoLogs bool
+}
+
+// Result describes a completed invocation.
+type Result struct {
+	Function      string
+	GatewayURL    string
+	StatusCode    int
+	FunctionError string
+	Payload       []byte
+	Logs          string
+	Duration      time.Duration
+}
+
+// Failed reports whether the invocation or the function itself failed.
+func (r Result) Failed() bool {
+	return r.StatusCode >= http.StatusBadRequest || r.FunctionError != ""
+}
+
+// Workflow executes invocations against the gateway.
+type Workflow struct {
+	DockerClient DockerClientFactory
+	HTTPClient   HTTPDoer
+	Now          func() time.Time
+}
+
+// Run resolves the gateway and invokes req.Function with req.Payload.
+func (w Workflow) Run(req Request) (Result, error) {
+	function := strings.TrimSpace(req.Function)
+	if function == "" {
+		return Result{}, errFunctionRequired
+	}
+	if w.HTTPClient == nil {
+		return Result{}, errHTTPClientNotConfigured
+	}
+	gatewayURL, err := w.resolveGatewayURL(req)
+	if err != nil {
+		return Result{}, err
+	}
+
+	ctx := context.Background()
+	if req.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, req.Timeout)
+		defer cancel()
+	}
+	endpoint := strings.TrimRight(gatewayURL, "/") +
+		"/2015-03-31/functions/" + url.PathEscape(function) + "/invocations"
+	payload := req.Payload
+	if len(bytes.TrimSpace(payload)) == 0 {
+		payload = []byte("{}")
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return Result{}, fmt.Errorf("build invoke request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	if !req.NoLogs {
+		httpReq.Header.Set(headerLogType, "Tail")
+	}
+
+	now := w.now()
+	start := now()
+	resp, err := w.HTTPClient.Do(httpReq)
+	if err != nil {
+		return Result{}, fmt.Errorf("invoke %s: %w", function, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Result{}, fmt.Errorf("read invoke response: %w", err)
+	}
+
+	return Result{
+		Function:      function,
+		GatewayURL:    gatewayURL,
+		StatusCode:    resp.StatusCode,
+		FunctionError: strings.TrimSpace(resp.Header.Get(headerFunctionError)),
+		Payload:       body,
+		Logs:          decodeLogTail(resp.Header.Get(headerLogResult)),
+		Duration:      now().Sub(start),
+	}, nil
+}
+
+func (w Workflow) now() func() time.Time {
+	if w.Now != nil {
+		return w.Now
+	}
+	return time.Now
+}
+
+func decodeLogTail(raw string) string {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" {
+		return ""
+	}
+	decoded, err := base64.StdEncoding.DecodeString(trimmed)
+	if err != nil {
+		return trimmed
+	}
+	return strings.TrimRight(string(decoded), "\n")
+}
@@ -0,0 +1,171 @@
+// Where: cli/internal/usecase/invoke/invoke_test.go
+// What: Tests for gateway discovery and invocation.
+// Why: Keep the invoke request/response contract and port discovery stable.
+package invoke
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/poruru-code/esb-cli/internal/constants"
+	"github.com/poruru-code/esb-cli/internal/infra/compose"
+)
+
+type invokeDockerClient struct {
+	containers []container.Summary
+}
+
+func (c *invokeDockerClient) ContainerList(_ context.Context, opts container.ListOptions) ([]container.Summary, error) {
+	out := []container.Summary{}
+	for _, ctr := range c.containers {
+		matched := true
+		for _, label := range opts.Filters.Get("label") {
+			key, value, _ := strings.Cut(label, "=")
+			if ctr.Labels[key] != value {
+				matched = false
+			}
+		}
+		if matched {
+			out = append(out, ctr)
+		}
+	}
+	return out, nil
+}
+
+func (c *invokeDockerClient) ContainerInspect(context.Context, string) (container.InspectResponse, error) {
+	return container.InspectResponse{}, nil
+}
+
+func (c *invokeDockerClient) ImageList(context.Context, image.ListOptions) ([]image.Summary, error) {
+	return nil, nil
+}
+
+func (c *invokeDockerClient) ContainerStop(context.Context, string, container.StopOptions) error {
+	return nil
+}
+
+func (c *invokeDockerClient) ContainerRemove(context.Context, string, container.RemoveOptions) error {
+	return nil
+}
+
+func (c *invokeDockerClient) ContainersPrune(context.Context, filters.Args) (container.PruneReport, error) {
+	return container.PruneReport{}, nil
+}
+
+func (c *invokeDockerClient) ImagesPrune(context.Context, filters.Args) (image.PruneReport, error) {
+	return image.PruneReport{}, nil
+}
+
+func (c *invokeDockerClient) NetworksPrune(context.Context, filters.Args) (network.PruneReport, error) {
+	return network.PruneReport{}, nil
+}
+
+func (c *invokeDockerClient) VolumesPrune(context.Context, filters.Args) (volume.PruneReport, error) {
+	return volume.PruneReport{}, nil
+}
+
+func TestDiscoverGatewayPortUsesPublishedGatewayPort(t *testing.T) {
+	client := &invokeDockerClient{containers: []container.Summary{
+		{
+			Names:  []string{"/esb-dev-gateway-1"},
+			State:  "running",
+			Labels: map[string]string{compose.ComposeServiceLabel: "gateway", compose.ComposeProjectLabel: "esb-dev"},
+			Ports:  []container.Port{{PrivatePort: 8443, PublicPort: 10443}},
+		},
+		{
+			Names:  []string{"/esb-dev-database-1"},
+			State:  "running",
+			Labels: map[string]string{compose.ComposeServiceLabel: "database", compose.ComposeProjectLabel: "esb-dev"},
+			Ports:  []container.Port{{PrivatePort: 8000, PublicPort: 18000}},
+		},
+	}}
+	workflow := Workflow{DockerClient: func() (compose.DockerClient, error) { return client, nil }}
+
+	got, err := workflow.resolveGatewayURL(Request{ComposeProject: "esb-dev"})
+	if err != nil {
+		t.Fatalf("resolve gateway url: %v", err)
+	}
+	if got != "https://localhost:10443" {
+		t.Fatalf("unexpected gateway url: %s", got)
+	}
+}
+
+func TestResolveGatewayURLFallsBackToPortEnv(t *testing.T) {
+	t.Setenv(constants.EnvGatewayURL, "")
+	t.Setenv(constants.EnvPortGatewayHTTPS, "20443")
+	workflow := Workflow{DockerClient: func() (compose.DockerClient, error) { return &invokeDockerClient{}, nil }}
+
+	got, err := workflow.resolveGatewayURL(Request{})
+	if err != nil {
+		t.Fatalf("resolve gateway url: %v", err)
+	}
+	if got != "https://localhost:20443" {
+		t.Fatalf("unexpected gateway url: %s", got)
+	}
+}
+
+func TestResolveGatewayURLReportsMissingGateway(t *testing.T) {
+	t.Setenv(constants.EnvGatewayURL, "")
+	t.Setenv(constants.EnvPortGatewayHTTPS, "")
+	if _, err := (Workflow{}).resolveGatewayURL(Request{}); err != errGatewayNotFound {
+		t.Fatalf("expected errGatewayNotFound, got %v", err)
+	}
+}
+
+func TestRunInvokesFunctionAndDecodesLogTail(t *testing.T) {
+	var gotPath, gotLogType, gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotLogType = r.Header.Get(headerLogType)
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		w.Header().Set(headerLogResult, base64.StdEncoding.EncodeToString([]byte("START\nEND\n")))
+		w.Header().Set(headerFunctionError, "Unhandled")
+		_, _ = w.Write([]byte(`{"errorMessage":"boom"}`))
+	}))
+	defer server.Close()
+
+	ticks := []time.Time{time.Unix(0, 0), time.Unix(0, int64(250*time.Millisecond))}
+	workflow := Workflow{
+		HTTPClient: server.Client(),
+		Now: func() time.Time {
+			next := ticks[0]
+			ticks = ticks[1:]
+			return next
+		},
+	}
+	result, err := workflow.Run(Request{
+		Function:   "hello",
+		Payload:    []byte(`{"key":"value"}`),
+		GatewayURL: server.URL,
+	})
+	if err != nil {
+		t.Fatalf("run invoke: %v", err)
+	}
+	if gotPath != "/2015-03-31/functions/hello/invocations" || gotLogType != "Tail" || gotBody != `{"key":"value"}` {
+		t.Fatalf("unexpected request: path=%s logType=%s body=%s", gotPath, gotLogType, gotBody)
+	}
+	if result.Logs != "START\nEND" || result.FunctionError != "Unhandled" || !result.Failed() {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if result.Duration != 250*time.Millisecond {
+		t.Fatalf("unexpected duration: %s", result.Duration)
+	}
+}
+
+func TestRunRequiresFunction(t *testing.T) {
+	if _, err := (Workflow{}).Run(Request{}); err != errFunctionRequired {
+		t.Fatalf("expected errFunctionRequired, got %v", err)
+	}
+}
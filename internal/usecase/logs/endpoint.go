@@ -0,0 +1,56 @@
+// Where: cli/internal/usecase/logs/endpoint.go
+// What: VictoriaLogs endpoint discovery for logs.
+// Why: Find the published VictoriaLogs port of the running stack.
+package logs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/poruru-code/esb-cli/internal/constants"
+	"github.com/poruru-code/esb-cli/internal/infra/compose"
+)
+
+var errVictoriaLogsNotFound = errors.New("running victorialogs service not found (deploy first or pass --url)")
+
+// resolveURL picks the VictoriaLogs endpoint in priority order:
+// explicit URL, VICTORIALOGS_URL, discovered port, PORT_VICTORIALOGS.
+func (w Workflow) resolveURL(ctx context.Context, req Request) (string, error) {
+	if value := strings.TrimSpace(req.URL); value != "" {
+		return strings.TrimRight(value, "/"), nil
+	}
+	if value := strings.TrimSpace(os.Getenv(constants.EnvVictoriaLogsURL)); value != "" {
+		return strings.TrimRight(value, "/"), nil
+	}
+	port, err := w.discoverPort(ctx, req.ComposeProject)
+	if err != nil {
+		return "", err
+	}
+	if port == 0 {
+		if value, convErr := strconv.Atoi(strings.TrimSpace(os.Getenv(constants.EnvPortVictoriaLogs))); convErr == nil && value > 0 {
+			port = value
+		}
+	}
+	if port == 0 {
+		return "", errVictoriaLogsNotFound
+	}
+	return fmt.Sprintf("http://localhost:%d", port), nil
+}
+
+func (w Workflow) discoverPort(ctx context.Context, composeProject string) (int, error) {
+	if w.DockerClient == nil {
+		return 0, nil
+	}
+	client, err := w.DockerClient()
+	if err != nil {
+		return 0, fmt.Errorf("create docker client: %w", err)
+	}
+	if client == nil {
+		return 0, nil
+	}
+	return compose.DiscoverServicePort(ctx, client, composeProject, constants.EnvPortVictoriaLogs)
+}
@@ -0,0 +1,211 @@
+// Where: cli/internal/usecase/logs/logs.go
+// What: Function log queries against the stack's VictoriaLogs service.
+// Why: Read function logs from the CLI instead of the VictoriaLogs web UI.
+package logs
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/poruru-code/esb-cli/internal/infra/compose"
+)
+
+var errHTTPClientNotConfigured = errors.New("http client is not configured")
+
+// Log fields written by the ESB runtime.
+const (
+	FieldTime      = "_time"
+	FieldMessage   = "_msg"
+	FieldFunction  = "function_name"
+	FieldRequestID = "request_id"
+	FieldLevel     = "level"
+	FieldContainer = "container_name"
+)
+
+const defaultLimit = 1000
+
+// DockerClientFactory constructs Docker SDK clients used for port discovery.
+type DockerClientFactory func() (compose.DockerClient, error)
+
+// HTTPDoer sends queries to VictoriaLogs.
+type HTTPDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Request captures the log query inputs.
+type Request struct {
+	Function  string
+	RequestID string
+	// Filter is a raw LogsQL expression ANDed with the other conditions.
+	Filter         string
+	Since          time.Duration
+	Limit          int
+	Follow         bool
+	ComposeProject string
+	// URL skips discovery when set.
+	URL string
+}
+
+// Entry is a single log line returned by VictoriaLogs.
+type Entry struct {
+	Time      time.Time
+	Function  string
+	RequestID string
+	Level     string
+	Message   string
+	Fields    map[string]string
+}
+
+// Workflow queries VictoriaLogs for function logs.
+type Workflow struct {
+	DockerClient DockerClientFactory
+	HTTPClient   HTTPDoer
+}
+
+// Run queries matching entries and passes them to sink in time order.
+// With req.Follow it keeps streaming new entries until ctx is cancelled.
+func (w Workflow) Run(ctx context.Context, req Request, sink func(Entry)) error {
+	if w.HTTPClient == nil {
+		return errHTTPClientNotConfigured
+	}
+	baseURL, err := w.resolveURL(ctx, req)
+	if err != nil {
+		return err
+	}
+	if req.Follow {
+		return w.tail(ctx, baseURL, req, sink)
+	}
+	return w.query(ctx, baseURL, req, sink)
+}
+
+func (w Workflow) query(ctx context.Context, baseURL string, req Request, sink func(Entry)) error {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultLimit
+	}
+	query := BuildQuery(req, true) + fmt.Sprintf(" | sort by (_time) desc | limit %d", limit)
+	values := url.Values{"query": {query}}
+	entries := []Entry{}
+	err := w.stream(ctx, baseURL+"/select/logsql/query", values, func(entry Entry) {
+		entries = append(entries, entry)
+	})
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Time.Before(entries[j].Time)
+	})
+	for _, entry := range entries {
+		sink(entry)
+	}
+	return nil
+}
+
+func (w Workflow) tail(ctx context.Context, baseURL string, req Request, sink func(Entry)) error {
+	values := url.Values{"query": {BuildQuery(req, false)}}
+	if req.Since > 0 {
+		values.Set("start_offset", formatDuration(req.Since))
+	}
+	err := w.stream(ctx, baseURL+"/select/logsql/tail", values, sink)
+	if err != nil && ctx.Err() != nil {
+		return nil
+	}
+	return err
+}
+
+func (w Workflow) stream(ctx context.Context, endpoint string, values url.Values, sink func(Entry)) error {
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(values.Encode()))
+	if err != nil {
+		return fmt.Errorf("build logs request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := w.HTTPClient.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("query logs: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("query logs: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		entry, err := parseEntry([]byte(line))
+		if err != nil {
+			return err
+		}
+		sink(entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read logs: %w", err)
+	}
+	return nil
+}
+
+// BuildQuery renders req as a LogsQL filter. withTime adds the _time
+// filter for one-shot queries; live tailing uses start_offset instead.
+func BuildQuery(req Request, withTime bool) string {
+	parts := []string{}
+	if withTime && req.Since > 0 {
+		parts = append(parts, FieldTime+":"+formatDuration(req.Since))
+	}
+	if value := strings.TrimSpace(req.Function); value != "" {
+		parts = append(parts, FieldFunction+":="+strconv.Quote(value))
+	}
+	if value := strings.TrimSpace(req.RequestID); value != "" {
+		parts = append(parts, FieldRequestID+":="+strconv.Quote(value))
+	}
+	if value := strings.TrimSpace(req.Filter); value != "" {
+		parts = append(parts, "("+value+")")
+	}
+	if len(parts) == 0 {
+		return "*"
+	}
+	return strings.Join(parts, " ")
+}
+
+func formatDuration(value time.Duration) string {
+	seconds := int64(value / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+	return fmt.Sprintf("%ds", seconds)
+}
+
+func parseEntry(line []byte) (Entry, error) {
+	fields := map[string]string{}
+	if err := json.Unmarshal(line, &fields); err != nil {
+		return Entry{}, fmt.Errorf("decode log entry: %w", err)
+	}
+	entry := Entry{
+		Function:  fields[FieldFunction],
+		RequestID: fields[FieldRequestID],
+		Level:     fields[FieldLevel],
+		Message:   fields[FieldMessage],
+		Fields:    fields,
+	}
+	if entry.Function == "" {
+		entry.Function = strings.TrimPrefix(fields[FieldContainer], "/")
+	}
+	if raw := fields[FieldTime]; raw != "" {
+		if parsed, err := time.Parse(time.RFC3339Nano, raw); err == nil {
+			entry.Time = parsed
+		}
+	}
+	return entry, nil
+}
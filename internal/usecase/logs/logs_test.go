@@ -0,0 +1,138 @@
+// Where: cli/internal/usecase/logs/logs_test.go
+// What: Tests for VictoriaLogs queries.
+// Why: Keep LogsQL rendering and entry ordering stable.
+package logs
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildQuery(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      Request
+		withTime bool
+		want     string
+	}{
+		{name: "empty", req: Request{}, withTime: true, want: "*"},
+		{
+			name:     "all filters",
+			req:      Request{Function: "hello", RequestID: "abc", Filter: "error OR panic", Since: 10 * time.Minute},
+			withTime: true,
+			want:     `_time:600s function_name:="hello" request_id:="abc" (error OR panic)`,
+		},
+		{
+			name:     "tail skips time",
+			req:      Request{Function: "hello", Since: time.Minute},
+			withTime: false,
+			want:     `function_name:="hello"`,
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			if got := BuildQuery(tc.req, tc.withTime); got != tc.want {
+				t.Fatalf("BuildQuery() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRunQueryReturnsEntriesInTimeOrder(t *testing.T) {
+	var gotPath string
+	var gotForm url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		body, _ := io.ReadAll(r.Body)
+		gotForm, _ = url.ParseQuery(string(body))
+		_, _ = io.WriteString(w, strings.Join([]string{
+			`{"_time":"2026-01-01T00:00:02Z","_msg":"second","function_name":"hello","request_id":"r1"}`,
+			`{"_time":"2026-01-01T00:00:01Z","_msg":"first","container_name":"/esb-hello"}`,
+		}, "\n"))
+	}))
+	defer server.Close()
+
+	entries := []Entry{}
+	err := Workflow{HTTPClient: server.Client()}.Run(
+		context.Background(),
+		Request{Function: "hello", Since: time.Minute, Limit: 5, URL: server.URL},
+		func(entry Entry) { entries = append(entries, entry) },
+	)
+	if err != nil {
+		t.Fatalf("run: %v", err)
+	}
+	if gotPath != "/select/logsql/query" {
+		t.Fatalf("unexpected path: %q", gotPath)
+	}
+	if want := `_time:60s function_name:="hello" | sort by (_time) desc | limit 5`; gotForm.Get("query") != want {
+		t.Fatalf("unexpected query: %q", gotForm.Get("query"))
+	}
+	if len(entries) != 2 || entries[0].Message != "first" || entries[1].Message != "second" {
+		t.Fatalf("unexpected entries: %#v", entries)
+	}
+	if entries[0].Function != "esb-hello" {
+		t.Fatalf("expected container name fallback, got %q", entries[0].Function)
+	}
+	if entries[1].RequestID != "r1" {
+		t.Fatalf("unexpected request id: %q", entries[1].RequestID)
+	}
+}
+
+func TestRunFollowStopsOnCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/select/logsql/tail" {
+			http.NotFound(w, r)
+			return
+		}
+		_ = r.ParseForm()
+		if r.Form.Get("start_offset") != "300s" {
+			http.Error(w, "missing start_offset", http.StatusBadRequest)
+			return
+		}
+		_, _ = io.WriteString(w, `{"_time":"2026-01-01T00:00:01Z","_msg":"live"}`+"\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	got := []string{}
+	err := Workflow{HTTPClient: server.Client()}.Run(
+		ctx,
+		Request{Follow: true, Since: 5 * time.Minute, URL: server.URL},
+		func(entry Entry) {
+			got = append(got, entry.Message)
+			cancel()
+		},
+	)
+	if err != nil {
+		t.Fatalf("expected nil error after cancel, got %v", err)
+	}
+	if len(got) != 1 || got[0] != "live" {
+		t.Fatalf("unexpected entries: %v", got)
+	}
+}
+
+func TestRunReportsServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "cannot parse query", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	err := Workflow{HTTPClient: server.Client()}.Run(
+		context.Background(),
+		Request{Filter: "((", URL: server.URL},
+		func(Entry) {},
+	)
+	if err == nil || !strings.Contains(err.Error(), "cannot parse query") {
+		t.Fatalf("expected server error, got %v", err)
+	}
+}
@@ -0,0 +1,40 @@
+// Where: cli/internal/usecase/stack/down.go
+// What: Stop and remove a stack's containers with docker compose.
+// Why: Tear down the exact compose file set the project was started with.
+package stack
+
+import (
+	"context"
+	"fmt"
+)
+
+// DownRequest captures stack stop inputs.
+type DownRequest struct {
+	Request
+	// Volumes also removes named volumes (database, object storage, logs).
+	Volumes bool
+}
+
+// Down runs `docker compose down` with the compose files recorded on the
+// project's containers (or the mode compose files when none are running).
+func (w Workflow) Down(ctx context.Context, req DownRequest) error {
+	if w.Runner == nil {
+		return errRunnerNotConfigured
+	}
+	project, err := req.project()
+	if err != nil {
+		return err
+	}
+	files, err := w.runningComposeFiles(ctx, req.Request)
+	if err != nil {
+		return err
+	}
+	args := append(composeArgs(project, files), "down", "--remove-orphans")
+	if req.Volumes {
+		args = append(args, "--volumes")
+	}
+	if err := w.Runner.Run(ctx, req.RepoRoot, "docker", args...); err != nil {
+		return fmt.Errorf("stop stack %s: %w", project, err)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+// Where: cli/internal/usecase/stack/stack.go
+// What: Shared stack lifecycle types and compose argument helpers.
+// Why: Start, stop and inspect a project-env stack without raw docker compose.
+package stack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/poruru-code/esb-cli/internal/domain/state"
+	"github.com/poruru-code/esb-cli/internal/infra/compose"
+)
+
+const infraComposeFile = "docker-compose.infra.yml"
+
+var (
+	errRunnerNotConfigured = errors.New("command runner is not configured")
+	errProjectRequired     = errors.New("compose project is required")
+)
+
+// DockerClientFactory constructs Docker SDK clients used for status queries.
+type DockerClientFactory func() (compose.DockerClient, error)
+
+// Workflow runs stack lifecycle operations.
+type Workflow struct {
+	Runner       compose.CommandRunner
+	DockerClient DockerClientFactory
+	// ApplyRuntimeEnv exports ENV/MODE/PROJECT_NAME and port defaults for compose.
+	ApplyRuntimeEnv func(state.Context) error
+	// PollInterval is the health polling interval (default 2s).
+	PollInterval time.Duration
+}
+
+// Request identifies the stack.
+type Request struct {
+	RepoRoot string
+	Project  string
+	Env      string
+	Mode     string
+	// ComposeFiles overrides the mode compose files.
+	ComposeFiles []string
+}
+
+// Service is one compose service container of a stack.
+type Service struct {
+	Name      string `json:"service"`
+	Container string `json:"container"`
+	State     string `json:"state"`
+	Health    string `json:"health,omitempty"`
+	Status    string `json:"status"`
+	Ports     []Port `json:"ports,omitempty"`
+}
+
+// Port is a published container port. EnvVar names the DefaultPortMappings
+// entry the port is discovered as, when there is one.
+type Port struct {
+	HostIP        string `json:"host_ip,omitempty"`
+	HostPort      int    `json:"host_port"`
+	ContainerPort int    `json:"container_port"`
+	Protocol      string `json:"protocol"`
+	EnvVar        string `json:"env_var,omitempty"`
+}
+
+func (r Request) project() (string, error) {
+	project := strings.TrimSpace(r.Project)
+	if project == "" {
+		return "", errProjectRequired
+	}
+	return project, nil
+}
+
+// upComposeFiles returns explicit compose files, or the mode compose file plus
+// docker-compose.infra.yml when it exists.
+func upComposeFiles(req Request) ([]string, error) {
+	if files := trimmedFiles(req.ComposeFiles); len(files) > 0 {
+		return files, nil
+	}
+	mode := strings.TrimSpace(req.Mode)
+	if mode == "" {
+		mode = compose.ModeDocker
+	}
+	files, err := compose.ResolveComposeFiles(req.RepoRoot, mode, "")
+	if err != nil {
+		return nil, err
+	}
+	infraFile := filepath.Join(req.RepoRoot, infraComposeFile)
+	if _, err := os.Stat(infraFile); err == nil {
+		files = append(files, infraFile)
+	}
+	return files, nil
+}
+
+// runningComposeFiles returns the config files recorded on the project's
+// containers, falling back to upComposeFiles.
+func (w Workflow) runningComposeFiles(ctx context.Context, req Request) ([]string, error) {
+	if files := trimmedFiles(req.ComposeFiles); len(files) > 0 {
+		return files, nil
+	}
+	if w.DockerClient != nil {
+		if client, err := w.DockerClient(); err == nil && client != nil {
+			result, err := compose.ResolveComposeFilesFromProject(ctx, client, req.Project)
+			if err == nil && len(result.Files) > 0 && len(result.Missing) == 0 {
+				return result.Files, nil
+			}
+		}
+	}
+	if strings.TrimSpace(req.RepoRoot) == "" {
+		return nil, nil
+	}
+	return upComposeFiles(req)
+}
+
+func composeArgs(project string, files []string) []string {
+	args := []string{"compose", "-p", project}
+	for _, file := range files {
+		args = append(args, "-f", file)
+	}
+	return args
+}
+
+func trimmedFiles(files []string) []string {
+	out := make([]string, 0, len(files))
+	seen := map[string]struct{}{}
+	for _, file := range files {
+		trimmed := strings.TrimSpace(file)
+		if trimmed == "" {
+			continue
+		}
+		if _, ok := seen[trimmed]; ok {
+			continue
+		}
+		seen[trimmed] = struct{}{}
+		out = append(out, trimmed)
+	}
+	return out
+}
+
+func (w Workflow) dockerClient() (compose.DockerClient, error) {
+	if w.DockerClient == nil {
+		return nil, fmt.Errorf("docker client factory is not configured")
+	}
+	client, err := w.DockerClient()
+	if err != nil {
+		return nil, fmt.Errorf("create docker client: %w", err)
+	}
+	if client == nil {
+		return nil, fmt.Errorf("docker client factory returned nil client")
+	}
+	return client, nil
+}
@@ -0,0 +1,233 @@
+// Where: cli/internal/usecase/stack/stack_test.go
+// What: Tests for stack up/down/status.
+// Why: Keep compose arguments, health waiting and port reporting stable.
+package stack
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/volume"
+	"github.com/poruru-code/esb-cli/internal/constants"
+	"github.com/poruru-code/esb-cli/internal/domain/state"
+	"github.com/poruru-code/esb-cli/internal/infra/compose"
+)
+
+type stackDockerClient struct {
+	// polls returns successive ContainerList results; the last one repeats.
+	polls [][]container.Summary
+	calls int
+}
+
+func (c *stackDockerClient) ContainerList(_ context.Context, opts container.ListOptions) ([]container.Summary, error) {
+	idx := c.calls
+	if idx >= len(c.polls) {
+		idx = len(c.polls) - 1
+	}
+	c.calls++
+	out := []container.Summary{}
+	for _, ctr := range c.polls[idx] {
+		matched := true
+		for _, label := range opts.Filters.Get("label") {
+			key, value, _ := strings.Cut(label, "=")
+			if ctr.Labels[key] != value {
+				matched = false
+			}
+		}
+		if matched {
+			out = append(out, ctr)
+		}
+	}
+	return out, nil
+}
+
+func (c *stackDockerClient) ContainerInspect(context.Context, string) (container.InspectResponse, error) {
+	return container.InspectResponse{}, nil
+}
+
+func (c *stackDockerClient) ImageList(context.Context, image.ListOptions) ([]image.Summary, error) {
+	return nil, nil
+}
+
+func (c *stackDockerClient) ContainerStop(context.Context, string, container.StopOptions) error {
+	return nil
+}
+
+func (c *stackDockerClient) ContainerRemove(context.Context, string, container.RemoveOptions) error {
+	return nil
+}
+
+func (c *stackDockerClient) ContainersPrune(context.Context, filters.Args) (container.PruneReport, error) {
+	return container.PruneReport{}, nil
+}
+
+func (c *stackDockerClient) ImagesPrune(context.Context, filters.Args) (image.PruneReport, error) {
+	return image.PruneReport{}, nil
+}
+
+func (c *stackDockerClient) NetworksPrune(context.Context, filters.Args) (network.PruneReport, error) {
+	return network.PruneReport{}, nil
+}
+
+func (c *stackDockerClient) VolumesPrune(context.Context, filters.Args) (volume.PruneReport, error) {
+	return volume.PruneReport{}, nil
+}
+
+type recordRunner struct {
+	commands []string
+}
+
+func (r *recordRunner) Run(_ context.Context, _, name string, args ...string) error {
+	r.commands = append(r.commands, strings.Join(append([]string{name}, args...), " "))
+	return nil
+}
+
+func (r *recordRunner) RunQuiet(ctx context.Context, dir, name string, args ...string) error {
+	return r.Run(ctx, dir, name, args...)
+}
+
+func (r *recordRunner) RunOutput(context.Context, string, string, ...string) ([]byte, error) {
+	return nil, nil
+}
+
+func stackContainer(service, state, status string, ports ...container.Port) container.Summary {
+	return container.Summary{
+		Names:  []string{"/esb-dev-" + service},
+		State:  state,
+		Status: status,
+		Ports:  ports,
+		Labels: map[string]string{
+			compose.ComposeProjectLabel: "esb-dev",
+			compose.ComposeServiceLabel: service,
+		},
+	}
+}
+
+func newWorkflow(runner *recordRunner, client *stackDockerClient) Workflow {
+	return Workflow{
+		Runner:       runner,
+		DockerClient: func() (compose.DockerClient, error) { return client, nil },
+		PollInterval: time.Millisecond,
+	}
+}
+
+func TestUpStartsStackAndWaitsForHealth(t *testing.T) {
+	repoRoot := t.TempDir()
+	for _, name := range []string{"docker-compose.docker.yml", infraComposeFile} {
+		if err := os.WriteFile(filepath.Join(repoRoot, name), []byte("services: {}\n"), 0o600); err != nil {
+			t.Fatalf("write %s: %v", name, err)
+		}
+	}
+	client := &stackDockerClient{polls: [][]container.Summary{
+		{stackContainer("gateway", "running", "Up 1 second (health: starting)")},
+		{
+			stackContainer("gateway", "running", "Up 3 seconds (healthy)",
+				container.Port{IP: "0.0.0.0", PrivatePort: 8443, PublicPort: 443, Type: "tcp"}),
+			stackContainer("agent", "running", "Up 3 seconds"),
+		},
+	}}
+	runner := &recordRunner{}
+	var applied state.Context
+	workflow := newWorkflow(runner, client)
+	workflow.ApplyRuntimeEnv = func(ctx state.Context) error {
+		applied = ctx
+		return nil
+	}
+
+	services, err := workflow.Up(context.Background(), UpRequest{
+		Request: Request{RepoRoot: repoRoot, Project: "esb-dev", Env: "dev", Mode: "docker"},
+		Wait:    true,
+		Timeout: time.Second,
+	})
+	if err != nil {
+		t.Fatalf("up: %v", err)
+	}
+	want := "docker compose -p esb-dev -f " + filepath.Join(repoRoot, "docker-compose.docker.yml") +
+		" -f " + filepath.Join(repoRoot, infraComposeFile) + " up -d"
+	if !reflect.DeepEqual(runner.commands, []string{want}) {
+		t.Fatalf("unexpected commands: %v", runner.commands)
+	}
+	if applied.Env != "dev" || applied.ComposeProject != "esb-dev" || applied.ProjectDir != repoRoot {
+		t.Fatalf("unexpected runtime env context: %#v", applied)
+	}
+	if client.calls != 2 || len(services) != 2 || services[0].Name != "agent" {
+		t.Fatalf("unexpected services after %d polls: %#v", client.calls, services)
+	}
+	gateway := services[1]
+	if gateway.Health != HealthHealthy || len(gateway.Ports) != 1 ||
+		gateway.Ports[0].HostPort != 443 || gateway.Ports[0].EnvVar != constants.EnvPortGatewayHTTPS {
+		t.Fatalf("unexpected gateway status: %#v", gateway)
+	}
+}
+
+func TestUpFailsFastAndTimesOut(t *testing.T) {
+	repoRoot := t.TempDir()
+	request := Request{RepoRoot: repoRoot, Project: "esb-dev", Mode: "docker"}
+
+	failing := &stackDockerClient{polls: [][]container.Summary{
+		{stackContainer("database", "exited", "Exited (1) 2 seconds ago")},
+	}}
+	_, err := newWorkflow(&recordRunner{}, failing).Up(context.Background(), UpRequest{Request: request, Wait: true})
+	if err == nil || !strings.Contains(err.Error(), "services failed to start: database (exited)") {
+		t.Fatalf("expected fail-fast error, got %v", err)
+	}
+
+	unhealthy := &stackDockerClient{polls: [][]container.Summary{
+		{stackContainer("gateway", "running", "Up 1 minute (unhealthy)")},
+	}}
+	_, err = newWorkflow(&recordRunner{}, unhealthy).Up(context.Background(), UpRequest{
+		Request: request,
+		Wait:    true,
+		Timeout: 20 * time.Millisecond,
+	})
+	if err == nil || !strings.Contains(err.Error(), "timed out waiting for services: gateway (unhealthy)") {
+		t.Fatalf("expected timeout error, got %v", err)
+	}
+}
+
+func TestDownUsesComposeFilesFromRunningProject(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "docker-compose.containerd.yml")
+	if err := os.WriteFile(configFile, []byte("services: {}\n"), 0o600); err != nil {
+		t.Fatalf("write compose file: %v", err)
+	}
+	ctr := stackContainer("gateway", "running", "Up 1 minute")
+	ctr.Labels[compose.ComposeConfigFilesLabel] = configFile
+	client := &stackDockerClient{polls: [][]container.Summary{{ctr}}}
+	runner := &recordRunner{}
+
+	err := newWorkflow(runner, client).Down(context.Background(), DownRequest{
+		Request: Request{Project: "esb-dev"},
+		Volumes: true,
+	})
+	if err != nil {
+		t.Fatalf("down: %v", err)
+	}
+	want := "docker compose -p esb-dev -f " + configFile + " down --remove-orphans --volumes"
+	if !reflect.DeepEqual(runner.commands, []string{want}) {
+		t.Fatalf("unexpected commands: %v", runner.commands)
+	}
+}
+
+func TestHealthFromStatus(t *testing.T) {
+	cases := map[string]string{
+		"Up 2 minutes (healthy)":          HealthHealthy,
+		"Up 3 seconds (health: starting)": HealthStarting,
+		"Up 1 hour (unhealthy)":           HealthUnhealthy,
+		"Up 5 minutes":                    "",
+		"Exited (0) 1 minute ago":         "",
+	}
+	for status, want := range cases {
+		if got := healthFromStatus(status); got != want {
+			t.Fatalf("%q: expected %q, got %q", status, want, got)
+		}
+	}
+}
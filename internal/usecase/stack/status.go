@@ -0,0 +1,131 @@
+// Where: cli/internal/usecase/stack/status.go
+// What: Service, health and published port listing for a compose project.
+// Why: Show what is running without reading raw `docker compose ps` output.
+package stack
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/poruru-code/esb-cli/internal/infra/compose"
+)
+
+// Health values reported by Docker in the container status text.
+const (
+	HealthHealthy   = "healthy"
+	HealthUnhealthy = "unhealthy"
+	HealthStarting  = "starting"
+)
+
+// Status lists the service containers of the project, sorted by service name.
+func (w Workflow) Status(ctx context.Context, req Request) ([]Service, error) {
+	project, err := req.project()
+	if err != nil {
+		return nil, err
+	}
+	client, err := w.dockerClient()
+	if err != nil {
+		return nil, err
+	}
+	filterArgs := filters.NewArgs()
+	filterArgs.Add("label", fmt.Sprintf("%s=%s", compose.ComposeProjectLabel, project))
+	containers, err := client.ContainerList(ctx, container.ListOptions{All: true, Filters: filterArgs})
+	if err != nil {
+		return nil, fmt.Errorf("list containers: %w", err)
+	}
+	services := make([]Service, 0, len(containers))
+	for _, ctr := range containers {
+		services = append(services, serviceFromContainer(ctr))
+	}
+	sort.SliceStable(services, func(i, j int) bool {
+		if services[i].Name != services[j].Name {
+			return services[i].Name < services[j].Name
+		}
+		return services[i].Container < services[j].Container
+	})
+	return services, nil
+}
+
+func serviceFromContainer(ctr container.Summary) Service {
+	name := strings.TrimSpace(ctr.Labels[compose.ComposeServiceLabel])
+	service := Service{
+		Name:      name,
+		Container: compose.PrimaryContainerName(ctr.Names),
+		State:     strings.ToLower(strings.TrimSpace(ctr.State)),
+		Health:    healthFromStatus(ctr.Status),
+		Status:    strings.TrimSpace(ctr.Status),
+	}
+	for _, port := range ctr.Ports {
+		if port.PublicPort == 0 {
+			continue
+		}
+		service.Ports = append(service.Ports, Port{
+			HostIP:        port.IP,
+			HostPort:      int(port.PublicPort),
+			ContainerPort: int(port.PrivatePort),
+			Protocol:      port.Type,
+			EnvVar:        portEnvVar(name, int(port.PrivatePort)),
+		})
+	}
+	sort.SliceStable(service.Ports, func(i, j int) bool {
+		if service.Ports[i].ContainerPort != service.Ports[j].ContainerPort {
+			return service.Ports[i].ContainerPort < service.Ports[j].ContainerPort
+		}
+		return service.Ports[i].HostIP < service.Ports[j].HostIP
+	})
+	return service
+}
+
+// healthFromStatus extracts the health from "Up 2 minutes (healthy)" or
+// "Up 3 seconds (health: starting)". It is empty without a healthcheck.
+func healthFromStatus(status string) string {
+	open := strings.LastIndex(status, "(")
+	if open < 0 || !strings.HasSuffix(status, ")") {
+		return ""
+	}
+	value := strings.TrimPrefix(status[open+1:len(status)-1], "health: ")
+	switch value {
+	case HealthHealthy, HealthUnhealthy, HealthStarting:
+		return value
+	default:
+		return ""
+	}
+}
+
+func portEnvVar(service string, containerPort int) string {
+	for _, mapping := range compose.DefaultPortMappings {
+		if mapping.Service == service && mapping.ContainerPort == containerPort {
+			return mapping.EnvVar
+		}
+	}
+	return ""
+}
+
+// Ready reports whether the service is running and healthy (or has no
+// healthcheck), or is a one-shot container that exited successfully.
+func (s Service) Ready() bool {
+	if s.State == "exited" {
+		return s.exitedCleanly()
+	}
+	return s.State == "running" && (s.Health == "" || s.Health == HealthHealthy)
+}
+
+// Failed reports whether the service cannot become ready without a restart.
+func (s Service) Failed() bool {
+	switch s.State {
+	case "dead":
+		return true
+	case "exited":
+		return !s.exitedCleanly()
+	default:
+		return false
+	}
+}
+
+func (s Service) exitedCleanly() bool {
+	return strings.HasPrefix(s.Status, "Exited (0)")
+}
@@ -0,0 +1,117 @@
+// Where: cli/internal/usecase/stack/up.go
+// What: Start a stack with docker compose and wait for service health.
+// Why: Deploy needs gateway and agent running; starting them was manual.
+package stack
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/poruru-code/esb-cli/internal/domain/state"
+)
+
+const defaultPollInterval = 2 * time.Second
+
+// UpRequest captures stack start inputs.
+type UpRequest struct {
+	Request
+	// Wait polls until every service is ready; Timeout bounds the wait.
+	Wait    bool
+	Timeout time.Duration
+	Build   bool
+}
+
+// Up runs `docker compose up -d` for the project and, with Wait, polls the
+// services until they are running and healthy.
+func (w Workflow) Up(ctx context.Context, req UpRequest) ([]Service, error) {
+	if w.Runner == nil {
+		return nil, errRunnerNotConfigured
+	}
+	project, err := req.project()
+	if err != nil {
+		return nil, err
+	}
+	if w.ApplyRuntimeEnv != nil {
+		if err := w.ApplyRuntimeEnv(state.Context{
+			ProjectDir:     req.RepoRoot,
+			Env:            req.Env,
+			Mode:           req.Mode,
+			ComposeProject: project,
+		}); err != nil {
+			return nil, err
+		}
+	}
+	files, err := upComposeFiles(req.Request)
+	if err != nil {
+		return nil, err
+	}
+	args := append(composeArgs(project, files), "up", "-d")
+	if req.Build {
+		args = append(args, "--build")
+	}
+	if err := w.Runner.Run(ctx, req.RepoRoot, "docker", args...); err != nil {
+		return nil, fmt.Errorf("start stack %s: %w", project, err)
+	}
+	if !req.Wait {
+		return w.Status(ctx, req.Request)
+	}
+	return w.waitReady(ctx, req.Request, req.Timeout)
+}
+
+// waitReady polls Status until every service is ready. It fails early when a
+// service exits with an error, and reports pending services on timeout.
+func (w Workflow) waitReady(ctx context.Context, req Request, timeout time.Duration) ([]Service, error) {
+	interval := w.PollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	var deadline <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+	for {
+		services, err := w.Status(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		if failed := servicesMatching(services, Service.Failed); len(failed) > 0 {
+			return services, fmt.Errorf("services failed to start: %s", strings.Join(failed, ", "))
+		}
+		pending := servicesMatching(services, func(s Service) bool { return !s.Ready() })
+		if len(services) > 0 && len(pending) == 0 {
+			return services, nil
+		}
+		select {
+		case <-ctx.Done():
+			return services, ctx.Err()
+		case <-deadline:
+			if len(services) == 0 {
+				return services, fmt.Errorf("no containers found for project %s", req.Project)
+			}
+			return services, fmt.Errorf("timed out waiting for services: %s", strings.Join(pending, ", "))
+		case <-time.After(interval):
+		}
+	}
+}
+
+func servicesMatching(services []Service, match func(Service) bool) []string {
+	names := []string{}
+	for _, service := range services {
+		if match(service) {
+			label := service.Name
+			if service.Health != "" {
+				label += " (" + service.Health + ")"
+			} else {
+				label += " (" + service.State + ")"
+			}
+			names = append(names, label)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,151 @@
+// Where: cli/internal/usecase/validate/validate.go
+// What: Offline SAM template validation.
+// Why: Report template problems as structured findings without touching Docker.
+package validate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/poruru-code/esb-cli/internal/domain/template"
+	"github.com/poruru-code/esb-cli/internal/infra/sam"
+	"github.com/poruru-code/esb-cli/internal/infra/templategen"
+)
+
+// Severity classifies a finding. Errors fail validation; warnings only fail
+// it in strict mode.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Rule identifiers reported alongside the sam lint rules.
+const (
+	RuleTemplateParse       = "template-parse"
+	RuleParserWarning       = "parser-warning"
+	RuleImageName           = "image-name"
+	RuleCodeURIMissing      = "code-uri-missing"
+	RuleLayerContentMissing = "layer-content-missing"
+)
+
+// Finding is a single validation result tied to a template and resource.
+type Finding struct {
+	Rule      string   `json:"rule"`
+	Severity  Severity `json:"severity"`
+	Template  string   `json:"template"`
+	LogicalID string   `json:"logical_id,omitempty"`
+	Message   string   `json:"message"`
+}
+
+// Report aggregates findings for one or more templates.
+type Report struct {
+	Findings []Finding
+}
+
+// Count returns the number of findings with the given severity.
+func (r Report) Count(severity Severity) int {
+	count := 0
+	for _, finding := range r.Findings {
+		if finding.Severity == severity {
+			count++
+		}
+	}
+	return count
+}
+
+// Failed reports whether the findings should fail validation.
+func (r Report) Failed(strict bool) bool {
+	if r.Count(SeverityError) > 0 {
+		return true
+	}
+	return strict && r.Count(SeverityWarning) > 0
+}
+
+// Input configures validation of a single template.
+type Input struct {
+	TemplatePath string
+	Parameters   map[string]string
+}
+
+// Template validates a single SAM template and returns its findings.
+// Problems in the template are reported as findings, never as errors.
+func Template(input Input) Report {
+	report := Report{}
+	add := func(rule string, severity Severity, logicalID, message string) {
+		report.Findings = append(report.Findings, Finding{
+			Rule:      rule,
+			Severity:  severity,
+			Template:  input.TemplatePath,
+			LogicalID: logicalID,
+			Message:   message,
+		})
+	}
+
+	content, err := os.ReadFile(input.TemplatePath)
+	if err != nil {
+		add(RuleTemplateParse, SeverityError, "", fmt.Sprintf("read template: %v", err))
+		return report
+	}
+	parsed, err := sam.ParseSAMTemplate(string(content), cloneParameters(input.Parameters))
+	if err != nil {
+		add(RuleTemplateParse, SeverityError, "", err.Error())
+		return report
+	}
+	for _, warning := range parsed.Warnings {
+		add(RuleParserWarning, SeverityWarning, "", warning)
+	}
+	if err := template.ApplyImageNames(parsed.Functions); err != nil {
+		add(RuleImageName, SeverityError, "", err.Error())
+	}
+
+	issues, err := sam.LintTemplate(string(content), cloneParameters(input.Parameters))
+	if err != nil {
+		add(RuleTemplateParse, SeverityError, "", err.Error())
+		return report
+	}
+	for _, issue := range issues {
+		severity := SeverityWarning
+		if issue.Rule == sam.LintRuleUnresolvedLayer {
+			severity = SeverityError
+		}
+		add(issue.Rule, severity, issue.LogicalID, issue.Message)
+	}
+
+	baseDir := filepath.Dir(input.TemplatePath)
+	for _, fn := range parsed.Functions {
+		if strings.TrimSpace(fn.ImageSource) != "" || !isLocalPath(fn.CodeURI) {
+			continue
+		}
+		if _, err := os.Stat(templategen.ResolveResourcePath(baseDir, fn.CodeURI)); err != nil {
+			add(RuleCodeURIMissing, SeverityError, fn.LogicalID,
+				fmt.Sprintf("CodeUri %s for function %s does not exist", fn.CodeURI, fn.Name))
+		}
+	}
+	for _, layer := range parsed.Resources.Layers {
+		if !isLocalPath(layer.ContentURI) {
+			continue
+		}
+		if _, err := os.Stat(templategen.ResolveResourcePath(baseDir, layer.ContentURI)); err != nil {
+			add(RuleLayerContentMissing, SeverityError, "",
+				fmt.Sprintf("ContentUri %s for layer %s does not exist", layer.ContentURI, layer.Name))
+		}
+	}
+	return report
+}
+
+func isLocalPath(raw string) bool {
+	trimmed := strings.TrimSpace(raw)
+	return trimmed != "" && !strings.Contains(trimmed, "://")
+}
+
+func cloneParameters(parameters map[string]string) map[string]string {
+	out := make(map[string]string, len(parameters))
+	for key, value := range parameters {
+		out[key] = value
+	}
+	return out
+}
@@ -0,0 +1,122 @@
+// Where: cli/internal/usecase/validate/validate_test.go
+// What: Tests for offline template validation.
+// Why: Keep finding rules and severities stable for CI gating.
+package validate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeTemplate(t *testing.T, dir, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, "template.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+	return path
+}
+
+func TestTemplateReportsFindings(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "functions", "hello"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	path := writeTemplate(t, dir, `
+Resources:
+  AppRule:
+    Type: AWS::Events::Rule
+    Properties: {}
+  HelloFunction:
+    Type: AWS::Serverless::Function
+    Properties:
+      FunctionName: hello
+      CodeUri: functions/hello/
+      Handler: app.handler
+      Runtime: python3.12
+  MissingFunction:
+    Type: AWS::Serverless::Function
+    Properties:
+      FunctionName: missing
+      CodeUri: functions/missing/
+      Handler: app.handler
+      Runtime: python3.12
+      Layers:
+        - UnknownLayer
+        - arn:aws:lambda:us-east-1:123456789012:layer:shared:1
+`)
+
+	report := Template(Input{TemplatePath: path})
+
+	rules := map[string]Severity{}
+	for _, finding := range report.Findings {
+		if finding.Template != path {
+			t.Fatalf("unexpected template in finding: %+v", finding)
+		}
+		rules[finding.Rule] = finding.Severity
+	}
+	expected := map[string]Severity{
+		"unsupported-resource": SeverityWarning,
+		"unresolved-layer":     SeverityError,
+		"external-layer":       SeverityWarning,
+		RuleCodeURIMissing:     SeverityError,
+	}
+	if len(rules) != len(expected) {
+		t.Fatalf("expected rules %v, got %+v", expected, report.Findings)
+	}
+	for rule, severity := range expected {
+		if rules[rule] != severity {
+			t.Fatalf("expected %s with %s, got %+v", rule, severity, report.Findings)
+		}
+	}
+	if !report.Failed(false) {
+		t.Fatal("expected report with errors to fail")
+	}
+}
+
+func TestTemplateImageNameCollision(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "src"), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	path := writeTemplate(t, dir, `
+Resources:
+  First:
+    Type: AWS::Serverless::Function
+    Properties:
+      FunctionName: MyFunc
+      CodeUri: src/
+      Handler: app.handler
+      Runtime: python3.12
+  Second:
+    Type: AWS::Serverless::Function
+    Properties:
+      FunctionName: myfunc
+      CodeUri: src/
+      Handler: app.handler
+      Runtime: python3.12
+`)
+
+	report := Template(Input{TemplatePath: path})
+	if len(report.Findings) != 1 || report.Findings[0].Rule != RuleImageName {
+		t.Fatalf("expected single image-name finding, got %+v", report.Findings)
+	}
+}
+
+func TestTemplateParseFailure(t *testing.T) {
+	report := Template(Input{TemplatePath: filepath.Join(t.TempDir(), "missing.yaml")})
+	if len(report.Findings) != 1 || report.Findings[0].Rule != RuleTemplateParse {
+		t.Fatalf("expected template-parse finding, got %+v", report.Findings)
+	}
+}
+
+func TestReportFailedStrict(t *testing.T) {
+	report := Report{Findings: []Finding{{Rule: RuleParserWarning, Severity: SeverityWarning}}}
+	if report.Failed(false) {
+		t.Fatal("warnings should not fail without strict mode")
+	}
+	if !report.Failed(true) {
+		t.Fatal("warnings should fail in strict mode")
+	}
+}